@@ -0,0 +1,49 @@
+package config
+
+import (
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InitIPExtractor decides how client IPs are read for rate limits and
+// sessions. Without TRUSTED_PROXIES the address of the connection is used, so
+// clients cannot pick their IP with X-Forwarded-For. Behind a reverse proxy,
+// list its addresses or CIDR ranges there, for example "10.0.0.0/8,172.17.0.1".
+func InitIPExtractor() echo.IPExtractor {
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Printf("Skipping invalid trusted proxy %q in TRUSTED_PROXIES", proxy)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	if len(options) == 3 {
+		return echo.ExtractIPDirect()
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"kreasi-nusantara-api/utils/oidc"
+	"log"
+	"os"
+	"strings"
+)
+
+// defaultIssuers are the issuers of well-known providers, other providers
+// need OIDC_<NAME>_ISSUER
+var defaultIssuers = map[string]string{
+	"google": "https://accounts.google.com",
+}
+
+// InitConfigOIDC reads the social login providers listed in OIDC_PROVIDERS,
+// for example "google,keycloak", from OIDC_<NAME>_* environment variables
+func InitConfigOIDC() []oidc.Config {
+	var configs []oidc.Config
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+
+		if config.Issuer == "" {
+			config.Issuer = defaultIssuers[name]
+		}
+		if len(config.Scopes) == 0 {
+			config.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		if config.Issuer == "" || config.ClientID == "" || config.ClientSecret == "" || config.RedirectURL == "" {
+			log.Printf("Skipping OIDC provider %s, set %sISSUER, %sCLIENT_ID, %sCLIENT_SECRET and %sREDIRECT_URL", name, prefix, prefix, prefix, prefix)
+			continue
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs
+}
@@ -21,6 +21,15 @@ const (
 	UNAUTHORIZED            = "unauthorized user"
 	FAILED_GENERATE_TOKEN   = "failed to generate token!"
 	FAILED_INVALIDATE_TOKEN = "failed to invalidate token!"
+	INVALID_REFRESH_TOKEN   = "invalid or expired refresh token!"
+	REFRESH_TOKEN_REUSED    = "refresh token was already used, please log in again!"
+	FAILED_REFRESH_TOKEN    = "failed to refresh token!"
+	FAILED_LOGOUT           = "failed to log out!"
+	SESSION_REVOKED         = "session has been logged out"
+	SESSION_NOT_FOUND       = "session not found!"
+	FAILED_GET_SESSIONS     = "failed to get sessions!"
+	FAILED_REVOKE_SESSION   = "failed to revoke session!"
+	TOO_MANY_ATTEMPTS       = "too many attempts, please try again later!"
 
 	// Forbidden
 	FORBIDDEN_RESOURCE = "Forbidden	resource!"
@@ -49,6 +58,48 @@ const (
 	FAILED_UPDATE_USER_ADDRESSES = "failed to update user addresses!"
 	FAILED_DELETE_USER_ADDRESSES = "failed to delete user addresses!"
 	FAILED_CHANGE_PASSWORD       = "failed to change password!"
+	EMAIL_NOT_VERIFIED           = "email is not verified, enter the code sent to your email!"
+	EMAIL_ALREADY_VERIFIED       = "email is already verified!"
+	FAILED_RESEND_OTP            = "failed to resend otp!"
+	SAME_EMAIL                   = "new email is the same as the current one!"
+	NO_EMAIL_CHANGE              = "no email change is pending, request a new code!"
+	PHONE_NOT_SET                = "add a phone number to your profile first!"
+	PHONE_ALREADY_VERIFIED       = "phone number is already verified!"
+	FAILED_CHANGE_EMAIL          = "failed to change email!"
+	FAILED_SEND_PHONE_OTP        = "failed to send phone verification code!"
+	FAILED_VERIFY_PHONE          = "failed to verify phone number!"
+	INVALID_EXPORT_FORMAT        = "export format must be zip or json!"
+	FAILED_EXPORT_DATA           = "failed to export user data!"
+
+	// Social Login
+	UNKNOWN_OIDC_PROVIDER        = "unknown login provider!"
+	INVALID_OIDC_STATE           = "login request is invalid or expired, please try again!"
+	OIDC_LOGIN_FAILED            = "provider rejected the login, please try again!"
+	OIDC_EMAIL_NOT_VERIFIED      = "provider has not verified your email address!"
+	IDENTITY_ALREADY_LINKED      = "this account is already linked!"
+	IDENTITY_NOT_FOUND           = "linked account not found!"
+	LAST_LOGIN_METHOD            = "cannot unlink your only way to log in, set a password first!"
+	FAILED_OIDC_LOGIN            = "failed to log in with provider!"
+	FAILED_GET_AUTHORIZATION_URL = "failed to start login with provider!"
+	FAILED_GET_IDENTITIES        = "failed to get linked accounts!"
+	FAILED_LINK_IDENTITY         = "failed to link account!"
+	FAILED_UNLINK_IDENTITY       = "failed to unlink account!"
+
+	// Two-Factor Authentication
+	INVALID_TWO_FACTOR_CODE          = "invalid two-factor code!"
+	INVALID_TWO_FACTOR_CHALLENGE     = "login attempt expired, please log in again!"
+	TWO_FACTOR_ALREADY_ENABLED       = "two-factor authentication is already enabled!"
+	TWO_FACTOR_NOT_ENABLED           = "two-factor authentication is not enabled!"
+	TWO_FACTOR_SETUP_NOT_STARTED     = "start the two-factor authentication setup first!"
+	TWO_FACTOR_REQUIRED              = "two-factor authentication is required for this account!"
+	TWO_FACTOR_NOT_FOUND             = "admin has no two-factor authentication!"
+	FAILED_GET_TWO_FACTOR            = "failed to get two-factor authentication status!"
+	FAILED_SETUP_TWO_FACTOR          = "failed to set up two-factor authentication!"
+	FAILED_ENABLE_TWO_FACTOR         = "failed to enable two-factor authentication!"
+	FAILED_DISABLE_TWO_FACTOR        = "failed to disable two-factor authentication!"
+	FAILED_REGENERATE_RECOVERY_CODES = "failed to regenerate recovery codes!"
+	FAILED_RESET_TWO_FACTOR          = "failed to reset two-factor authentication!"
+	FAILED_VERIFY_TWO_FACTOR         = "failed to verify two-factor code!"
 
 	//Admin
 	FAILED_CREATE_ADMIN        = "failed to create admin!"
@@ -62,6 +113,32 @@ const (
 	ADMIN_NOT_FOUND            = "admin not found"
 	FAILED_GET_ADMIN           = "failed to get admin!"
 
+	// Admin Invitations
+	INVALID_INVITATION       = "invitation link is invalid or expired!"
+	INVITATION_NOT_FOUND     = "invitation not found!"
+	ADMIN_EMAIL_EXIST        = "an admin with this email already exists!"
+	ADMIN_USERNAME_EXIST     = "username is already taken!"
+	ADMIN_DEACTIVATED        = "admin account is deactivated!"
+	CANNOT_DEACTIVATE_SELF   = "you cannot deactivate your own account!"
+	FAILED_INVITE_ADMIN      = "failed to invite admin!"
+	FAILED_GET_INVITATIONS   = "failed to get invitations!"
+	FAILED_REVOKE_INVITATION = "failed to revoke invitation!"
+	FAILED_ACCEPT_INVITATION = "failed to accept invitation!"
+	FAILED_DEACTIVATE_ADMIN  = "failed to deactivate admin!"
+	FAILED_ACTIVATE_ADMIN    = "failed to activate admin!"
+
+	// Admin Roles
+	PERMISSION_DENIED       = "you do not have permission to access this resource!"
+	FAILED_CHECK_PERMISSION = "failed to check permissions!"
+	ROLE_NOT_FOUND          = "role not found!"
+	ROLE_NAME_EXIST         = "a role with this name already exists!"
+	UNKNOWN_PERMISSION      = "unknown permission!"
+	FAILED_GET_ROLES        = "failed to get roles!"
+	FAILED_CREATE_ROLE      = "failed to create role!"
+	FAILED_UPDATE_ROLE      = "failed to update role!"
+	FAILED_DELETE_ROLE      = "failed to delete role!"
+	FAILED_ASSIGN_ROLES     = "failed to assign roles!"
+
 	//Product Admin
 	FAILED_CREATE_CATEGORY        = "failed to create category"
 	FAILED_PARSE_CATEGORY         = "failed to parse category id"
@@ -119,6 +196,51 @@ const (
 	FAILED_SEARCH_EVENTS = "failed to search events by name"
 	EVENT_NOT_FOUND      = "event not found"
 
+	// Waitlist
+	FAILED_JOIN_WAITLIST    = "failed to join waitlist!"
+	FAILED_LEAVE_WAITLIST   = "failed to leave waitlist!"
+	FAILED_GET_WAITLIST     = "failed to get waitlist!"
+	WAITLIST_NOT_FOUND      = "waitlist entry not found!"
+	ALREADY_ON_WAITLIST     = "already on the waitlist for this ticket!"
+	TICKETS_STILL_AVAILABLE = "tickets are still available!"
+	TICKET_SOLD_OUT         = "tickets are sold out!"
+	INVALID_RESERVATION     = "invalid or expired reservation!"
+	INVALID_TICKET_QUANTITY = "ticket quantity must be at least 1!"
+
+	// Event Reviews
+	FAILED_GET_EVENT_REVIEWS   = "failed to get event reviews!"
+	FAILED_GET_CATEGORY_RATING = "failed to get category ratings!"
+	EVENT_NOT_ENDED            = "event has not ended yet!"
+	NOT_EVENT_ATTENDEE         = "only attendees can review this event!"
+	ALREADY_REVIEWED_EVENT     = "you have already reviewed this event!"
+
+	// Event Tickets
+	FAILED_GET_TICKETS             = "failed to get tickets!"
+	FAILED_TRANSFER_TICKET         = "failed to transfer ticket!"
+	FAILED_GET_TICKET_TRANSFERS    = "failed to get ticket transfers!"
+	FAILED_UPDATE_TRANSFER_SETTING = "failed to update ticket transfer setting!"
+	TICKET_NOT_FOUND               = "ticket not found or no longer valid!"
+	TICKET_TRANSFER_DISABLED       = "ticket transfer is not available for this event!"
+	INVALID_TRANSFER_RECIPIENT     = "invalid ticket transfer recipient!"
+	TICKET_ALREADY_TRANSFERRED     = "tickets of this booking have been transferred!"
+	TICKET_CHANGED                 = "ticket was changed by another request, please try again!"
+
+	// Free Events & RSVP
+	FAILED_CANCEL_BOOKING     = "failed to cancel booking!"
+	BOOKING_NOT_CANCELLABLE   = "only upcoming confirmed free bookings can be cancelled!"
+	ALREADY_RSVPED            = "you have already RSVPed to this event!"
+	PAID_TICKET_ON_RSVP_EVENT = "RSVP-only events can only have free tickets!"
+
+	// Venues
+	FAILED_GET_VENUES        = "failed to get venues!"
+	FAILED_CREATE_VENUE      = "failed to create venue!"
+	FAILED_UPDATE_VENUE      = "failed to update venue!"
+	FAILED_DELETE_VENUE      = "failed to delete venue!"
+	VENUE_NOT_FOUND          = "venue not found"
+	VENUE_IN_USE             = "venue is still used by events!"
+	FAILED_SAVE_POSTAL_CODES = "failed to save postal code centroids!"
+	INVALID_COORDINATES      = "invalid coordinates!"
+
 	// Articles
 	FAILED_CREATE_ARTICLE = "failed to create article!"
 	FAILED_UPDATE_ARTICLE = "failed to update article!"
@@ -127,6 +249,65 @@ const (
 	ARTICLE_NOT_FOUND     = "article not found!"
 	NOT_FOUND             = "not found"
 
+	// Article Workflow
+	FAILED_UPDATE_ARTICLE_STATUS = "failed to update article status!"
+	INVALID_ARTICLE_STATUS       = "invalid article status!"
+	INVALID_PUBLISH_AT           = "scheduled articles need a future publish_at!"
+
+	// Article Revisions
+	FAILED_GET_ARTICLE_REVISIONS    = "failed to get article revisions!"
+	FAILED_DIFF_ARTICLE_REVISIONS   = "failed to compare article revisions!"
+	FAILED_RESTORE_ARTICLE_REVISION = "failed to restore article revision!"
+	ARTICLE_REVISION_NOT_FOUND      = "article revision not found!"
+
+	// Featured Products
+	FAILED_GET_ARTICLE_PRODUCTS    = "failed to get featured products!"
+	FAILED_UPDATE_ARTICLE_PRODUCTS = "failed to update featured products!"
+	INVALID_ARTICLE_PRODUCTS       = "some featured products do not exist!"
+
+	// Tags
+	FAILED_GET_TAGS    = "failed to get tags!"
+	FAILED_GET_TAG     = "failed to get tag!"
+	FAILED_CREATE_TAG  = "failed to create tag!"
+	FAILED_UPDATE_TAG  = "failed to update tag!"
+	FAILED_DELETE_TAG  = "failed to delete tag!"
+	FAILED_MERGE_TAGS  = "failed to merge tags!"
+	TAG_NOT_FOUND      = "tag not found!"
+	TAG_ALREADY_EXISTS = "tag already exists, merge the tags instead!"
+	INVALID_TAG_NAME   = "tag names must contain letters or digits and be at most 50 characters!"
+	TOO_MANY_TAGS      = "at most 10 tags are allowed!"
+	INVALID_TAG_MERGE  = "a tag cannot be merged into itself!"
+
+	// SEO
+	FAILED_GET_SITEMAP = "failed to generate sitemap!"
+	FAILED_GET_FEED    = "failed to generate feed!"
+
+	// Comment Moderation
+	FAILED_UPDATE_COMMENT         = "failed to update comment!"
+	FAILED_DELETE_COMMENT         = "failed to delete comment!"
+	FAILED_REPORT_COMMENT         = "failed to report comment!"
+	FAILED_GET_MODERATION_QUEUE   = "failed to get moderation queue!"
+	FAILED_MODERATE_COMMENT       = "failed to moderate comment!"
+	FAILED_GET_BANNED_WORDS       = "failed to get banned words!"
+	FAILED_CREATE_BANNED_WORD     = "failed to create banned word!"
+	FAILED_DELETE_BANNED_WORD     = "failed to delete banned word!"
+	COMMENT_NOT_FOUND             = "comment not found!"
+	BANNED_WORD_NOT_FOUND         = "banned word not found!"
+	COMMENT_CONTAINS_BANNED_WORDS = "comment contains words that are not allowed!"
+	ALREADY_REPORTED_COMMENT      = "you have already reported this comment!"
+	BANNED_WORD_ALREADY_EXISTS    = "banned word already exists!"
+	INVALID_BANNED_WORD           = "banned words must contain letters or digits!"
+	INVALID_COMMENT               = "comment cannot be empty!"
+	FAILED_GET_REPLIES            = "failed to get replies!"
+	FAILED_LIKE_COMMENT           = "failed to like comment!"
+	FAILED_UNLIKE_COMMENT         = "failed to unlike comment!"
+	INVALID_CURSOR                = "invalid cursor!"
+
+	// Engagement
+	FAILED_SHARE_CONTENT     = "failed to record share!"
+	FAILED_GET_ARTICLE_STATS = "failed to get article stats!"
+	INVALID_SHARE_CHANNEL    = "share channel is not supported!"
+
 	// Categories
 	FAILED_GET_CATEGORIES = "failed to get categories!"
 	FAILED_CREATE_TICKET_TYPE = "failed to create ticket type!"
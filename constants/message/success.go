@@ -15,6 +15,30 @@ const (
 	UPDATE_USER_ADDRESSES_SUCCESS = "user addresses updated successfully!"
 	DELETE_USER_ADDRESSES_SUCCESS = "user addresses deleted successfully!"
 	CHANGE_PASSWORD_SUCCESS       = "password changed successfully!"
+	EMAIL_CHANGE_OTP_SENT_SUCCESS = "OTP sent to new email!"
+	CHANGE_EMAIL_SUCCESS          = "email changed successfully!"
+	PHONE_OTP_SENT_SUCCESS        = "OTP sent to phone!"
+	VERIFY_PHONE_SUCCESS          = "phone number verified successfully!"
+	REFRESH_TOKEN_SUCCESS         = "token refreshed successfully!"
+	LOGOUT_SUCCESS                = "logged out successfully!"
+	LOGOUT_ALL_SUCCESS            = "logged out of all devices successfully!"
+	GET_SESSIONS_SUCCESS          = "sessions retrieved successfully!"
+	REVOKE_SESSION_SUCCESS        = "session revoked successfully!"
+	GET_OIDC_PROVIDERS_SUCCESS    = "login providers retrieved successfully!"
+	GET_AUTHORIZATION_URL_SUCCESS = "authorization url created successfully!"
+	GET_IDENTITIES_SUCCESS        = "linked accounts retrieved successfully!"
+	LINK_IDENTITY_SUCCESS         = "account linked successfully!"
+	UNLINK_IDENTITY_SUCCESS       = "account unlinked successfully!"
+
+	// Two-Factor Authentication
+	TWO_FACTOR_CHALLENGE_SUCCESS      = "enter the code of your authenticator app to finish logging in!"
+	TWO_FACTOR_SETUP_REQUIRED_SUCCESS = "set up two-factor authentication to finish logging in!"
+	GET_TWO_FACTOR_SUCCESS            = "two-factor authentication status retrieved successfully!"
+	SETUP_TWO_FACTOR_SUCCESS          = "scan the QR code with your authenticator app and confirm a code!"
+	ENABLE_TWO_FACTOR_SUCCESS         = "two-factor authentication enabled, store your recovery codes safely!"
+	DISABLE_TWO_FACTOR_SUCCESS        = "two-factor authentication disabled successfully!"
+	REGENERATE_RECOVERY_CODES_SUCCESS = "recovery codes regenerated successfully!"
+	RESET_TWO_FACTOR_SUCCESS          = "two-factor authentication reset successfully!"
 
 	//Admin
 	ADMIN_CREATED_SUCCESS   = "admin created successfully!"
@@ -25,6 +49,22 @@ const (
 	SUCCES_SEARCH_ADMIN     = "Successfully search admin"
 	GET_ADMIN_SUCCESS       = "admin retrieved successfully!"
 
+	// Admin Invitations
+	INVITE_ADMIN_SUCCESS      = "invitation sent successfully!"
+	GET_INVITATIONS_SUCCESS   = "invitations retrieved successfully!"
+	REVOKE_INVITATION_SUCCESS = "invitation revoked successfully!"
+	ACCEPT_INVITATION_SUCCESS = "admin account created, you can log in now!"
+	DEACTIVATE_ADMIN_SUCCESS  = "admin deactivated successfully!"
+	ACTIVATE_ADMIN_SUCCESS    = "admin activated successfully!"
+
+	// Admin Roles
+	GET_PERMISSIONS_SUCCESS = "permissions retrieved successfully!"
+	GET_ROLES_SUCCESS       = "roles retrieved successfully!"
+	CREATE_ROLE_SUCCESS     = "role created successfully!"
+	UPDATE_ROLE_SUCCESS     = "role updated successfully!"
+	DELETE_ROLE_SUCCESS     = "role deleted successfully!"
+	ASSIGN_ROLES_SUCCESS    = "roles assigned successfully!"
+
 	//Products Admin
 	PRODUCT_CREATED_SUCCESS  = "product created successfully!"
 	CATEGORY_CREATED_SUCCESS = "category created successfully!"
@@ -60,6 +100,32 @@ const (
 	UPDATE_EVENTS_SUCCESS = "event updated successfully!"
 	DELETE_EVENTS_SUCCESS = "event deleted successfully!"
 
+	// Waitlist
+	JOIN_WAITLIST_SUCCESS  = "joined waitlist successfully!"
+	LEAVE_WAITLIST_SUCCESS = "left waitlist successfully!"
+	GET_WAITLIST_SUCCESS   = "waitlist retrieved successfully!"
+
+	// Event Reviews
+	GET_EVENT_REVIEWS_SUCCESS    = "event reviews retrieved successfully!"
+	GET_CATEGORY_RATINGS_SUCCESS = "category ratings retrieved successfully!"
+
+	// Event Tickets
+	GET_TICKETS_SUCCESS             = "tickets retrieved successfully!"
+	TRANSFER_TICKET_SUCCESS         = "ticket transferred successfully!"
+	VERIFY_TICKET_SUCCESS           = "ticket is valid!"
+	GET_TICKET_TRANSFERS_SUCCESS    = "ticket transfers retrieved successfully!"
+	UPDATE_TRANSFER_SETTING_SUCCESS = "ticket transfer setting updated successfully!"
+
+	// Free Events & RSVP
+	CANCEL_BOOKING_SUCCESS = "booking cancelled successfully!"
+
+	// Venues
+	GET_VENUES_SUCCESS        = "venues retrieved successfully!"
+	CREATE_VENUE_SUCCESS      = "venue created successfully!"
+	UPDATE_VENUE_SUCCESS      = "venue updated successfully!"
+	DELETE_VENUE_SUCCESS      = "venue deleted successfully!"
+	SAVE_POSTAL_CODES_SUCCESS = "postal code centroids saved successfully!"
+
 	// Categories Event
 	GET_CATEGORY_SUCCESS    = "categories retrieved successfully!"
 	UPDATE_CATEGORY_SUCCESS = "category updated successfully!"
@@ -81,6 +147,44 @@ const (
 	UPDATE_ARTICLE_SUCCESS = "article updated successfully!"
 	DELETE_ARTICLE_SUCCESS = "article deleted successfully!"
 
+	// Article Workflow
+	UPDATE_ARTICLE_STATUS_SUCCESS = "article status updated successfully!"
+
+	// Article Revisions
+	GET_ARTICLE_REVISIONS_SUCCESS    = "article revisions retrieved successfully!"
+	DIFF_ARTICLE_REVISIONS_SUCCESS   = "article revisions compared successfully!"
+	RESTORE_ARTICLE_REVISION_SUCCESS = "article revision restored successfully!"
+
+	// Featured Products
+	GET_ARTICLE_PRODUCTS_SUCCESS    = "featured products retrieved successfully!"
+	UPDATE_ARTICLE_PRODUCTS_SUCCESS = "featured products updated successfully!"
+
+	// Tags
+	GET_TAGS_SUCCESS   = "tags retrieved successfully!"
+	GET_TAG_SUCCESS    = "tag retrieved successfully!"
+	CREATE_TAG_SUCCESS = "tag created successfully!"
+	UPDATE_TAG_SUCCESS = "tag updated successfully!"
+	DELETE_TAG_SUCCESS = "tag deleted successfully!"
+	MERGE_TAGS_SUCCESS = "tags merged successfully!"
+
+	// Comment Moderation
+	UPDATE_COMMENT_SUCCESS       = "comment updated successfully!"
+	DELETE_COMMENT_SUCCESS       = "comment deleted successfully!"
+	REPORT_COMMENT_SUCCESS       = "comment reported successfully!"
+	GET_MODERATION_QUEUE_SUCCESS = "moderation queue retrieved successfully!"
+	HIDE_COMMENT_SUCCESS         = "comment hidden successfully!"
+	RESTORE_COMMENT_SUCCESS      = "comment restored successfully!"
+	GET_BANNED_WORDS_SUCCESS     = "banned words retrieved successfully!"
+	CREATE_BANNED_WORD_SUCCESS   = "banned word created successfully!"
+	DELETE_BANNED_WORD_SUCCESS   = "banned word deleted successfully!"
+	GET_REPLIES_SUCCESS          = "replies retrieved successfully!"
+	LIKE_COMMENT_SUCCESS         = "comment liked successfully!"
+	UNLIKE_COMMENT_SUCCESS       = "comment unliked successfully!"
+
+	// Engagement
+	SHARE_CONTENT_SUCCESS     = "share recorded successfully!"
+	GET_ARTICLE_STATS_SUCCESS = "article stats retrieved successfully!"
+
 	// Cart
 	ADD_TO_CART_SUCCESS       = "items added to cart successfully!"
 	GET_CART_ITEMS_SUCCESS    = "items retrieved successfully!"
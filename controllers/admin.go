@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	msg "kreasi-nusantara-api/constants/message"
 	dto "kreasi-nusantara-api/dto/admin"
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
@@ -31,24 +33,6 @@ func NewAdminController(adminUsecase usecases.AdminUsecase, validator *validatio
 	}
 }
 
-func (ac *adminController) Register(c echo.Context) error {
-	request := new(dto.RegisterRequest)
-	if err := c.Bind(request); err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
-	}
-
-	if err := ac.validator.Validate(request); err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
-	}
-
-	err := ac.adminUsecase.Register(c, request)
-	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_ADMIN)
-	}
-
-	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.ADMIN_CREATED_SUCCESS, nil)
-}
-
 func (ac *adminController) Login(c echo.Context) error {
 	request := new(dto.LoginRequest)
 	if err := c.Bind(request); err != nil {
@@ -64,9 +48,21 @@ func (ac *adminController) Login(c echo.Context) error {
 	response, err := ac.adminUsecase.Login(c, request)
 	if err != nil {
 		fmt.Println("Error: ", err)
+		if errors.Is(err, err_util.ErrTooManyAttempts) {
+			return http_util.HandleErrorResponse(c, http.StatusTooManyRequests, msg.TOO_MANY_ATTEMPTS)
+		}
+		if errors.Is(err, err_util.ErrAdminDeactivated) {
+			return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.ADMIN_DEACTIVATED)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_LOGIN)
 	}
 
+	switch {
+	case response.TwoFactorSetupRequired:
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.TWO_FACTOR_SETUP_REQUIRED_SUCCESS, response)
+	case response.TwoFactorRequired:
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.TWO_FACTOR_CHALLENGE_SUCCESS, response)
+	}
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGIN_SUCCESS, response)
 }
 
@@ -208,6 +204,43 @@ func (ac *adminController) GetAdminByID(c echo.Context) error {
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ADMIN_SUCCESS, result)
 }
 
+func (ac *adminController) DeactivateAdmin(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+	claims := ac.tokenUtil.GetClaims(c)
+
+	if err := ac.adminUsecase.DeactivateAdmin(c, id, claims.ID); err != nil {
+		return activationErrorResponse(c, err, msg.FAILED_DEACTIVATE_ADMIN)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DEACTIVATE_ADMIN_SUCCESS, nil)
+}
+
+func (ac *adminController) ActivateAdmin(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+	claims := ac.tokenUtil.GetClaims(c)
+
+	if err := ac.adminUsecase.ActivateAdmin(c, id, claims.ID); err != nil {
+		return activationErrorResponse(c, err, msg.FAILED_ACTIVATE_ADMIN)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.ACTIVATE_ADMIN_SUCCESS, nil)
+}
+
+func activationErrorResponse(c echo.Context, err error, failedMessage string) error {
+	switch {
+	case errors.Is(err, err_util.ErrNotFound):
+		return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ADMIN_NOT_FOUND)
+	case errors.Is(err, err_util.ErrCannotDeactivateSelf):
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.CANNOT_DEACTIVATE_SELF)
+	default:
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, failedMessage)
+	}
+}
+
 func (ac *adminController) convertQueryParams(page, limit string) (int, int, error) {
 	if page == "" {
 		page = "1"
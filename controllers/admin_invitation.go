@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	dto "kreasi-nusantara-api/dto/admin"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type adminInvitationController struct {
+	invitationUseCase usecases.AdminInvitationUseCase
+	validator         *validation.Validator
+	tokenUtil         token.TokenUtil
+}
+
+func NewAdminInvitationController(invitationUseCase usecases.AdminInvitationUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *adminInvitationController {
+	return &adminInvitationController{
+		invitationUseCase: invitationUseCase,
+		validator:         validator,
+		tokenUtil:         tokenUtil,
+	}
+}
+
+func (ic *adminInvitationController) InviteAdmin(c echo.Context) error {
+	request := new(dto.InviteAdminRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := ic.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	claims := ic.tokenUtil.GetClaims(c)
+
+	invitation, err := ic.invitationUseCase.InviteAdmin(c, claims.ID, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrAdminEmailExists) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ADMIN_EMAIL_EXIST)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_INVITE_ADMIN)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.INVITE_ADMIN_SUCCESS, invitation)
+}
+
+func (ic *adminInvitationController) GetPendingInvitations(c echo.Context) error {
+	invitations, err := ic.invitationUseCase.GetPendingInvitations(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_INVITATIONS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_INVITATIONS_SUCCESS, invitations)
+}
+
+func (ic *adminInvitationController) RevokeInvitation(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ic.invitationUseCase.RevokeInvitation(c, id); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.INVITATION_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_REVOKE_INVITATION)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.REVOKE_INVITATION_SUCCESS, nil)
+}
+
+func (ic *adminInvitationController) AcceptInvitation(c echo.Context) error {
+	request := new(dto.AcceptInvitationRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := ic.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ic.invitationUseCase.AcceptInvitation(c, request); err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrInvalidInvitation):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_INVITATION)
+		case errors.Is(err, err_util.ErrAdminEmailExists):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ADMIN_EMAIL_EXIST)
+		case errors.Is(err, err_util.ErrAdminUsernameExists):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ADMIN_USERNAME_EXIST)
+		default:
+			return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_ACCEPT_INVITATION)
+		}
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.ACCEPT_INVITATION_SUCCESS, nil)
+}
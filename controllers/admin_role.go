@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	dto "kreasi-nusantara-api/dto/admin"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type adminRoleController struct {
+	roleUseCase usecases.AdminRoleUseCase
+	validator   *validation.Validator
+	tokenUtil   token.TokenUtil
+}
+
+func NewAdminRoleController(roleUseCase usecases.AdminRoleUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *adminRoleController {
+	return &adminRoleController{
+		roleUseCase: roleUseCase,
+		validator:   validator,
+		tokenUtil:   tokenUtil,
+	}
+}
+
+func (rc *adminRoleController) GetPermissions(c echo.Context) error {
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_PERMISSIONS_SUCCESS, rc.roleUseCase.GetPermissions(c))
+}
+
+func (rc *adminRoleController) GetMyPermissions(c echo.Context) error {
+	claims := rc.tokenUtil.GetClaims(c)
+
+	permissions, err := rc.roleUseCase.GetMyPermissions(c, claims.ID, claims.Role)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CHECK_PERMISSION)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_PERMISSIONS_SUCCESS, permissions)
+}
+
+func (rc *adminRoleController) GetRoles(c echo.Context) error {
+	roles, err := rc.roleUseCase.GetRoles(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ROLES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ROLES_SUCCESS, roles)
+}
+
+func (rc *adminRoleController) CreateRole(c echo.Context) error {
+	request := new(dto.RoleRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := rc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	role, err := rc.roleUseCase.CreateRole(c, request)
+	if err != nil {
+		return roleErrorResponse(c, err, msg.FAILED_CREATE_ROLE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.CREATE_ROLE_SUCCESS, role)
+}
+
+func (rc *adminRoleController) UpdateRole(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.RoleRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := rc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	role, err := rc.roleUseCase.UpdateRole(c, id, request)
+	if err != nil {
+		return roleErrorResponse(c, err, msg.FAILED_UPDATE_ROLE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_ROLE_SUCCESS, role)
+}
+
+func (rc *adminRoleController) DeleteRole(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := rc.roleUseCase.DeleteRole(c, id); err != nil {
+		return roleErrorResponse(c, err, msg.FAILED_DELETE_ROLE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_ROLE_SUCCESS, nil)
+}
+
+func (rc *adminRoleController) GetAdminRoles(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	roles, err := rc.roleUseCase.GetAdminRoles(c, id)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ADMIN_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ROLES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ROLES_SUCCESS, roles)
+}
+
+func (rc *adminRoleController) SetAdminRoles(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.AssignRolesRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	roles, err := rc.roleUseCase.SetAdminRoles(c, id, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ADMIN_NOT_FOUND)
+		}
+		return roleErrorResponse(c, err, msg.FAILED_ASSIGN_ROLES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.ASSIGN_ROLES_SUCCESS, roles)
+}
+
+func roleErrorResponse(c echo.Context, err error, failedMessage string) error {
+	switch {
+	case errors.Is(err, err_util.ErrNotFound), errors.Is(err, err_util.ErrRoleNotFound):
+		return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ROLE_NOT_FOUND)
+	case errors.Is(err, err_util.ErrRoleNameExists):
+		return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ROLE_NAME_EXIST)
+	case errors.Is(err, err_util.ErrUnknownPermission):
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.UNKNOWN_PERMISSION)
+	default:
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, failedMessage)
+	}
+}
@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	msg "kreasi-nusantara-api/constants/message"
 	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
@@ -14,19 +17,24 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type articleController struct {
-	articleUseCase usecases.ArticleUseCase
-	validator      *validation.Validator
-	tokenUtil      token.TokenUtil
+	articleUseCase    usecases.ArticleUseCase
+	validator         *validation.Validator
+	tokenUtil         token.TokenUtil
+	slugUseCase       usecases.SlugUseCase
+	engagementUseCase usecases.EngagementUseCase
 }
 
-func NewArticleController(articleUseCase usecases.ArticleUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *articleController {
+func NewArticleController(articleUseCase usecases.ArticleUseCase, validator *validation.Validator, tokenUtil token.TokenUtil, slugUseCase usecases.SlugUseCase, engagementUseCase usecases.EngagementUseCase) *articleController {
 	return &articleController{
-		articleUseCase: articleUseCase,
-		validator:      validator,
-		tokenUtil: tokenUtil,
+		articleUseCase:    articleUseCase,
+		validator:         validator,
+		tokenUtil:         tokenUtil,
+		slugUseCase:       slugUseCase,
+		engagementUseCase: engagementUseCase,
 	}
 }
 
@@ -67,9 +75,42 @@ func (ac *articleController) GetArticleByID(c echo.Context) error {
 
 	result, err := ac.articleUseCase.GetArticleByID(c, articleUUID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLES)
 	}
 
+	recordView(c, ac.engagementUseCase, ac.tokenUtil, entities.SlugTypeArticle, articleUUID)
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLES_SUCCESS, result)
+}
+
+func (ac *articleController) GetArticleBySlug(c echo.Context) error {
+	articleSlug := strings.ToLower(c.Param("slug"))
+
+	articleUUID, canonical, err := ac.slugUseCase.ResolveSlug(c, entities.SlugTypeArticle, articleSlug)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLES)
+	}
+
+	if canonical != c.Param("slug") {
+		return redirectToSlug(c, canonical)
+	}
+
+	result, err := ac.articleUseCase.GetArticleByID(c, articleUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLES)
+	}
+
+	recordView(c, ac.engagementUseCase, ac.tokenUtil, entities.SlugTypeArticle, articleUUID)
+
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLES_SUCCESS, result)
 }
 
@@ -113,6 +154,7 @@ func (ac *articleController) SearchArticles(c echo.Context) error {
 }
 
 func (ac *articleController) GetCommentsByArticleID(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
 	articleId := c.Param("article_id")
 	articleUUID, err := uuid.Parse(articleId)
 	if err != nil {
@@ -138,7 +180,7 @@ func (ac *articleController) GetCommentsByArticleID(c echo.Context) error {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
 	}
 
-	result, meta, link, err := ac.articleUseCase.GetCommentsByArticleID(c, articleUUID, req)
+	result, meta, link, err := ac.articleUseCase.GetCommentsByArticleID(c, claims.ID, articleUUID, req)
 	if err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_COMMENTS)
 	}
@@ -166,7 +208,7 @@ func (ac *articleController) AddCommentToArticle(c echo.Context) error {
 
 	err = ac.articleUseCase.AddCommentToArticle(c, claims.ID, articleUUID, req)
 	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_ADD_COMMENT)
+		return commentErrorResponse(c, err, msg.FAILED_ADD_COMMENT)
 	}
 
 	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.ADD_COMMENT_SUCCESS, nil)
@@ -188,7 +230,7 @@ func (ac *articleController) ReplyToComment(c echo.Context) error {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
 	}
 
-	req := new(dto.ArticleCommentRequest)
+	req := new(dto.ArticleReplyRequest)
 	if err := c.Bind(req); err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
 	}
@@ -199,12 +241,251 @@ func (ac *articleController) ReplyToComment(c echo.Context) error {
 
 	err = ac.articleUseCase.ReplyToComment(c, claims.ID, articleUUID, commentUUID, req)
 	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_REPLY_COMMENT)
+		return commentErrorResponse(c, err, msg.FAILED_REPLY_COMMENT)
 	}
 
 	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.REPLY_COMMENT_SUCCESS, nil)
 }
 
+func (ac *articleController) GetReplies(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	_, limit, err := ac.convertQueryParams("", strings.TrimSpace(c.QueryParam("limit")))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if limit < 1 || limit > 50 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, meta, err := ac.articleUseCase.GetReplies(c, claims.ID, articleUUID, commentUUID, c.QueryParam("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidCursor) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_CURSOR)
+		}
+		return commentErrorResponse(c, err, msg.FAILED_GET_REPLIES)
+	}
+
+	return http_util.HandleCursorResponse(c, msg.GET_REPLIES_SUCCESS, result, meta)
+}
+
+func (ac *articleController) LikeComment(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.LikeComment(c, claims.ID, articleUUID, commentUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_LIKE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LIKE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) UnlikeComment(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.UnlikeComment(c, claims.ID, articleUUID, commentUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_UNLIKE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UNLIKE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) LikeReply(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	replyUUID, err := uuid.Parse(c.Param("reply_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.LikeReply(c, claims.ID, articleUUID, commentUUID, replyUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_LIKE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LIKE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) UnlikeReply(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	replyUUID, err := uuid.Parse(c.Param("reply_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.UnlikeReply(c, claims.ID, articleUUID, commentUUID, replyUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_UNLIKE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UNLIKE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) EditComment(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.ArticleCommentRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.articleUseCase.EditComment(c, claims.ID, articleUUID, commentUUID, req); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_UPDATE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) DeleteComment(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.DeleteComment(c, claims.ID, articleUUID, commentUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_DELETE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) ReportComment(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.CommentReportRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.articleUseCase.ReportComment(c, claims.ID, articleUUID, commentUUID, req); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_REPORT_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.REPORT_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) EditReply(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	replyUUID, err := uuid.Parse(c.Param("reply_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.ArticleCommentRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.articleUseCase.EditReply(c, claims.ID, articleUUID, commentUUID, replyUUID, req); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_UPDATE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) DeleteReply(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	replyUUID, err := uuid.Parse(c.Param("reply_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.articleUseCase.DeleteReply(c, claims.ID, articleUUID, commentUUID, replyUUID); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_DELETE_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_COMMENT_SUCCESS, nil)
+}
+
+func (ac *articleController) ReportReply(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	articleUUID, commentUUID, err := parseCommentParams(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	replyUUID, err := uuid.Parse(c.Param("reply_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.CommentReportRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.articleUseCase.ReportReply(c, claims.ID, articleUUID, commentUUID, replyUUID, req); err != nil {
+		return commentErrorResponse(c, err, msg.FAILED_REPORT_COMMENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.REPORT_COMMENT_SUCCESS, nil)
+}
+
 func (ac *articleController) LikeArticle(c echo.Context) error {
 	claims := ac.tokenUtil.GetClaims(c)
 	articleId := c.Param("article_id")
@@ -239,6 +520,10 @@ func (ac *articleController) UnlikeArticle(c echo.Context) error {
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UNLIKE_ARTICLE_SUCCESS, nil)
 }
 
+func (ac *articleController) ShareArticle(c echo.Context) error {
+	return shareContent(c, ac.engagementUseCase, ac.tokenUtil, ac.validator, entities.SlugTypeArticle, "article_id", msg.ARTICLE_NOT_FOUND)
+}
+
 func (ac *articleController) convertQueryParams(page, limit string) (int, int, error) {
 	if page == "" {
 		page = "1"
@@ -265,3 +550,35 @@ func (ac *articleController) convertQueryParams(page, limit string) (int, int, e
 
 	return intPage, intLimit, nil
 }
+
+func parseCommentParams(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	articleUUID, err := uuid.Parse(c.Param("article_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	commentUUID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return articleUUID, commentUUID, nil
+}
+
+// commentErrorResponse maps errors of the comment use cases to responses
+func commentErrorResponse(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, err_util.ErrNotFound):
+		return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.COMMENT_NOT_FOUND)
+	case errors.Is(err, err_util.ErrForbiddenResource):
+		return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.FORBIDDEN_RESOURCE)
+	case errors.Is(err, err_util.ErrCommentBlocked):
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.COMMENT_CONTAINS_BANNED_WORDS)
+	case errors.Is(err, err_util.ErrInvalidComment):
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_COMMENT)
+	case errors.Is(err, err_util.ErrAlreadyReported):
+		return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ALREADY_REPORTED_COMMENT)
+	}
+
+	return http_util.HandleErrorResponse(c, http.StatusInternalServerError, fallback)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	msg "kreasi-nusantara-api/constants/message"
 	"kreasi-nusantara-api/drivers/cloudinary"
 	"kreasi-nusantara-api/dto"
@@ -13,6 +15,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -23,14 +26,16 @@ type ArticlesAdminController struct {
 	validator           *validation.Validator
 	cloudinaryService   cloudinary.CloudinaryService
 	tokenUtil           token.TokenUtil
+	engagementUseCase   usecases.EngagementUseCase
 }
 
-func NewArticlesAdminController(articleUseCaseAdmin usecases.ArticleUseCaseAdmin, validator *validation.Validator, cloudinaryService cloudinary.CloudinaryService, tokenUtil token.TokenUtil) *ArticlesAdminController {
+func NewArticlesAdminController(articleUseCaseAdmin usecases.ArticleUseCaseAdmin, validator *validation.Validator, cloudinaryService cloudinary.CloudinaryService, tokenUtil token.TokenUtil, engagementUseCase usecases.EngagementUseCase) *ArticlesAdminController {
 	return &ArticlesAdminController{
 		articleUseCaseAdmin: articleUseCaseAdmin,
 		validator:           validator,
 		cloudinaryService:   cloudinaryService,
 		tokenUtil:           tokenUtil,
+		engagementUseCase:   engagementUseCase,
 	}
 }
 
@@ -55,8 +60,13 @@ func (ac *ArticlesAdminController) GetArticles(c echo.Context) error {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
 	}
 
-	result, meta, link, err := ac.articleUseCaseAdmin.GetArticles(c, req)
+	status := strings.TrimSpace(c.QueryParam("status"))
+
+	result, meta, link, err := ac.articleUseCaseAdmin.GetArticles(c, req, status)
 	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidArticleStatus) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_ARTICLE_STATUS)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLES)
 	}
 
@@ -174,12 +184,31 @@ func (ac *ArticlesAdminController) CreateArticlesAdmin(c echo.Context) error {
 
 	request.Image = secureURL
 
+	// Ringkasan, status dan jadwal publikasi bersifat opsional
+	request.Excerpt = formValue(form, "excerpt")
+	request.Status = formValue(form, "status")
+	if publishAt := formValue(form, "publish_at"); publishAt != "" {
+		parsed, err := time.Parse(time.RFC3339, publishAt)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PUBLISH_AT)
+		}
+		request.PublishAt = &parsed
+	}
+
 	if err := ac.validator.Validate(&request); err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
 	}
 
 	err = ac.articleUseCaseAdmin.CreateArticles(c, &request, claims.ID)
 	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrInvalidPublishAt):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PUBLISH_AT)
+		case errors.Is(err, err_util.ErrInvalidTagName):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAG_NAME)
+		case errors.Is(err, err_util.ErrTooManyTags):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.TOO_MANY_TAGS)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_ARTICLE)
 	}
 
@@ -238,6 +267,8 @@ func (ac *ArticlesAdminController) UpdateArticlesAdmin(c echo.Context) error {
 		request.Image = form.Value["image"][0] // Use existing image URL if no new file is uploaded
 	}
 
+	request.Excerpt = formValue(form, "excerpt")
+
 	if err := ac.validator.Validate(&request); err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
 	}
@@ -250,16 +281,52 @@ func (ac *ArticlesAdminController) UpdateArticlesAdmin(c echo.Context) error {
 		Image:   request.Image,
 		Content: request.Content,
 		Tags:    request.Tags,
+		Excerpt: request.Excerpt,
 	}
 
 	err = ac.articleUseCaseAdmin.UpdateArticles(c, articleID, &newRequest)
 	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrInvalidTagName):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAG_NAME)
+		case errors.Is(err, err_util.ErrTooManyTags):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.TOO_MANY_TAGS)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_ARTICLE)
 	}
 
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_ARTICLE_SUCCESS, nil)
 }
 
+// UploadArticleImage mengunggah gambar untuk disisipkan ke dalam konten Markdown artikel
+func (ac *ArticlesAdminController) UploadArticleImage(c echo.Context) error {
+	file, err := c.FormFile("image")
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, "Image is required")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, "Failed to open the image file")
+	}
+	defer src.Close()
+
+	secureURL, err := ac.cloudinaryService.UploadImage(c.Request().Context(), src, "kreasinusantara/articles/content")
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPLOAD_IMAGE)
+	}
+
+	// Teks alternatif dibersihkan agar tidak merusak sintaks gambar Markdown
+	alt := strings.NewReplacer("[", "", "]", "", "\n", " ").Replace(strings.TrimSpace(c.FormValue("alt")))
+
+	response := dto.ArticleImageResponse{
+		URL:      secureURL,
+		Markdown: fmt.Sprintf("![%s](%s)", alt, secureURL),
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.UPLOAD_IMAGE_SUCCESS, response)
+}
+
 func (ac *ArticlesAdminController) DeleteArticlesAdmin(c echo.Context) error {
 	articleIDStr := c.Param("id")
 	if articleIDStr == "" {
@@ -303,3 +370,166 @@ func (ac *ArticlesAdminController) GetArticleByID(c echo.Context) error {
 
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLE_SUCCESS, article)
 }
+
+func (ac *ArticlesAdminController) UpdateArticleStatus(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	var request dto.ArticleStatusRequest
+	if err := c.Bind(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := ac.validator.Validate(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err = ac.articleUseCaseAdmin.UpdateArticleStatus(c, articleID, &request)
+	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		case errors.Is(err, err_util.ErrInvalidArticleStatus):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_ARTICLE_STATUS)
+		case errors.Is(err, err_util.ErrInvalidPublishAt):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PUBLISH_AT)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_ARTICLE_STATUS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_ARTICLE_STATUS_SUCCESS, nil)
+}
+
+func (ac *ArticlesAdminController) GetArticleRevisions(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	revisions, err := ac.articleUseCaseAdmin.GetArticleRevisions(c, articleID)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLE_REVISIONS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLE_REVISIONS_SUCCESS, revisions)
+}
+
+func (ac *ArticlesAdminController) GetArticleStats(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	stats, err := ac.engagementUseCase.GetArticleStats(c, articleID)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLE_STATS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLE_STATS_SUCCESS, stats)
+}
+
+func (ac *ArticlesAdminController) GetArticleProducts(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	products, err := ac.articleUseCaseAdmin.GetArticleProducts(c, articleID)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_ARTICLE_PRODUCTS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_ARTICLE_PRODUCTS_SUCCESS, products)
+}
+
+func (ac *ArticlesAdminController) SetArticleProducts(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	req := new(dto.ArticleProductsRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	products, err := ac.articleUseCaseAdmin.SetArticleProducts(c, articleID, req)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrInvalidArticleProducts) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_ARTICLE_PRODUCTS)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_ARTICLE_PRODUCTS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_ARTICLE_PRODUCTS_SUCCESS, products)
+}
+
+func (ac *ArticlesAdminController) DiffArticleRevisions(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	fromID, err := uuid.Parse(strings.TrimSpace(c.QueryParam("from")))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	toID, err := uuid.Parse(strings.TrimSpace(c.QueryParam("to")))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := ac.articleUseCaseAdmin.DiffArticleRevisions(c, articleID, fromID, toID)
+	if err != nil {
+		if errors.Is(err, err_util.ErrArticleRevisionNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_REVISION_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_DIFF_ARTICLE_REVISIONS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DIFF_ARTICLE_REVISIONS_SUCCESS, result)
+}
+
+func (ac *ArticlesAdminController) RestoreArticleRevision(c echo.Context) error {
+	articleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	revisionID, err := uuid.Parse(c.Param("revision_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := ac.articleUseCaseAdmin.RestoreArticleRevision(c, articleID, revisionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_NOT_FOUND)
+		case errors.Is(err, err_util.ErrArticleRevisionNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ARTICLE_REVISION_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_RESTORE_ARTICLE_REVISION)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.RESTORE_ARTICLE_REVISION_SUCCESS, result)
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type authController struct {
+	authUseCase usecases.AuthUseCase
+	validator   *validation.Validator
+	tokenUtil   token.TokenUtil
+}
+
+func NewAuthController(authUseCase usecases.AuthUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *authController {
+	return &authController{
+		authUseCase: authUseCase,
+		validator:   validator,
+		tokenUtil:   tokenUtil,
+	}
+}
+
+func (ac *authController) RefreshToken(c echo.Context) error {
+	req := new(dto.RefreshTokenRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := ac.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := ac.authUseCase.RefreshToken(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrInvalidRefreshToken):
+			return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_REFRESH_TOKEN)
+		case errors.Is(err, err_util.ErrRefreshTokenReused):
+			return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.REFRESH_TOKEN_REUSED)
+		case errors.Is(err, err_util.ErrFailedGenerateToken):
+			return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GENERATE_TOKEN)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_REFRESH_TOKEN)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.REFRESH_TOKEN_SUCCESS, response)
+}
+
+func (ac *authController) Logout(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	if err := ac.authUseCase.Logout(c, claims.ID, claims.SessionID); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_LOGOUT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGOUT_SUCCESS, nil)
+}
+
+func (ac *authController) LogoutAll(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	if err := ac.authUseCase.LogoutAll(c, claims.ID); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_LOGOUT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGOUT_ALL_SUCCESS, nil)
+}
+
+func (ac *authController) GetSessions(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	sessions, err := ac.authUseCase.GetSessions(c, claims.ID, claims.SessionID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_SESSIONS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_SESSIONS_SUCCESS, sessions)
+}
+
+func (ac *authController) RevokeSession(c echo.Context) error {
+	claims := ac.tokenUtil.GetClaims(c)
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := ac.authUseCase.RevokeSession(c, claims.ID, sessionID); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.SESSION_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_REVOKE_SESSION)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.REVOKE_SESSION_SUCCESS, nil)
+}
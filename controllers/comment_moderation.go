@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type commentModerationController struct {
+	moderationUseCase usecases.CommentModerationUseCase
+	validator         *validation.Validator
+}
+
+func NewCommentModerationController(moderationUseCase usecases.CommentModerationUseCase, validator *validation.Validator) *commentModerationController {
+	return &commentModerationController{
+		moderationUseCase: moderationUseCase,
+		validator:         validator,
+	}
+}
+
+func (mc *commentModerationController) GetModerationQueue(c echo.Context) error {
+	hidden := false
+	if param := c.QueryParam("hidden"); param != "" {
+		parsed, err := strconv.ParseBool(param)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+		}
+		hidden = parsed
+	}
+
+	result, err := mc.moderationUseCase.GetModerationQueue(c, hidden)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_MODERATION_QUEUE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_MODERATION_QUEUE_SUCCESS, result)
+}
+
+func (mc *commentModerationController) HideComment(c echo.Context) error {
+	return mc.moderate(c, entities.CommentTargetComment, true)
+}
+
+func (mc *commentModerationController) RestoreComment(c echo.Context) error {
+	return mc.moderate(c, entities.CommentTargetComment, false)
+}
+
+func (mc *commentModerationController) HideReply(c echo.Context) error {
+	return mc.moderate(c, entities.CommentTargetReply, true)
+}
+
+func (mc *commentModerationController) RestoreReply(c echo.Context) error {
+	return mc.moderate(c, entities.CommentTargetReply, false)
+}
+
+func (mc *commentModerationController) GetBannedWords(c echo.Context) error {
+	result, err := mc.moderationUseCase.GetBannedWords(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_BANNED_WORDS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_BANNED_WORDS_SUCCESS, result)
+}
+
+func (mc *commentModerationController) CreateBannedWord(c echo.Context) error {
+	request := new(dto.BannedWordRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := mc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := mc.moderationUseCase.CreateBannedWord(c, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidBannedWord) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_BANNED_WORD)
+		}
+		if errors.Is(err, err_util.ErrBannedWordExists) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.BANNED_WORD_ALREADY_EXISTS)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_BANNED_WORD)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.CREATE_BANNED_WORD_SUCCESS, result)
+}
+
+func (mc *commentModerationController) DeleteBannedWord(c echo.Context) error {
+	wordID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := mc.moderationUseCase.DeleteBannedWord(c, wordID); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.BANNED_WORD_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_DELETE_BANNED_WORD)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_BANNED_WORD_SUCCESS, nil)
+}
+
+func (mc *commentModerationController) moderate(c echo.Context, targetType string, hide bool) error {
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if hide {
+		err = mc.moderationUseCase.HideComment(c, targetType, targetID)
+	} else {
+		err = mc.moderationUseCase.RestoreComment(c, targetType, targetID)
+	}
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.COMMENT_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_MODERATE_COMMENT)
+	}
+
+	if hide {
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.HIDE_COMMENT_SUCCESS, nil)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.RESTORE_COMMENT_SUCCESS, nil)
+}
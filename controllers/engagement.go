@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// recordView counts a view of the content by the current user. Failures are
+// only logged so tracking never breaks the page itself.
+func recordView(c echo.Context, engagementUseCase usecases.EngagementUseCase, tokenUtil token.TokenUtil, objectType string, objectID uuid.UUID) {
+	claims := tokenUtil.GetClaims(c)
+
+	if err := engagementUseCase.RecordView(c, objectType, objectID, claims.ID); err != nil {
+		logrus.WithError(err).WithField("object_id", objectID).Error("Failed to record view")
+	}
+}
+
+// shareContent records the current user sharing the content identified by the
+// given path parameter
+func shareContent(c echo.Context, engagementUseCase usecases.EngagementUseCase, tokenUtil token.TokenUtil, validator *validation.Validator, objectType string, param string, notFound string) error {
+	claims := tokenUtil.GetClaims(c)
+
+	objectID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.ShareRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_SHARE_CHANNEL)
+	}
+
+	if err := engagementUseCase.ShareContent(c, objectType, objectID, claims.ID, req); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, notFound)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_SHARE_CONTENT)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SHARE_CONTENT_SUCCESS, nil)
+}
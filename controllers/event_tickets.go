@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type eventTicketController struct {
+	eventTicketUseCase usecases.EventTicketUseCase
+	validator          *validation.Validator
+	tokenUtil          token.TokenUtil
+}
+
+func NewEventTicketController(eventTicketUseCase usecases.EventTicketUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *eventTicketController {
+	return &eventTicketController{
+		eventTicketUseCase: eventTicketUseCase,
+		validator:          validator,
+		tokenUtil:          tokenUtil,
+	}
+}
+
+func (tc *eventTicketController) GetMyTickets(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	result, err := tc.eventTicketUseCase.GetMyTickets(c, claims.ID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TICKETS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TICKETS_SUCCESS, result)
+}
+
+func (tc *eventTicketController) TransferTicket(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	ticketID, err := uuid.Parse(c.Param("ticket_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.EventTicketTransferRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := tc.eventTicketUseCase.TransferTicket(c, claims.ID, ticketID, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TICKET_NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrForbiddenResource) {
+			return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.FORBIDDEN_RESOURCE)
+		}
+		if errors.Is(err, err_util.ErrTicketTransferDisabled) {
+			return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.TICKET_TRANSFER_DISABLED)
+		}
+		if errors.Is(err, err_util.ErrInvalidTransferRecipient) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TRANSFER_RECIPIENT)
+		}
+		if errors.Is(err, err_util.ErrTicketChanged) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TICKET_CHANGED)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_TRANSFER_TICKET)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.TRANSFER_TICKET_SUCCESS, result)
+}
+
+func (tc *eventTicketController) VerifyTicket(c echo.Context) error {
+	result, err := tc.eventTicketUseCase.VerifyTicket(c, c.Param("code"))
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TICKET_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TICKETS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.VERIFY_TICKET_SUCCESS, result)
+}
+
+func (tc *eventTicketController) GetTicketTransfers(c echo.Context) error {
+	eventID, err := uuid.Parse(c.Param("event_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	result, err := tc.eventTicketUseCase.GetTicketTransfers(c, eventID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TICKET_TRANSFERS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TICKET_TRANSFERS_SUCCESS, result)
+}
+
+func (tc *eventTicketController) UpdateTicketTransferSetting(c echo.Context) error {
+	eventID, err := uuid.Parse(c.Param("event_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.EventTicketTransferSettingRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := tc.eventTicketUseCase.UpdateTicketTransferSetting(c, eventID, request); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.EVENT_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_TRANSFER_SETTING)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_TRANSFER_SETTING_SUCCESS, nil)
+}
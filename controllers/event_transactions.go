@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	msg "kreasi-nusantara-api/constants/message"
 	"kreasi-nusantara-api/dto"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
@@ -48,6 +50,18 @@ func (etc *eventTransactionController) CreateEventTransaction(c echo.Context) er
 	response, err := etc.eventTransactionUsecase.CreateEventTransaction(c, claims.ID, *request)
 	if err != nil {
 		log.WithError(err).Error("Failed to create event transaction")
+		if errors.Is(err, err_util.ErrTicketSoldOut) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TICKET_SOLD_OUT)
+		}
+		if errors.Is(err, err_util.ErrInvalidReservation) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_RESERVATION)
+		}
+		if errors.Is(err, err_util.ErrInvalidTicketQuantity) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TICKET_QUANTITY)
+		}
+		if errors.Is(err, err_util.ErrAlreadyRSVPed) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ALREADY_RSVPED)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
@@ -70,3 +84,32 @@ func (etc *eventTransactionController) GetEventTransactionById(c echo.Context) e
 	}
 	return http_util.HandleSuccessResponse(c, http.StatusOK, "Event transaction retrieved successfully", response)
 }
+
+func (etc *eventTransactionController) CancelFreeTransaction(c echo.Context) error {
+	claims := etc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	transactionUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	err = etc.eventTransactionUsecase.CancelFreeTransaction(c, claims.ID, transactionUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.NOT_FOUND)
+		case errors.Is(err, err_util.ErrForbiddenResource):
+			return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.FORBIDDEN_RESOURCE)
+		case errors.Is(err, err_util.ErrBookingNotCancellable):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.BOOKING_NOT_CANCELLABLE)
+		case errors.Is(err, err_util.ErrTicketTransferred):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TICKET_ALREADY_TRANSFERRED)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CANCEL_BOOKING)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.CANCEL_BOOKING_SUCCESS, nil)
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type eventWaitlistController struct {
+	eventWaitlistUseCase usecases.EventWaitlistUseCase
+	validator            *validation.Validator
+	tokenUtil            token.TokenUtil
+}
+
+func NewEventWaitlistController(eventWaitlistUseCase usecases.EventWaitlistUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *eventWaitlistController {
+	return &eventWaitlistController{
+		eventWaitlistUseCase: eventWaitlistUseCase,
+		validator:            validator,
+		tokenUtil:            tokenUtil,
+	}
+}
+
+func (wc *eventWaitlistController) JoinWaitlist(c echo.Context) error {
+	claims := wc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	priceID, err := uuid.Parse(c.Param("price_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.EventWaitlistRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := wc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := wc.eventWaitlistUseCase.JoinWaitlist(c, claims.ID, priceID, request)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrTicketsStillAvailable) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TICKETS_STILL_AVAILABLE)
+		}
+		if errors.Is(err, err_util.ErrAlreadyOnWaitlist) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ALREADY_ON_WAITLIST)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_JOIN_WAITLIST)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.JOIN_WAITLIST_SUCCESS, result)
+}
+
+func (wc *eventWaitlistController) LeaveWaitlist(c echo.Context) error {
+	claims := wc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	priceID, err := uuid.Parse(c.Param("price_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	err = wc.eventWaitlistUseCase.LeaveWaitlist(c, claims.ID, priceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.WAITLIST_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_LEAVE_WAITLIST)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LEAVE_WAITLIST_SUCCESS, nil)
+}
+
+func (wc *eventWaitlistController) GetMyWaitlist(c echo.Context) error {
+	claims := wc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+	}
+
+	result, err := wc.eventWaitlistUseCase.GetMyWaitlist(c, claims.ID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_WAITLIST)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_WAITLIST_SUCCESS, result)
+}
@@ -1,10 +1,15 @@
 package controllers
 
 import (
+	"errors"
 	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
 	"net/http"
 	"strconv"
@@ -16,14 +21,20 @@ import (
 )
 
 type eventController struct {
-	eventUseCase usecases.EventUseCase
-	validator    *validation.Validator
+	eventUseCase      usecases.EventUseCase
+	validator         *validation.Validator
+	token             token.TokenUtil
+	slugUseCase       usecases.SlugUseCase
+	engagementUseCase usecases.EngagementUseCase
 }
 
-func NewEventController(eventUseCase usecases.EventUseCase, validator *validation.Validator) *eventController {
+func NewEventController(eventUseCase usecases.EventUseCase, validator *validation.Validator, token token.TokenUtil, slugUseCase usecases.SlugUseCase, engagementUseCase usecases.EngagementUseCase) *eventController {
 	return &eventController{
-		eventUseCase: eventUseCase,
-		validator:    validator,
+		eventUseCase:      eventUseCase,
+		validator:         validator,
+		token:             token,
+		slugUseCase:       slugUseCase,
+		engagementUseCase: engagementUseCase,
 	}
 }
 
@@ -68,6 +79,33 @@ func (ec *eventController) GetEventByID(c echo.Context) error {
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_EVENTS)
 	}
 
+	recordView(c, ec.engagementUseCase, ec.token, entities.SlugTypeEvent, eventUUID)
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_EVENTS_SUCCESS, result)
+}
+
+func (ec *eventController) GetEventBySlug(c echo.Context) error {
+	eventSlug := strings.ToLower(c.Param("slug"))
+
+	eventUUID, canonical, err := ec.slugUseCase.ResolveSlug(c, entities.SlugTypeEvent, eventSlug)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.EVENT_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_EVENTS)
+	}
+
+	if canonical != c.Param("slug") {
+		return redirectToSlug(c, canonical)
+	}
+
+	result, err := ec.eventUseCase.GetEventByID(c, eventUUID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_EVENTS)
+	}
+
+	recordView(c, ec.engagementUseCase, ec.token, entities.SlugTypeEvent, eventUUID)
+
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_EVENTS_SUCCESS, result)
 }
 
@@ -187,6 +225,146 @@ func (ec *eventController) GetEventByDate(c echo.Context) error {
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_EVENTS_SUCCESS, events)
 }
 
+func (ec *eventController) GetNearbyEvents(c echo.Context) error {
+	lat := strings.TrimSpace(c.QueryParam("lat"))
+	lng := strings.TrimSpace(c.QueryParam("lng"))
+	radius := strings.TrimSpace(c.QueryParam("radius"))
+	limit := strings.TrimSpace(c.QueryParam("limit"))
+
+	if lat == "" || lng == "" {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_COORDINATES)
+	}
+
+	if radius == "" {
+		radius = "10"
+	}
+
+	if limit == "" {
+		limit = "10"
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	longitude, err := strconv.ParseFloat(lng, 64)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	floatRadius, err := strconv.ParseFloat(radius, 64)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	intLimit, err := strconv.Atoi(limit)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	req := &dto.EventNearbyRequest{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Radius:    floatRadius,
+		Limit:     intLimit,
+	}
+
+	if err := ec.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := ec.eventUseCase.GetNearbyEvents(c, req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_EVENTS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_EVENTS_SUCCESS, result)
+}
+
+func (ec *eventController) CreateEventReview(c echo.Context) error {
+	claims := ec.token.GetClaims(c)
+
+	eventId := c.Param("event_id")
+	eventUUID, err := uuid.Parse(eventId)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	req := new(dto.EventReviewRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ec.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err = ec.eventUseCase.CreateEventReview(c, claims.ID, eventUUID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.EVENT_NOT_FOUND)
+		case errors.Is(err, err_util.ErrEventNotEnded):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.EVENT_NOT_ENDED)
+		case errors.Is(err, err_util.ErrNotEventAttendee):
+			return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.NOT_EVENT_ATTENDEE)
+		case errors.Is(err, err_util.ErrAlreadyReviewedEvent):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.ALREADY_REVIEWED_EVENT)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_REVIEW)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.CREATE_REVIEW_SUCCESS, nil)
+}
+
+func (ec *eventController) GetEventReviews(c echo.Context) error {
+	eventId := c.Param("event_id")
+	eventUUID, err := uuid.Parse(eventId)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	page := strings.TrimSpace(c.QueryParam("page"))
+	limit := strings.TrimSpace(c.QueryParam("limit"))
+	sortBy := c.QueryParam("sort_by")
+
+	intPage, intLimit, err := ec.convertQueryParams(page, limit)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	req := &dto_base.PaginationRequest{
+		Page:   intPage,
+		Limit:  intLimit,
+		SortBy: sortBy,
+	}
+
+	if err := ec.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, meta, link, err := ec.eventUseCase.GetEventReviews(c, eventUUID, req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_EVENT_REVIEWS)
+	}
+
+	return http_util.HandlePaginationResponse(c, msg.GET_EVENT_REVIEWS_SUCCESS, result, meta, link)
+}
+
+func (ec *eventController) GetCategoryRatings(c echo.Context) error {
+	result, err := ec.eventUseCase.GetCategoryRatings(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_CATEGORY_RATING)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_CATEGORY_RATINGS_SUCCESS, result)
+}
+
+func (ec *eventController) ShareEvent(c echo.Context) error {
+	return shareContent(c, ec.engagementUseCase, ec.token, ec.validator, entities.SlugTypeEvent, "event_id", msg.EVENT_NOT_FOUND)
+}
+
 func (ec *eventController) convertQueryParams(page, limit string) (int, int, error) {
 	if page == "" {
 		page = "1"
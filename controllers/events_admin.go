@@ -7,8 +7,10 @@ import (
 	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/validation"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
@@ -149,15 +151,19 @@ func (ec *EventAdminController) CreateEventsAdmin(c echo.Context) error {
 	}
 
 	// Extract location information from form data
-	location := dto.EventLocationRequest{
-		Building:    form.Value["location.building"][0],
-		Address:     form.Value["location.address"][0],
-		Province:    form.Value["location.province"][0],
-		City:        form.Value["location.city"][0],
-		Subdistrict: form.Value["location.subdistrict"][0],
-		PostalCode:  form.Value["location.postal_code"][0],
+	request.LocationID, request.Location, err = ec.parseLocationForm(form)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	// Extract RSVP setting from form data
+	if value := formValue(form, "rsvp_only"); value != "" {
+		rsvpOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+		}
+		request.RSVPOnly = &rsvpOnly
 	}
-	request.Location = location
 
 	// Extract price information from form data
 	request.Prices = []dto.EventPricesRequest{}
@@ -218,6 +224,9 @@ func (ec *EventAdminController) CreateEventsAdmin(c echo.Context) error {
 	// Call the use case to create the event
 	err = ec.eventAdminUsecase.CreateEventsAdmin(c, &request)
 	if err != nil {
+		if errors.Is(err, err_util.ErrPaidTicketOnRSVPEvent) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.PAID_TICKET_ON_RSVP_EVENT)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_EVENTS)
 	}
 
@@ -269,15 +278,19 @@ func (ec *EventAdminController) UpdateEventsAdmin(c echo.Context) error {
 	}
 
 	// Extract location information from form data
-	location := dto.EventLocationRequest{
-		Building:    form.Value["location.building"][0],
-		Address:     form.Value["location.address"][0],
-		Province:    form.Value["location.province"][0],
-		City:        form.Value["location.city"][0],
-		Subdistrict: form.Value["location.subdistrict"][0],
-		PostalCode:  form.Value["location.postal_code"][0],
+	request.LocationID, request.Location, err = ec.parseLocationForm(form)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	// Extract RSVP setting from form data
+	if value := formValue(form, "rsvp_only"); value != "" {
+		rsvpOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+		}
+		request.RSVPOnly = &rsvpOnly
 	}
-	request.Location = location
 
 	// Extract price information from form data
 	request.Prices = []dto.EventPricesRequest{}
@@ -338,6 +351,9 @@ func (ec *EventAdminController) UpdateEventsAdmin(c echo.Context) error {
 	// Call the use case to update the event
 	err = ec.eventAdminUsecase.UpdateEventsAdmin(c, eventID, &request)
 	if err != nil {
+		if errors.Is(err, err_util.ErrPaidTicketOnRSVPEvent) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.PAID_TICKET_ON_RSVP_EVENT)
+		}
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_EVENTS)
 	}
 
@@ -566,8 +582,152 @@ func (ac *EventAdminController) UpdatePrices(c echo.Context) error {
 
 	err = ac.eventAdminUsecase.UpdatePrices(c, priceID, &req)
 	if err != nil {
+		if errors.Is(err, err_util.ErrPaidTicketOnRSVPEvent) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.PAID_TICKET_ON_RSVP_EVENT)
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update price")
 	}
 
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_PRICES, nil)
 }
+
+func (ec *EventAdminController) GetVenues(c echo.Context) error {
+	venues, err := ec.eventAdminUsecase.GetVenues(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_VENUES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_VENUES_SUCCESS, venues)
+}
+
+func (ec *EventAdminController) CreateVenue(c echo.Context) error {
+	var request dto.EventLocationRequest
+	if err := c.Bind(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ec.validator.Validate(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	venue, err := ec.eventAdminUsecase.CreateVenue(c, &request)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_VENUE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.CREATE_VENUE_SUCCESS, venue)
+}
+
+func (ec *EventAdminController) UpdateVenue(c echo.Context) error {
+	venueID, err := uuid.Parse(c.Param("venue_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	var request dto.EventLocationRequest
+	if err := c.Bind(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ec.validator.Validate(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err = ec.eventAdminUsecase.UpdateVenue(c, venueID, &request)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.VENUE_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_VENUE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_VENUE_SUCCESS, nil)
+}
+
+func (ec *EventAdminController) DeleteVenue(c echo.Context) error {
+	venueID, err := uuid.Parse(c.Param("venue_id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	err = ec.eventAdminUsecase.DeleteVenue(c, venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.VENUE_NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrVenueInUse) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.VENUE_IN_USE)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_DELETE_VENUE)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_VENUE_SUCCESS, nil)
+}
+
+func (ec *EventAdminController) UpsertPostalCodeCentroids(c echo.Context) error {
+	var request dto.PostalCodeCentroidsRequest
+	if err := c.Bind(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ec.validator.Validate(&request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := ec.eventAdminUsecase.UpsertPostalCodeCentroids(c, &request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_SAVE_POSTAL_CODES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SAVE_POSTAL_CODES_SUCCESS, nil)
+}
+
+// parseLocationForm reads either an existing venue ID (location_id) or the
+// location.* fields of a new venue from the multipart form. Both are nil when
+// the form has no location fields at all.
+func (ec *EventAdminController) parseLocationForm(form *multipart.Form) (*uuid.UUID, *dto.EventLocationRequest, error) {
+	if value := formValue(form, "location_id"); value != "" {
+		locationID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &locationID, nil, nil
+	}
+
+	location := &dto.EventLocationRequest{
+		Building:    formValue(form, "location.building"),
+		Address:     formValue(form, "location.address"),
+		Province:    formValue(form, "location.province"),
+		City:        formValue(form, "location.city"),
+		Subdistrict: formValue(form, "location.subdistrict"),
+		PostalCode:  formValue(form, "location.postal_code"),
+	}
+
+	if value := formValue(form, "location.latitude"); value != "" {
+		latitude, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		location.Latitude = &latitude
+	}
+
+	if value := formValue(form, "location.longitude"); value != "" {
+		longitude, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		location.Longitude = &longitude
+	}
+
+	if *location == (dto.EventLocationRequest{}) {
+		return nil, nil, nil
+	}
+
+	return nil, location, nil
+}
+
+func formValue(form *multipart.Form, key string) string {
+	if values := form.Value[key]; len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// feedMaxAge is how long feed readers and proxies may cache a feed
+const feedMaxAge = "public, max-age=900"
+
+var feedContentTypes = map[string]string{
+	usecases.FeedFormatRSS:  "application/rss+xml; charset=UTF-8",
+	usecases.FeedFormatAtom: "application/atom+xml; charset=UTF-8",
+}
+
+type feedController struct {
+	feedUseCase usecases.FeedUseCase
+}
+
+func NewFeedController(feedUseCase usecases.FeedUseCase) *feedController {
+	return &feedController{
+		feedUseCase: feedUseCase,
+	}
+}
+
+func (fc *feedController) GetArticlesRSS(c echo.Context) error {
+	return fc.serveFeed(c, usecases.FeedFormatRSS, "")
+}
+
+func (fc *feedController) GetArticlesAtom(c echo.Context) error {
+	return fc.serveFeed(c, usecases.FeedFormatAtom, "")
+}
+
+func (fc *feedController) GetTagRSS(c echo.Context) error {
+	return fc.serveFeed(c, usecases.FeedFormatRSS, strings.ToLower(c.Param("slug")))
+}
+
+func (fc *feedController) GetTagAtom(c echo.Context) error {
+	return fc.serveFeed(c, usecases.FeedFormatAtom, strings.ToLower(c.Param("slug")))
+}
+
+// serveFeed answers conditional requests with 304 Not Modified when the feed
+// has not changed and renders it otherwise
+func (fc *feedController) serveFeed(c echo.Context, format string, tagSlug string) error {
+	version, err := fc.feedUseCase.GetFeedVersion(c, format, tagSlug)
+	if err != nil {
+		return feedErrorResponse(c, err)
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, feedMaxAge)
+	header.Set("ETag", version.ETag)
+	if !version.LastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, version.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if isFeedNotModified(c.Request(), version.ETag, version.LastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	feed, err := fc.feedUseCase.RenderFeed(c, format, tagSlug)
+	if err != nil {
+		return feedErrorResponse(c, err)
+	}
+
+	return c.Blob(http.StatusOK, feedContentTypes[format], feed)
+}
+
+func feedErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, err_util.ErrNotFound) {
+		return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TAG_NOT_FOUND)
+	}
+	return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_FEED)
+}
+
+// isFeedNotModified evaluates If-None-Match, falling back to If-Modified-Since
+// when the client sent no ETag
+func isFeedNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	msg "kreasi-nusantara-api/constants/message"
 	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
@@ -17,16 +20,20 @@ import (
 )
 
 type productController struct {
-	productUseCase usecases.ProductUseCase
-	validator      *validation.Validator
-	token          token.TokenUtil
+	productUseCase    usecases.ProductUseCase
+	validator         *validation.Validator
+	token             token.TokenUtil
+	slugUseCase       usecases.SlugUseCase
+	engagementUseCase usecases.EngagementUseCase
 }
 
-func NewProductController(productUseCase usecases.ProductUseCase, validator *validation.Validator, token token.TokenUtil) *productController {
+func NewProductController(productUseCase usecases.ProductUseCase, validator *validation.Validator, token token.TokenUtil, slugUseCase usecases.SlugUseCase, engagementUseCase usecases.EngagementUseCase) *productController {
 	return &productController{
-		productUseCase: productUseCase,
-		validator:      validator,
-		token:          token,
+		productUseCase:    productUseCase,
+		validator:         validator,
+		token:             token,
+		slugUseCase:       slugUseCase,
+		engagementUseCase: engagementUseCase,
 	}
 }
 
@@ -71,6 +78,33 @@ func (pc *productController) GetProductByID(c echo.Context) error {
 		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_PRODUCTS)
 	}
 
+	recordView(c, pc.engagementUseCase, pc.token, entities.SlugTypeProduct, productUUID)
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_PRODUCTS_SUCCESS, result)
+}
+
+func (pc *productController) GetProductBySlug(c echo.Context) error {
+	productSlug := strings.ToLower(c.Param("slug"))
+
+	productUUID, canonical, err := pc.slugUseCase.ResolveSlug(c, entities.SlugTypeProduct, productSlug)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.PRODUCT_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_PRODUCTS)
+	}
+
+	if canonical != c.Param("slug") {
+		return redirectToSlug(c, canonical)
+	}
+
+	result, err := pc.productUseCase.GetProductByID(c, productUUID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_PRODUCTS)
+	}
+
+	recordView(c, pc.engagementUseCase, pc.token, entities.SlugTypeProduct, productUUID)
+
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_PRODUCTS_SUCCESS, result)
 }
 
@@ -209,6 +243,10 @@ func (pc *productController) GetProductReviews(c echo.Context) error {
 	return http_util.HandlePaginationResponse(c, msg.GET_PRODUCT_REVIEWS_SUCCESS, result, meta, link)
 }
 
+func (pc *productController) ShareProduct(c echo.Context) error {
+	return shareContent(c, pc.engagementUseCase, pc.token, pc.validator, entities.SlugTypeProduct, "product_id", msg.PRODUCT_NOT_FOUND)
+}
+
 func (pc *productController) convertQueryParams(page, limit string) (int, int, error) {
 	if page == "" {
 		page = "1"
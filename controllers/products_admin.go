@@ -2,10 +2,12 @@ package controllers
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"kreasi-nusantara-api/drivers/cloudinary"
 	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
 	http_util "kreasi-nusantara-api/utils/http"
 	"kreasi-nusantara-api/utils/validation"
 	"net/http"
@@ -208,6 +210,11 @@ func (c *ProductsAdminController) CreateProduct(ctx echo.Context) error {
 		request.ProductVideos = append(request.ProductVideos, videos)
 	}
 
+	// Tag produk bersifat opsional
+	if tags, ok := form.Value["tags"]; ok && len(tags) > 0 {
+		request.Tags = &tags[0]
+	}
+
 	// Validate the request
 	if err := c.validator.Validate(request); err != nil {
 
@@ -216,6 +223,9 @@ func (c *ProductsAdminController) CreateProduct(ctx echo.Context) error {
 
 	// Call the use case to create product
 	if err := c.productAdminUseCase.CreateProduct(ctx, &request); err != nil {
+		if tagErr := productTagError(ctx, err); tagErr != nil {
+			return tagErr
+		}
 		return http_util.HandleErrorResponse(ctx, http.StatusInternalServerError, msg.FAILED_CREATE_PRODUCT)
 	}
 
@@ -473,6 +483,11 @@ func (c *ProductsAdminController) UpdateProduct(ctx echo.Context) error {
 		request.ProductVideos = append(request.ProductVideos, videos)
 	}
 
+	// Tag lama dipertahankan jika field tags tidak dikirim
+	if tags, ok := form.Value["tags"]; ok && len(tags) > 0 {
+		request.Tags = &tags[0]
+	}
+
 	// Validate the request
 	if err := c.validator.Validate(request); err != nil {
 		logger.Error("Failed to validate request:", err)
@@ -481,6 +496,9 @@ func (c *ProductsAdminController) UpdateProduct(ctx echo.Context) error {
 
 	// Call the use case to update product
 	if err := c.productAdminUseCase.UpdateProduct(ctx, productID, &request); err != nil {
+		if tagErr := productTagError(ctx, err); tagErr != nil {
+			return tagErr
+		}
 		return http_util.HandleErrorResponse(ctx, http.StatusInternalServerError, msg.FAILED_UPDATE_PRODUCT)
 	}
 
@@ -587,3 +605,14 @@ func (pc *ProductsAdminController) convertQueryParams(page, limit string) (int,
 
 	return intPage, intLimit, nil
 }
+
+// productTagError maps invalid product tags to a bad request response
+func productTagError(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidTagName):
+		return http_util.HandleErrorResponse(ctx, http.StatusBadRequest, msg.INVALID_TAG_NAME)
+	case errors.Is(err, err_util.ErrTooManyTags):
+		return http_util.HandleErrorResponse(ctx, http.StatusBadRequest, msg.TOO_MANY_TAGS)
+	}
+	return nil
+}
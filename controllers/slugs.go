@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/usecases"
+	http_util "kreasi-nusantara-api/utils/http"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+)
+
+type slugController struct {
+	slugUseCase usecases.SlugUseCase
+}
+
+func NewSlugController(slugUseCase usecases.SlugUseCase) *slugController {
+	return &slugController{
+		slugUseCase: slugUseCase,
+	}
+}
+
+func (sc *slugController) GetSitemap(c echo.Context) error {
+	sitemap, err := sc.slugUseCase.GetSitemap(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_SITEMAP)
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, sitemap)
+}
+
+// redirectToSlug permanently redirects a lookup by an old slug to the same
+// route with the current slug
+func redirectToSlug(c echo.Context, canonical string) error {
+	target := path.Join(path.Dir(c.Request().URL.Path), canonical)
+	if query := c.QueryString(); query != "" {
+		target += "?" + query
+	}
+
+	return c.Redirect(http.StatusMovedPermanently, target)
+}
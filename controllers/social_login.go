@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	dto "kreasi-nusantara-api/dto/user"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+type socialLoginController struct {
+	socialLoginUseCase usecases.SocialLoginUseCase
+	validator          *validation.Validator
+	tokenUtil          token.TokenUtil
+}
+
+func NewSocialLoginController(socialLoginUseCase usecases.SocialLoginUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *socialLoginController {
+	return &socialLoginController{
+		socialLoginUseCase: socialLoginUseCase,
+		validator:          validator,
+		tokenUtil:          tokenUtil,
+	}
+}
+
+func (sc *socialLoginController) GetProviders(c echo.Context) error {
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_OIDC_PROVIDERS_SUCCESS, sc.socialLoginUseCase.GetProviders(c))
+}
+
+func (sc *socialLoginController) GetAuthorizationURL(c echo.Context) error {
+	response, err := sc.socialLoginUseCase.GetAuthorizationURL(c, c.Param("provider"))
+	if err != nil {
+		return sc.handleAuthorizationError(c, err)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_AUTHORIZATION_URL_SUCCESS, response)
+}
+
+func (sc *socialLoginController) Login(c echo.Context) error {
+	req := new(dto.OIDCCallbackRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := sc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := sc.socialLoginUseCase.Login(c, c.Param("provider"), req)
+	if err != nil {
+		var (
+			code    int
+			message string
+		)
+		switch {
+		case errors.Is(err, err_util.ErrUnknownOIDCProvider):
+			code = http.StatusNotFound
+			message = msg.UNKNOWN_OIDC_PROVIDER
+		case errors.Is(err, err_util.ErrInvalidOIDCState):
+			code = http.StatusBadRequest
+			message = msg.INVALID_OIDC_STATE
+		case errors.Is(err, err_util.ErrOIDCLoginFailed):
+			code = http.StatusUnauthorized
+			message = msg.OIDC_LOGIN_FAILED
+		case errors.Is(err, err_util.ErrOIDCEmailNotVerified):
+			code = http.StatusForbidden
+			message = msg.OIDC_EMAIL_NOT_VERIFIED
+		case errors.Is(err, err_util.ErrNotFound):
+			code = http.StatusNotFound
+			message = msg.USER_NOT_FOUND
+		default:
+			logrus.New().WithError(err).Error("Failed to log in with provider")
+			code = http.StatusInternalServerError
+			message = msg.FAILED_OIDC_LOGIN
+		}
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+
+	if response.TwoFactorRequired {
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.TWO_FACTOR_CHALLENGE_SUCCESS, response)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGIN_SUCCESS, response)
+}
+
+func (sc *socialLoginController) GetLinkURL(c echo.Context) error {
+	claims := sc.tokenUtil.GetClaims(c)
+
+	response, err := sc.socialLoginUseCase.GetLinkURL(c, claims.ID, c.Param("provider"))
+	if err != nil {
+		return sc.handleAuthorizationError(c, err)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_AUTHORIZATION_URL_SUCCESS, response)
+}
+
+func (sc *socialLoginController) LinkIdentity(c echo.Context) error {
+	claims := sc.tokenUtil.GetClaims(c)
+
+	req := new(dto.OIDCCallbackRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := sc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := sc.socialLoginUseCase.LinkIdentity(c, claims.ID, c.Param("provider"), req); err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrUnknownOIDCProvider):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.UNKNOWN_OIDC_PROVIDER)
+		case errors.Is(err, err_util.ErrInvalidOIDCState):
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_OIDC_STATE)
+		case errors.Is(err, err_util.ErrOIDCLoginFailed):
+			return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.OIDC_LOGIN_FAILED)
+		case errors.Is(err, err_util.ErrIdentityAlreadyLinked):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.IDENTITY_ALREADY_LINKED)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_LINK_IDENTITY)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.LINK_IDENTITY_SUCCESS, nil)
+}
+
+func (sc *socialLoginController) GetIdentities(c echo.Context) error {
+	claims := sc.tokenUtil.GetClaims(c)
+
+	identities, err := sc.socialLoginUseCase.GetIdentities(c, claims.ID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_IDENTITIES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_IDENTITIES_SUCCESS, identities)
+}
+
+func (sc *socialLoginController) UnlinkIdentity(c echo.Context) error {
+	claims := sc.tokenUtil.GetClaims(c)
+
+	if err := sc.socialLoginUseCase.UnlinkIdentity(c, claims.ID, c.Param("provider")); err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.IDENTITY_NOT_FOUND)
+		case errors.Is(err, err_util.ErrLastLoginMethod):
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.LAST_LOGIN_METHOD)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UNLINK_IDENTITY)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UNLINK_IDENTITY_SUCCESS, nil)
+}
+
+func (sc *socialLoginController) handleAuthorizationError(c echo.Context, err error) error {
+	if errors.Is(err, err_util.ErrUnknownOIDCProvider) {
+		return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.UNKNOWN_OIDC_PROVIDER)
+	}
+
+	logrus.New().WithError(err).Error("Failed to start login with provider")
+	return http_util.HandleErrorResponse(c, http.StatusBadGateway, msg.FAILED_GET_AUTHORIZATION_URL)
+}
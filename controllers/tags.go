@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type tagController struct {
+	tagUseCase usecases.TagUseCase
+	validator  *validation.Validator
+}
+
+func NewTagController(tagUseCase usecases.TagUseCase, validator *validation.Validator) *tagController {
+	return &tagController{
+		tagUseCase: tagUseCase,
+		validator:  validator,
+	}
+}
+
+func (tc *tagController) SearchTags(c echo.Context) error {
+	query := strings.TrimSpace(c.QueryParam("q"))
+
+	limit := 10
+	if param := strings.TrimSpace(c.QueryParam("limit")); param != "" {
+		intLimit, err := strconv.Atoi(param)
+		if err != nil || intLimit <= 0 || intLimit > 50 {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+		}
+		limit = intLimit
+	}
+
+	result, err := tc.tagUseCase.SearchTags(c, query, limit)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TAGS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TAGS_SUCCESS, result)
+}
+
+func (tc *tagController) GetTagBySlug(c echo.Context) error {
+	result, err := tc.tagUseCase.GetTagBySlug(c, strings.ToLower(c.Param("slug")))
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TAG_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TAG)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TAG_SUCCESS, result)
+}
+
+func (tc *tagController) GetTags(c echo.Context) error {
+	result, err := tc.tagUseCase.GetTags(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TAGS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TAGS_SUCCESS, result)
+}
+
+func (tc *tagController) CreateTag(c echo.Context) error {
+	request := new(dto.TagRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := tc.tagUseCase.CreateTag(c, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidTagName) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAG_NAME)
+		}
+		if errors.Is(err, err_util.ErrTagAlreadyExists) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TAG_ALREADY_EXISTS)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CREATE_TAG)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.CREATE_TAG_SUCCESS, result)
+}
+
+func (tc *tagController) RenameTag(c echo.Context) error {
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	request := new(dto.TagRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	result, err := tc.tagUseCase.RenameTag(c, tagID, request)
+	if err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TAG_NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrInvalidTagName) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAG_NAME)
+		}
+		if errors.Is(err, err_util.ErrTagAlreadyExists) {
+			return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TAG_ALREADY_EXISTS)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_UPDATE_TAG)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.UPDATE_TAG_SUCCESS, result)
+}
+
+func (tc *tagController) DeleteTag(c echo.Context) error {
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := tc.tagUseCase.DeleteTag(c, tagID); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TAG_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_DELETE_TAG)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_TAG_SUCCESS, nil)
+}
+
+func (tc *tagController) MergeTags(c echo.Context) error {
+	request := new(dto.TagMergeRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := tc.tagUseCase.MergeTags(c, request); err != nil {
+		if errors.Is(err, err_util.ErrNotFound) {
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TAG_NOT_FOUND)
+		}
+		if errors.Is(err, err_util.ErrInvalidTagMerge) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAG_MERGE)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_MERGE_TAGS)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.MERGE_TAGS_SUCCESS, nil)
+}
@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"errors"
+	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/usecases"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type twoFactorController struct {
+	twoFactorUseCase usecases.TwoFactorUseCase
+	validator        *validation.Validator
+	tokenUtil        token.TokenUtil
+}
+
+func NewTwoFactorController(twoFactorUseCase usecases.TwoFactorUseCase, validator *validation.Validator, tokenUtil token.TokenUtil) *twoFactorController {
+	return &twoFactorController{
+		twoFactorUseCase: twoFactorUseCase,
+		validator:        validator,
+		tokenUtil:        tokenUtil,
+	}
+}
+
+func (tc *twoFactorController) GetStatus(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	status, err := tc.twoFactorUseCase.GetStatus(c, claims.ID, accountType(claims.Role))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_GET_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.GET_TWO_FACTOR_SUCCESS, status)
+}
+
+func (tc *twoFactorController) Setup(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	response, err := tc.twoFactorUseCase.Setup(c, claims.ID, accountType(claims.Role))
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_SETUP_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SETUP_TWO_FACTOR_SUCCESS, response)
+}
+
+func (tc *twoFactorController) Enable(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	req := new(dto.TwoFactorCodeRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := tc.twoFactorUseCase.Enable(c, claims.ID, accountType(claims.Role), req)
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_ENABLE_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.ENABLE_TWO_FACTOR_SUCCESS, response)
+}
+
+func (tc *twoFactorController) Disable(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	req := new(dto.TwoFactorCodeRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	if err := tc.twoFactorUseCase.Disable(c, claims.ID, accountType(claims.Role), req); err != nil {
+		return tc.handleError(c, err, msg.FAILED_DISABLE_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DISABLE_TWO_FACTOR_SUCCESS, nil)
+}
+
+func (tc *twoFactorController) RegenerateRecoveryCodes(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	req := new(dto.TwoFactorCodeRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := tc.twoFactorUseCase.RegenerateRecoveryCodes(c, claims.ID, accountType(claims.Role), req)
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_REGENERATE_RECOVERY_CODES)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.REGENERATE_RECOVERY_CODES_SUCCESS, response)
+}
+
+func (tc *twoFactorController) ResetAdmin(c echo.Context) error {
+	claims := tc.tokenUtil.GetClaims(c)
+
+	adminID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_UUID)
+	}
+
+	if err := tc.twoFactorUseCase.ResetAdmin(c, adminID, claims.ID); err != nil {
+		switch {
+		case errors.Is(err, err_util.ErrNotFound):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.ADMIN_NOT_FOUND)
+		case errors.Is(err, err_util.ErrTwoFactorNotEnabled):
+			return http_util.HandleErrorResponse(c, http.StatusNotFound, msg.TWO_FACTOR_NOT_FOUND)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_RESET_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.RESET_TWO_FACTOR_SUCCESS, nil)
+}
+
+func (tc *twoFactorController) VerifyLogin(c echo.Context) error {
+	req := new(dto.TwoFactorLoginRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := tc.twoFactorUseCase.VerifyLogin(c, req)
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_VERIFY_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGIN_SUCCESS, response)
+}
+
+func (tc *twoFactorController) SetupLogin(c echo.Context) error {
+	req := new(dto.TwoFactorSetupLoginRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := tc.twoFactorUseCase.SetupLogin(c, req)
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_SETUP_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SETUP_TWO_FACTOR_SUCCESS, response)
+}
+
+func (tc *twoFactorController) EnableLogin(c echo.Context) error {
+	req := new(dto.TwoFactorLoginRequest)
+	if err := c.Bind(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := tc.validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	response, err := tc.twoFactorUseCase.EnableLogin(c, req)
+	if err != nil {
+		return tc.handleError(c, err, msg.FAILED_ENABLE_TWO_FACTOR)
+	}
+
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.ENABLE_TWO_FACTOR_SUCCESS, response)
+}
+
+func (tc *twoFactorController) handleError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, err_util.ErrTooManyAttempts):
+		return http_util.HandleErrorResponse(c, http.StatusTooManyRequests, msg.TOO_MANY_ATTEMPTS)
+	case errors.Is(err, err_util.ErrInvalidTwoFactorCode):
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_TWO_FACTOR_CODE)
+	case errors.Is(err, err_util.ErrInvalidTwoFactorChallenge):
+		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_TWO_FACTOR_CHALLENGE)
+	case errors.Is(err, err_util.ErrTwoFactorAlreadyEnabled):
+		return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TWO_FACTOR_ALREADY_ENABLED)
+	case errors.Is(err, err_util.ErrTwoFactorNotEnabled):
+		return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TWO_FACTOR_NOT_ENABLED)
+	case errors.Is(err, err_util.ErrTwoFactorSetupNotStarted):
+		return http_util.HandleErrorResponse(c, http.StatusConflict, msg.TWO_FACTOR_SETUP_NOT_STARTED)
+	case errors.Is(err, err_util.ErrTwoFactorRequired):
+		return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.TWO_FACTOR_REQUIRED)
+	}
+	return http_util.HandleErrorResponse(c, http.StatusInternalServerError, message)
+}
+
+// accountType tells whether the token belongs to a user or an admin
+func accountType(role string) string {
+	if role == "admin" || role == "super_admin" {
+		return entities.AccountTypeAdmin
+	}
+	return entities.AccountTypeUser
+}
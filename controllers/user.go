@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
 	http_const "kreasi-nusantara-api/constants/http"
 	msg "kreasi-nusantara-api/constants/message"
 	dto "kreasi-nusantara-api/dto/user"
@@ -13,6 +14,7 @@ import (
 	"kreasi-nusantara-api/utils/validation"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -90,6 +92,9 @@ func (uc *userController) VerifyOTP(c echo.Context) error {
 		case errors.Is(err, context.Canceled):
 			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
 			message = msg.FAILED_VERIFY_OTP
+		case errors.Is(err, err_util.ErrTooManyAttempts):
+			code = http.StatusTooManyRequests
+			message = msg.TOO_MANY_ATTEMPTS
 		case strings.Contains(err.Error(), "record not found"):
 			code = http.StatusNotFound
 			message = msg.USER_NOT_FOUND
@@ -105,6 +110,44 @@ func (uc *userController) VerifyOTP(c echo.Context) error {
 	return http_util.HandleSuccessResponse(c, http.StatusOK, "OTP verified!", nil)
 }
 
+func (uc *userController) ResendOTP(c echo.Context) error {
+	request := new(dto.ResendOTPRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+
+	if err := uc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err := uc.userUseCase.ResendOTP(c, request)
+	if err != nil {
+		var (
+			code    int
+			message string
+		)
+		switch {
+		case errors.Is(err, context.Canceled):
+			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
+			message = msg.FAILED_RESEND_OTP
+		case errors.Is(err, err_util.ErrTooManyAttempts):
+			code = http.StatusTooManyRequests
+			message = msg.TOO_MANY_ATTEMPTS
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			code = http.StatusNotFound
+			message = msg.UNREGISTERED_EMAIL
+		case errors.Is(err, err_util.ErrAlreadyVerified):
+			code = http.StatusConflict
+			message = msg.EMAIL_ALREADY_VERIFIED
+		default:
+			code = http.StatusInternalServerError
+			message = msg.FAILED_RESEND_OTP
+		}
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.OTP_SENT_SUCCESS, nil)
+}
+
 func (uc *userController) Login(c echo.Context) error {
 	request := new(dto.LoginRequest)
 	if err := c.Bind(request); err != nil {
@@ -123,12 +166,18 @@ func (uc *userController) Login(c echo.Context) error {
 		case errors.Is(err, context.Canceled):
 			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
 			message = msg.FAILED_LOGIN
+		case errors.Is(err, err_util.ErrTooManyAttempts):
+			code = http.StatusTooManyRequests
+			message = msg.TOO_MANY_ATTEMPTS
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			code = http.StatusNotFound
 			message = msg.UNREGISTERED_EMAIL
 		case errors.Is(err, err_util.ErrPasswordMismatch):
 			code = http.StatusUnauthorized
 			message = msg.PASSWORD_MISMATCH
+		case errors.Is(err, err_util.ErrEmailNotVerified):
+			code = http.StatusForbidden
+			message = msg.EMAIL_NOT_VERIFIED
 		case errors.Is(err, err_util.ErrFailedGenerateToken):
 			code = http.StatusInternalServerError
 			message = msg.FAILED_GENERATE_TOKEN
@@ -138,6 +187,9 @@ func (uc *userController) Login(c echo.Context) error {
 		}
 		return http_util.HandleErrorResponse(c, code, message)
 	}
+	if response.TwoFactorRequired {
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.TWO_FACTOR_CHALLENGE_SUCCESS, response)
+	}
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.LOGIN_SUCCESS, response)
 }
 
@@ -161,6 +213,9 @@ func (uc *userController) ForgotPassword(c echo.Context) error {
 		case errors.Is(err, context.Canceled):
 			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
 			message = msg.FAILED_FORGOT_PASSWORD
+		case errors.Is(err, err_util.ErrTooManyAttempts):
+			code = http.StatusTooManyRequests
+			message = msg.TOO_MANY_ATTEMPTS
 		case errors.Is(err, gorm.ErrRecordNotFound):
 			code = http.StatusNotFound
 			message = msg.UNREGISTERED_EMAIL
@@ -193,9 +248,15 @@ func (uc *userController) ResetPassword(c echo.Context) error {
 		case errors.Is(err, context.Canceled):
 			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
 			message = msg.FAILED_RESET_PASSWORD
+		case errors.Is(err, err_util.ErrTooManyAttempts):
+			code = http.StatusTooManyRequests
+			message = msg.TOO_MANY_ATTEMPTS
 		case strings.Contains(err.Error(), "passwords do not match"):
 			code = http.StatusBadRequest
 			message = msg.PASSWORD_MISMATCH
+		case strings.Contains(err.Error(), "invalid otp"):
+			code = http.StatusBadRequest
+			message = msg.INVALID_OTP
 		default:
 			code = http.StatusInternalServerError
 			message = msg.FAILED_RESET_PASSWORD
@@ -287,6 +348,48 @@ func (uc *userController) DeleteProfile(c echo.Context) error {
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.DELETE_PROFILE_SUCCESS, nil)
 }
 
+var exportContentTypes = map[string]string{
+	usecases.ExportFormatZip:  "application/zip",
+	usecases.ExportFormatJSON: echo.MIMEApplicationJSONCharsetUTF8,
+}
+
+func (uc *userController) ExportData(c echo.Context) error {
+	claims := uc.tokenUtil.GetClaims(c)
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = usecases.ExportFormatZip
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_EXPORT_FORMAT)
+	}
+
+	export, err := uc.userUseCase.ExportData(c, claims.ID, format)
+	if err != nil {
+		var (
+			code    int
+			message string
+		)
+		switch {
+		case errors.Is(err, context.Canceled):
+			code = http_const.STATUS_CLIENT_CANCELLED_REQUEST
+			message = msg.FAILED_EXPORT_DATA
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			code = http.StatusNotFound
+			message = msg.UNREGISTERED_USER
+		default:
+			code = http.StatusInternalServerError
+			message = msg.FAILED_EXPORT_DATA
+		}
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+
+	filename := fmt.Sprintf("kreasi-nusantara-data-%s.%s", time.Now().UTC().Format("20060102"), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, contentType, export)
+}
+
 func (uc *userController) UploadPhoto(c echo.Context) error {
 	claims := uc.tokenUtil.GetClaims(c)
 	request := new(dto.UserProfilePhotoRequest)
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	http_const "kreasi-nusantara-api/constants/http"
+	msg "kreasi-nusantara-api/constants/message"
+	dto "kreasi-nusantara-api/dto/user"
+	err_util "kreasi-nusantara-api/utils/error"
+	http_util "kreasi-nusantara-api/utils/http"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func (uc *userController) RequestEmailChange(c echo.Context) error {
+	claims := uc.tokenUtil.GetClaims(c)
+
+	request := new(dto.ChangeEmailRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := uc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err := uc.userUseCase.RequestEmailChange(c, claims.ID, request)
+	if err != nil {
+		code, message := contactErrorResponse(err, msg.FAILED_CHANGE_EMAIL)
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.EMAIL_CHANGE_OTP_SENT_SUCCESS, nil)
+}
+
+func (uc *userController) ConfirmEmailChange(c echo.Context) error {
+	claims := uc.tokenUtil.GetClaims(c)
+
+	request := new(dto.ConfirmOTPRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := uc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err := uc.userUseCase.ConfirmEmailChange(c, claims.ID, request)
+	if err != nil {
+		code, message := contactErrorResponse(err, msg.FAILED_CHANGE_EMAIL)
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.CHANGE_EMAIL_SUCCESS, nil)
+}
+
+func (uc *userController) SendPhoneOTP(c echo.Context) error {
+	claims := uc.tokenUtil.GetClaims(c)
+
+	err := uc.userUseCase.SendPhoneOTP(c, claims.ID)
+	if err != nil {
+		code, message := contactErrorResponse(err, msg.FAILED_SEND_PHONE_OTP)
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.PHONE_OTP_SENT_SUCCESS, nil)
+}
+
+func (uc *userController) VerifyPhone(c echo.Context) error {
+	claims := uc.tokenUtil.GetClaims(c)
+
+	request := new(dto.ConfirmOTPRequest)
+	if err := c.Bind(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.MISMATCH_DATA_TYPE)
+	}
+	if err := uc.validator.Validate(request); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	err := uc.userUseCase.VerifyPhone(c, claims.ID, request)
+	if err != nil {
+		code, message := contactErrorResponse(err, msg.FAILED_VERIFY_PHONE)
+		return http_util.HandleErrorResponse(c, code, message)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.VERIFY_PHONE_SUCCESS, nil)
+}
+
+// contactErrorResponse maps the errors of email changes and phone verification
+func contactErrorResponse(err error, fallback string) (int, string) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return http_const.STATUS_CLIENT_CANCELLED_REQUEST, fallback
+	case errors.Is(err, err_util.ErrTooManyAttempts):
+		return http.StatusTooManyRequests, msg.TOO_MANY_ATTEMPTS
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound, msg.UNREGISTERED_USER
+	case errors.Is(err, err_util.ErrSameEmail):
+		return http.StatusBadRequest, msg.SAME_EMAIL
+	case errors.Is(err, err_util.ErrEmailTaken):
+		return http.StatusConflict, msg.USER_EXIST
+	case errors.Is(err, err_util.ErrNoEmailChange):
+		return http.StatusBadRequest, msg.NO_EMAIL_CHANGE
+	case errors.Is(err, err_util.ErrPhoneNotSet):
+		return http.StatusBadRequest, msg.PHONE_NOT_SET
+	case errors.Is(err, err_util.ErrPhoneAlreadyVerified):
+		return http.StatusConflict, msg.PHONE_ALREADY_VERIFIED
+	case strings.Contains(err.Error(), "invalid otp"):
+		return http.StatusBadRequest, msg.INVALID_OTP
+	}
+	return http.StatusInternalServerError, fallback
+}
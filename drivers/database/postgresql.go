@@ -58,6 +58,16 @@ func migrate(db *gorm.DB) {
 		&entities.User{},
 		&entities.UserAddresses{},
 		&entities.Admin{},
+		&entities.AdminInvitations{},
+		&entities.AdminRoles{},
+		&entities.AdminRolePermissions{},
+		&entities.AdminRoleAssignments{},
+		&entities.UserSessions{},
+		&entities.RefreshTokens{},
+		&entities.SecurityEvents{},
+		&entities.UserIdentities{},
+		&entities.TwoFactorAuth{},
+		&entities.TwoFactorRecoveryCodes{},
 		&entities.ProductCategory{},
 		&entities.ProductPricing{},
 		&entities.ProductVariants{},
@@ -69,17 +79,32 @@ func migrate(db *gorm.DB) {
 		&entities.ArticleComments{},
 		&entities.ArticleCommentReplies{},
 		&entities.ArticleLikes{},
+		&entities.ArticleCommentLikes{},
+		&entities.ArticleRevisions{},
+		&entities.ArticleCommentReports{},
+		&entities.BannedWords{},
+		&entities.Tags{},
+		&entities.ArticleTags{},
+		&entities.ProductTags{},
+		&entities.ArticleProducts{},
+		&entities.SlugRedirects{},
+		&entities.ContentShares{},
 		&entities.EventCategories{},
 		&entities.EventLocations{},
+		&entities.PostalCodeCentroids{},
 		&entities.EventPhotos{},
 		&entities.EventTicketType{},
 		&entities.EventPrices{},
 		&entities.Events{},
+		&entities.EventReviews{},
+		&entities.EventTicket{},
+		&entities.EventTicketTransfer{},
 		&entities.CartItems{},
 		&entities.Cart{},
 		&entities.ProductTransaction{},
 		&entities.EventTransaction{},
 		&entities.EventTransactionBuyer{},
+		&entities.EventWaitlist{},
 	)
 	if err != nil {
 		log.Fatal(msg.FAILED_MIGRATE_DB)
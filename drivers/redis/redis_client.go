@@ -84,4 +84,60 @@ func (r *RedisClient) SetRecommendationProductsIds(key string, value []string) e
 	}
 
 	return err
-}
\ No newline at end of file
+}
+
+// SetNX sets key only when it does not exist yet and reports whether it was set
+func (r *RedisClient) SetNX(key string, value string, expiration time.Duration) (bool, error) {
+	return r.Client.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (r *RedisClient) HIncrBy(key string, field string, incr int64) error {
+	return r.Client.HIncrBy(ctx, key, field, incr).Err()
+}
+
+func (r *RedisClient) HGet(key string, field string) (string, error) {
+	return r.Client.HGet(ctx, key, field).Result()
+}
+
+// PopHash returns all fields of a hash and deletes it in a single transaction
+func (r *RedisClient) PopHash(key string) (map[string]string, error) {
+	var values *redis.StringStringMapCmd
+	_, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		values = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values.Val(), nil
+}
+
+func (r *RedisClient) Exists(key string) (bool, error) {
+	count, err := r.Client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// IncrWithExpiry increments a counter and starts its expiry when it is created
+func (r *RedisClient) IncrWithExpiry(key string, expiration time.Duration) (int64, error) {
+	count, err := r.Client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 1 {
+		if err := r.Client.Expire(ctx, key, expiration).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// GetDel returns a value and deletes it, so only one caller can ever read it
+func (r *RedisClient) GetDel(key string) (string, error) {
+	return r.Client.GetDel(ctx, key).Result()
+}
@@ -2,21 +2,32 @@ package dto
 
 import (
 	"mime/multipart"
+	"time"
 )
 
-type RegisterRequest struct {
-	FirstName    string                `json:"first_name" form:"first_name" validate:"required"`
-	LastName     string                `json:"last_name" form:"last_name" validate:"required"`
-	Username     string                `json:"username" form:"username" validate:"required"`
-	Email        string                `json:"email" form:"email" validate:"required,email"`
-	Password     string                `json:"password" form:"password" validate:"required,min=8,max=32"`
-	IsSuperAdmin bool                  `json:"is_super_admin" form:"is_super_admin"`
-	Image        *multipart.FileHeader ` form:"image" `
+type InviteAdminRequest struct {
+	FirstName    string `json:"first_name" validate:"required"`
+	LastName     string `json:"last_name" validate:"required"`
+	Email        string `json:"email" validate:"required,email"`
+	IsSuperAdmin bool   `json:"is_super_admin"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string                `json:"token" form:"token" validate:"required"`
+	Username string                `json:"username" form:"username" validate:"required"`
+	Password string                `json:"password" form:"password" validate:"required,min=8,max=32"`
+	Image    *multipart.FileHeader ` form:"image" `
 }
 
-type RegisterResponse struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
+type InvitationResponse struct {
+	ID           string    `json:"id"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	Email        string    `json:"email"`
+	IsSuperAdmin bool      `json:"is_super_admin"`
+	InvitedBy    *string   `json:"invited_by"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 type LoginRequest struct {
@@ -25,9 +36,16 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	// TwoFactorRequired is set when the login has to be finished with a
+	// two-factor code and the challenge token, the tokens are empty until then
+	TwoFactorRequired      bool   `json:"two_factor_required"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
 }
 
 type AdminResponse struct {
@@ -37,6 +55,7 @@ type AdminResponse struct {
 	Username     string  `json:"username"`
 	Email        string  `json:"email"`
 	IsSuperAdmin bool    `json:"is_super_admin"`
+	IsActive     bool    `json:"is_active"`
 	Photo        *string `json:"photo"`
 	CreatedAt    string  `json:"created_at"`
 }
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+}
+
+type AssignRolesRequest struct {
+	RoleIDs []uuid.UUID `json:"role_ids"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PermissionResponse struct {
+	Permission  string `json:"permission"`
+	Description string `json:"description"`
+}
+
+type AdminPermissionsResponse struct {
+	IsSuperAdmin bool     `json:"is_super_admin"`
+	Permissions  []string `json:"permissions"`
+}
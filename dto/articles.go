@@ -1,54 +1,152 @@
 package dto
 
 import (
+	"kreasi-nusantara-api/utils/diff"
+	"kreasi-nusantara-api/utils/markdown"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ArticleResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Title     string    `json:"title"`
-	Image     string    `json:"image"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          uuid.UUID `json:"id"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Image       string    `json:"image"`
+	Excerpt     string    `json:"excerpt"`
+	ReadingTime int       `json:"reading_time"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type ArticleAdminResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Tags      string    `json:"tags"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	Author    string    `json:"author"`
-	Image     string    `json:"image"`
-	CreatedAt string    `json:"created_at"`
+	ID          uuid.UUID  `json:"id"`
+	Tags        string     `json:"tags"`
+	Title       string     `json:"title"`
+	Content     string     `json:"content"`
+	Excerpt     string     `json:"excerpt"`
+	Author      string     `json:"author"`
+	Image       string     `json:"image"`
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedAt   string     `json:"created_at"`
 }
 
 type ArticleDetailResponse struct {
-	ID            uuid.UUID         `json:"id"`
-	Title         string            `json:"title"`
-	Content       string            `json:"content"`
-	LikesCount    int               `json:"likes_count"`
-	CommentsCount int               `json:"comments_count"`
-	CreatedAt     time.Time         `json:"created_at"`
-	Author        AuthorInformation `json:"author"`
+	ID            uuid.UUID          `json:"id"`
+	Slug          string             `json:"slug"`
+	CanonicalURL  string             `json:"canonical_url"`
+	Title         string             `json:"title"`
+	Content       string             `json:"content"`
+	ContentHTML   string             `json:"content_html"`
+	TOC           []markdown.Heading `json:"table_of_contents"`
+	Excerpt       string             `json:"excerpt"`
+	ReadingTime   int                `json:"reading_time"`
+	LikesCount    int                `json:"likes_count"`
+	CommentsCount int                `json:"comments_count"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Author        AuthorInformation  `json:"author"`
+	Tags          []TagResponse      `json:"tags"`
+	// RelatedArticles are suggested from shared tags and similar titles and excerpts
+	RelatedArticles  []ArticleResponse        `json:"related_articles"`
+	FeaturedProducts []ArticleProductResponse `json:"featured_products"`
 }
 
 type ArticleCommentResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Content      string     `json:"content"`
+	LikesCount   int        `json:"likes_count"`
+	RepliesCount int        `json:"replies_count"`
+	Liked        bool       `json:"liked"`
+	EditedAt     *time.Time `json:"edited_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type ArticleReplyResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	CommentID       uuid.UUID  `json:"comment_id"`
+	ParentReplyID   *uuid.UUID `json:"parent_reply_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	MentionedUserID *uuid.UUID `json:"mentioned_user_id"`
+	Content         string     `json:"content"`
+	LikesCount      int        `json:"likes_count"`
+	Liked           bool       `json:"liked"`
+	EditedAt        *time.Time `json:"edited_at"`
+	CreatedAt       time.Time  `json:"created_at"`
 }
 
 type ArticleCommentRequest struct {
-	Content string `json:"content"`
+	Content string `json:"content" validate:"required,max=1000"`
+}
+
+type ArticleReplyRequest struct {
+	Content string `json:"content" validate:"required,max=1000"`
+	// ReplyToID is the reply being answered, its author is mentioned in the new reply
+	ReplyToID *uuid.UUID `json:"reply_to_id"`
 }
 
 type ArticleRequest struct {
-	Title   string `json:"title" form:"title"`
-	Image   string `json:"image" form:"image"`
+	Title string `json:"title" form:"title"`
+	Image string `json:"image" form:"image"`
+	// Content is written in Markdown
 	Content string `json:"content" form:"content"`
 	Tags    string `json:"tags" form:"tags"`
 	Author  string `json:"author" form:"author"`
+	// Excerpt is generated from the content when left empty
+	Excerpt string `json:"excerpt" form:"excerpt" validate:"max=300"`
+	// Status defaults to draft when creating an article
+	Status    string     `json:"status" form:"status" validate:"omitempty,oneof=draft in_review scheduled published"`
+	PublishAt *time.Time `json:"publish_at" form:"publish_at"`
+}
+
+type ArticleProductResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Slug            string    `json:"slug"`
+	Name            string    `json:"name"`
+	Image           string    `json:"image"`
+	OriginalPrice   int       `json:"original_price"`
+	DiscountPercent *int      `json:"discount_percent"`
+	DiscountPrice   *float64  `json:"discount_price"`
+}
+
+type ArticleProductsRequest struct {
+	// ProductIDs are shown in the given order
+	ProductIDs []uuid.UUID `json:"product_ids" validate:"max=10,unique"`
+}
+
+type ArticleImageResponse struct {
+	URL      string `json:"url"`
+	Markdown string `json:"markdown"`
+}
+
+type ArticleStatusRequest struct {
+	Status    string     `json:"status" validate:"required,oneof=draft in_review scheduled published archived"`
+	PublishAt *time.Time `json:"publish_at" validate:"required_if=Status scheduled"`
+}
+
+type ArticleRevisionResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ArticleID      uuid.UUID  `json:"article_id"`
+	Version        int        `json:"version"`
+	EditorID       uuid.UUID  `json:"editor_id"`
+	Editor         string     `json:"editor"`
+	Title          string     `json:"title"`
+	Image          string     `json:"image"`
+	Content        string     `json:"content"`
+	Tags           string     `json:"tags"`
+	RestoredFromID *uuid.UUID `json:"restored_from_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ArticleRevisionDiffResponse struct {
+	From         ArticleRevisionResponse `json:"from"`
+	To           ArticleRevisionResponse `json:"to"`
+	TitleDiff    []diff.Line             `json:"title_diff"`
+	ContentDiff  []diff.Line             `json:"content_diff"`
+	TagsDiff     []diff.Line             `json:"tags_diff"`
+	ImageChanged bool                    `json:"image_changed"`
+	Unified      string                  `json:"unified"`
 }
 
 type AuthorInformation struct {
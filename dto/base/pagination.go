@@ -22,3 +22,13 @@ type PaginationMetadata struct {
 	TotalPage   int   `json:"total_page"`
 	TotalData   int64 `json:"total_data"`
 }
+
+type CursorResponse struct {
+	BaseResponse
+	Metadata *CursorMetadata `json:"metadata"`
+}
+
+type CursorMetadata struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
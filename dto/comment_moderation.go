@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CommentReportRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type CommentModerationResponse struct {
+	TargetType     string     `json:"target_type"`
+	TargetID       uuid.UUID  `json:"target_id"`
+	ArticleID      uuid.UUID  `json:"article_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	Content        string     `json:"content"`
+	Hidden         bool       `json:"hidden"`
+	ReportCount    int        `json:"report_count"`
+	Reasons        []string   `json:"reasons"`
+	LastReportedAt *time.Time `json:"last_reported_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type BannedWordRequest struct {
+	Word string `json:"word" validate:"required,max=50"`
+}
+
+type BannedWordResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Word      string    `json:"word"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type ShareRequest struct {
+	Channel string `json:"channel" validate:"required,oneof=whatsapp facebook x telegram email copy_link other"`
+}
+
+type ShareChannelResponse struct {
+	Channel string `json:"channel"`
+	Count   int    `json:"count"`
+}
+
+type ArticleStatsResponse struct {
+	ArticleID       uuid.UUID              `json:"article_id"`
+	Views           int                    `json:"views"`
+	Shares          int                    `json:"shares"`
+	Likes           int                    `json:"likes"`
+	Comments        int                    `json:"comments"`
+	SharesByChannel []ShareChannelResponse `json:"shares_by_channel"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventTicketTransferRequest struct {
+	// UserID picks a registered user; otherwise the ticket goes to Email
+	UserID         *uuid.UUID `json:"user_id"`
+	Email          string     `json:"email" validate:"required_without=UserID,omitempty,email"`
+	IdentityNumber string     `json:"identity_number" validate:"required"`
+	FullName       string     `json:"full_name" validate:"required"`
+	Phone          string     `json:"phone" validate:"required"`
+}
+
+type EventTicketTransferSettingRequest struct {
+	AllowTicketTransfer *bool `json:"allow_ticket_transfer" validate:"required"`
+}
+
+type EventTicketResponse struct {
+	ID                 uuid.UUID        `json:"id"`
+	EventTransactionID uuid.UUID        `json:"event_transaction_id"`
+	EventID            uuid.UUID        `json:"event_id"`
+	EventName          string           `json:"event_name"`
+	EventDate          string           `json:"event_date"`
+	Code               string           `json:"code,omitempty"`
+	Holder             BuyerInformation `json:"holder"`
+	Transferable       bool             `json:"transferable"`
+}
+
+type EventTicketTransferResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	EventTicketID uuid.UUID  `json:"event_ticket_id"`
+	FromUserID    *uuid.UUID `json:"from_user_id"`
+	FromFullName  string     `json:"from_full_name"`
+	FromEmail     string     `json:"from_email"`
+	ToUserID      *uuid.UUID `json:"to_user_id"`
+	ToFullName    string     `json:"to_full_name"`
+	ToEmail       string     `json:"to_email"`
+	RevokedCode   string     `json:"revoked_code"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
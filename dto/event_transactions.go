@@ -4,11 +4,13 @@ import "github.com/google/uuid"
 
 type EventTransactionRequest struct {
 	EventPriceID   uuid.UUID `json:"event_price_id" validate:"required"`
-	Quantity       int       `json:"quantity" validate:"required"`
+	Quantity       int       `json:"quantity" validate:"required,gt=0"`
 	IdentityNumber string    `json:"identity_number" validate:"required"`
 	FullName       string    `json:"full_name" validate:"required"`
 	Email          string    `json:"email" validate:"required,email"`
 	Phone          string    `json:"phone" validate:"required"`
+	// ReservationToken is set when checking out from a waitlist reservation
+	ReservationToken string `json:"reservation_token"`
 }
 
 type EventTransactionResponse struct {
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/google/uuid"
+
+type EventWaitlistRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+}
+
+type EventWaitlistResponse struct {
+	ID               uuid.UUID               `json:"id"`
+	EventID          uuid.UUID               `json:"event_id"`
+	EventPriceID     uuid.UUID               `json:"event_price_id"`
+	TicketType       EventTicketTypeResponse `json:"ticket_type"`
+	Quantity         int                     `json:"quantity"`
+	Status           string                  `json:"status"`
+	Position         int                     `json:"position,omitempty"`
+	ReservationToken string                  `json:"reservation_token,omitempty"`
+	ReservedUntil    string                  `json:"reserved_until,omitempty"`
+	CreatedAt        string                  `json:"created_at"`
+}
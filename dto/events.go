@@ -1,52 +1,111 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type EventResponse struct {
-	ID       uuid.UUID           `json:"id"`
-	Name     string              `json:"name"`
-	Image    string              `json:"image"`
-	Category string              `json:"category"`
-	Location EventLocationDetail `json:"location"`
-	Date     string              `json:"date"`
-	MinPrice int                 `json:"min_price"`
+	ID            uuid.UUID           `json:"id"`
+	Slug          string              `json:"slug"`
+	Name          string              `json:"name"`
+	Image         string              `json:"image"`
+	Category      string              `json:"category"`
+	Location      EventLocationDetail `json:"location"`
+	Date          string              `json:"date"`
+	MinPrice      int                 `json:"min_price"`
+	AverageRating float64             `json:"average_rating"`
+	TotalReview   int                 `json:"total_review"`
 }
 
 type EventDetailResponse struct {
-	ID          uuid.UUID             `json:"id"`
-	Name        string                `json:"name"`
-	Description string                `json:"description"`
-	Images      []string              `json:"images"`
-	Location    EventLocationDetail   `json:"location"`
-	Date        string                `json:"date"`
-	Ticket      []EventPricesResponse `json:"ticket"`
+	ID            uuid.UUID              `json:"id"`
+	Slug          string                 `json:"slug"`
+	CanonicalURL  string                 `json:"canonical_url"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Images        []string               `json:"images"`
+	Location      EventLocationDetail    `json:"location"`
+	Date          string                 `json:"date"`
+	Ticket        []EventPricesResponse  `json:"ticket"`
+	RSVPOnly      bool                   `json:"rsvp_only"`
+	AverageRating float64                `json:"average_rating"`
+	TotalReview   int                    `json:"total_review"`
+	LatestReview  []*EventReviewResponse `json:"latest_review,omitempty"`
+}
+
+type EventReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Review string `json:"review" validate:"max=255"`
+}
+
+type EventReviewResponse struct {
+	User      UserReview `json:"user"`
+	Rating    int        `json:"rating"`
+	CreatedAt time.Time  `json:"created_at"`
+	Review    string     `json:"review"`
+}
+
+type EventCategoryRatingResponse struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	AverageRating float64 `json:"average_rating"`
+	TotalReview   int     `json:"total_review"`
 }
 
 type EventLocationDetail struct {
-	Building    string `json:"building"`
-	Subdistrict string `json:"subdistrict"`
-	City        string `json:"city"`
+	Building    string   `json:"building"`
+	Subdistrict string   `json:"subdistrict"`
+	City        string   `json:"city"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+type EventNearbyResponse struct {
+	EventResponse
+	Distance float64 `json:"distance"`
+}
+
+type EventNearbyRequest struct {
+	Latitude  float64 `json:"lat" query:"lat" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"lng" query:"lng" validate:"gte=-180,lte=180"`
+	Radius    float64 `json:"radius" query:"radius" validate:"gt=0,lte=500"`
+	Limit     int     `json:"limit" query:"limit" validate:"gt=0,lte=100"`
 }
 
 type EventRequest struct {
-	Name        string               `json:"name" form:"name" validate:"required"`
-	Description string               `json:"description" form:"description" validate:"required"`
-	CategoryID  int                  `json:"category_id" form:"category_id" validate:"required"`
-	Date        string               `json:"date" form:"date" validate:"required"`
-	Prices      []EventPricesRequest `json:"prices" form:"prices" validate:"required"`
-	Photos      []EventPhotosRequest `json:"photos" form:"photos" validate:"required"`
-	Location    EventLocationRequest `json:"location" form:"location" validate:"required"`
+	Name        string                `json:"name" form:"name" validate:"required"`
+	Description string                `json:"description" form:"description" validate:"required"`
+	CategoryID  int                   `json:"category_id" form:"category_id" validate:"required"`
+	Date        string                `json:"date" form:"date" validate:"required"`
+	Prices      []EventPricesRequest  `json:"prices" form:"prices" validate:"required"`
+	Photos      []EventPhotosRequest  `json:"photos" form:"photos" validate:"required"`
+	LocationID  *uuid.UUID            `json:"location_id" form:"location_id"`
+	Location    *EventLocationRequest `json:"location" form:"location" validate:"required_without=LocationID"`
+	// RSVPOnly events only accept free ticket tiers; nil keeps the current setting on update
+	RSVPOnly *bool `json:"rsvp_only" form:"rsvp_only"`
 }
 
 type EventLocationRequest struct {
-	Building    string `json:"building" form:"building" validate:"required"`
-	Address     string `json:"address" form:"address" validate:"required"`
-	Province    string `json:"province" form:"province" validate:"required"`
-	City        string `json:"city" form:"city" validate:"required"`
-	Subdistrict string `json:"subdistrict" form:"subdistrict" validate:"required"`
-	PostalCode  string `json:"postal_code" form:"postal_code" validate:"required"`
+	Building    string   `json:"building" form:"building" validate:"required"`
+	Address     string   `json:"address" form:"address" validate:"required"`
+	Province    string   `json:"province" form:"province" validate:"required"`
+	City        string   `json:"city" form:"city" validate:"required"`
+	Subdistrict string   `json:"subdistrict" form:"subdistrict" validate:"required"`
+	PostalCode  string   `json:"postal_code" form:"postal_code" validate:"required"`
+	Latitude    *float64 `json:"latitude" form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+type PostalCodeCentroidRequest struct {
+	PostalCode string  `json:"postal_code" validate:"required,max=10"`
+	Latitude   float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude  float64 `json:"longitude" validate:"gte=-180,lte=180"`
+}
+
+type PostalCodeCentroidsRequest struct {
+	Centroids []PostalCodeCentroidRequest `json:"centroids" validate:"required,min=1,dive"`
 }
 
 type EventCategoryRequest struct {
@@ -58,7 +117,7 @@ type EventPhotosRequest struct {
 }
 
 type EventPricesRequest struct {
-	Price        int    `json:"price" form:"price" validate:"required"`
+	Price        int    `json:"price" form:"price" validate:"gte=0"`
 	TicketTypeID int    `json:"ticket_type_id" form:"ticket_type_id" validate:"required"`
 	NoOfTicket   int    `json:"no_of_ticket" form:"no_of_ticket" validate:"required"`
 	Publish      string `json:"publish" form:"publish" validate:"required"`
@@ -84,15 +143,17 @@ type EventAdminResponse struct {
 }
 
 type EventAdminDetailResponse struct {
-	ID          uuid.UUID               `json:"id"`
-	Name        string                  `json:"name"`
-	Status      string                  `json:"status"`
-	Date        string                  `json:"date"`
-	Description string                  `json:"description"`
-	Category    EventCategoriesResponse `json:"category"`
-	Ticket      []EventPricesResponse   `json:"ticket"`
-	Location    EventLocationResponse   `json:"location"`
-	Photos      []EventPhotosResponse   `json:"photos"`
+	ID                  uuid.UUID               `json:"id"`
+	Name                string                  `json:"name"`
+	Status              string                  `json:"status"`
+	AllowTicketTransfer bool                    `json:"allow_ticket_transfer"`
+	RSVPOnly            bool                    `json:"rsvp_only"`
+	Date                string                  `json:"date"`
+	Description         string                  `json:"description"`
+	Category            EventCategoriesResponse `json:"category"`
+	Ticket              []EventPricesResponse   `json:"ticket"`
+	Location            EventLocationResponse   `json:"location"`
+	Photos              []EventPhotosResponse   `json:"photos"`
 }
 
 type EventLocationResponse struct {
@@ -103,6 +164,8 @@ type EventLocationResponse struct {
 	City        string    `json:"city" `
 	Subdistrict string    `json:"subdistrict" `
 	PostalCode  string    `json:"postal_code" `
+	Latitude    *float64  `json:"latitude"`
+	Longitude   *float64  `json:"longitude"`
 }
 
 type EventPricesResponse struct {
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+// FeedVersion identifies the current content of a feed for conditional requests
+type FeedVersion struct {
+	ETag         string
+	LastModified time.Time
+}
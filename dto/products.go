@@ -8,6 +8,7 @@ import (
 
 type ProductResponse struct {
 	ID              uuid.UUID `json:"id"`
+	Slug            string    `json:"slug"`
 	Image           string    `json:"image"`
 	Name            string    `json:"name"`
 	OriginalPrice   int       `json:"original_price"`
@@ -19,6 +20,8 @@ type ProductResponse struct {
 
 type ProductDetailResponse struct {
 	ID              uuid.UUID                `json:"id"`
+	Slug            string                   `json:"slug"`
+	CanonicalURL    string                   `json:"canonical_url"`
 	Name            string                   `json:"name"`
 	Description     string                   `json:"description"`
 	Images          []string                 `json:"images"`
@@ -30,6 +33,7 @@ type ProductDetailResponse struct {
 	TotalReview     int                      `json:"total_review"`
 	LatestReview    []*ProductReviewResponse `json:"latest_review,omitempty"`
 	Variants        []ProductVariantResponse `json:"variants"`
+	Tags            []TagResponse            `json:"tags"`
 }
 
 type ProductReviewRequest struct {
@@ -1,6 +1,8 @@
 package productsadmin
 
 import (
+	"kreasi-nusantara-api/dto"
+
 	"github.com/google/uuid"
 )
 
@@ -13,6 +15,8 @@ type ProductRequest struct {
 	ProductImages   []ProductImagesRequest    `json:"product_images" form:"images"`
 	ProductVideos   []ProductVideosRequest    `json:"product_videos" form:"videos"`
 	ProductVariants *[]ProductVariantsRequest `json:"product_variants" form:"product_variants"`
+	// Tags is a comma separated list of tag names, nil keeps the current tags
+	Tags *string `json:"tags" form:"tags"`
 }
 
 type ProductPricingRequest struct {
@@ -45,6 +49,7 @@ type ProductResponse struct {
 	ProductImages   []ProductImagesResponse   `json:"product_images"`
 	ProductVideos   []ProductVideosResponse   `json:"product_videos"`
 	Rating          float64                   `json:"rating"`
+	Tags            []dto.TagResponse         `json:"tags"`
 }
 
 type ProductResponseAdmin struct {
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type TagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+type TagMergeRequest struct {
+	SourceIDs []uuid.UUID `json:"source_ids" validate:"required,min=1"`
+	TargetID  uuid.UUID   `json:"target_id" validate:"required"`
+}
+
+type TagResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+}
+
+type TagAdminResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	ArticleCount int       `json:"article_count"`
+	ProductCount int       `json:"product_count"`
+}
+
+type TagDetailResponse struct {
+	Tag      TagResponse       `json:"tag"`
+	Articles []ArticleResponse `json:"articles"`
+	Products []ProductResponse `json:"products"`
+}
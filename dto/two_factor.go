@@ -0,0 +1,45 @@
+package dto
+
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	// OTPAuthURL is the content of the QR code authenticator apps scan
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled bool `json:"enabled"`
+	// Required is set when the account may not turn two-factor authentication off
+	Required          bool  `json:"required"`
+	RecoveryCodesLeft int64 `json:"recovery_codes_left"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallenge is the second login step of an account that passed its
+// password check
+type TwoFactorChallenge struct {
+	ChallengeToken string
+	// SetupRequired is set when the account must enrol before it can log in
+	SetupRequired bool
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code is a code of the authenticator app or a recovery code
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorSetupLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type TwoFactorEnableLoginResponse struct {
+	TokenResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport is everything stored about a user, as handed out by a data export
+type UserDataExport struct {
+	ExportedAt          time.Time                  `json:"exported_at"`
+	Profile             ExportProfile              `json:"profile"`
+	Addresses           []ExportAddress            `json:"addresses"`
+	ProductTransactions []ExportProductTransaction `json:"product_transactions"`
+	EventTransactions   []ExportEventTransaction   `json:"event_transactions"`
+	Tickets             []ExportTicket             `json:"tickets"`
+	Comments            []ExportComment            `json:"comments"`
+	ProductReviews      []ExportReview             `json:"product_reviews"`
+	EventReviews        []ExportReview             `json:"event_reviews"`
+	Waitlist            []ExportWaitlist           `json:"waitlist"`
+	LinkedAccounts      []IdentityResponse         `json:"linked_accounts"`
+	Sessions            []ExportSession            `json:"sessions"`
+	ChatHistory         []ExportChatMessage        `json:"chat_history"`
+}
+
+type ExportProfile struct {
+	ID              uuid.UUID `json:"id"`
+	Username        string    `json:"username"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Email           string    `json:"email"`
+	Phone           *string   `json:"phone"`
+	IsPhoneVerified bool      `json:"is_phone_verified"`
+	Photo           *string   `json:"photo"`
+	Bio             *string   `json:"bio"`
+	IsVerified      bool      `json:"is_verified"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ExportAddress struct {
+	Label         string    `json:"label"`
+	RecipientName string    `json:"recipient_name"`
+	Phone         string    `json:"phone"`
+	Address       string    `json:"address"`
+	City          string    `json:"city"`
+	Province      string    `json:"province"`
+	PostalCode    string    `json:"postal_code"`
+	IsPrimary     bool      `json:"is_primary"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ExportProductTransaction struct {
+	ID                string              `json:"id"`
+	TransactionDate   time.Time           `json:"transaction_date"`
+	TotalAmount       float64             `json:"total_amount"`
+	TransactionStatus string              `json:"transaction_status"`
+	TransactionMethod string              `json:"transaction_method"`
+	Items             []ExportProductItem `json:"items"`
+}
+
+type ExportProductItem struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Size        string    `json:"size"`
+	Quantity    int       `json:"quantity"`
+}
+
+type ExportEventTransaction struct {
+	ID                uuid.UUID `json:"id"`
+	EventPriceID      uuid.UUID `json:"event_price_id"`
+	TransactionDate   time.Time `json:"transaction_date"`
+	Quantity          int       `json:"quantity"`
+	TotalAmount       float64   `json:"total_amount"`
+	TransactionStatus string    `json:"transaction_status"`
+	TransactionMethod string    `json:"transaction_method"`
+	BuyerName         string    `json:"buyer_name"`
+	BuyerEmail        string    `json:"buyer_email"`
+	BuyerPhone        string    `json:"buyer_phone"`
+	BuyerIdentity     string    `json:"buyer_identity_number"`
+}
+
+type ExportTicket struct {
+	Code           string    `json:"code"`
+	EventID        uuid.UUID `json:"event_id"`
+	EventName      string    `json:"event_name"`
+	EventDate      time.Time `json:"event_date"`
+	FullName       string    `json:"full_name"`
+	Email          string    `json:"email"`
+	Phone          string    `json:"phone"`
+	IdentityNumber string    `json:"identity_number"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ExportComment struct {
+	ID        uuid.UUID  `json:"id"`
+	ArticleID uuid.UUID  `json:"article_id"`
+	ReplyTo   *uuid.UUID `json:"reply_to,omitempty"`
+	Content   string     `json:"content"`
+	Hidden    bool       `json:"hidden"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+type ExportReview struct {
+	SubjectID uuid.UUID `json:"subject_id"`
+	Rating    int       `json:"rating"`
+	Review    string    `json:"review"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ExportWaitlist struct {
+	EventPriceID uuid.UUID `json:"event_price_id"`
+	Quantity     int       `json:"quantity"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ExportSession struct {
+	DeviceName string     `json:"device_name"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ExportChatMessage struct {
+	Role    string `json:"role"`
+	Message string `json:"message"`
+}
@@ -18,8 +18,13 @@ type RegisterResponse struct {
 }
 
 type VerifyOTPRequest struct {
-	Email string `json:"email"`
-	OTP   string `json:"otp"`
+	Email string `json:"email" validate:"required,email"`
+	OTP   string `json:"otp" validate:"required"`
+}
+
+type ResendOTPRequest struct {
+	Email   string `json:"email" validate:"required,email"`
+	Purpose string `json:"purpose" validate:"required,oneof=verify_email reset_password"`
 }
 
 type LoginRequest struct {
@@ -28,9 +33,16 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	// TwoFactorRequired is set when the login has to be finished with a
+	// two-factor code and the challenge token, the tokens are empty until then
+	TwoFactorRequired      bool   `json:"two_factor_required"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	ChallengeToken         string `json:"challenge_token,omitempty"`
 }
 
 type ForgotPasswordRequest struct {
@@ -39,18 +51,20 @@ type ForgotPasswordRequest struct {
 
 type ResetPasswordRequest struct {
 	Email              string `json:"email" validate:"required,email"`
+	OTP                string `json:"otp" validate:"required"`
 	NewPassword        string `json:"new_password" validate:"required,min=8,max=32"`
 	ConfirmNewPassword string `json:"confirm_new_password" validate:"required,min=8,max=32"`
 }
 
 type UserProfileResponse struct {
-	Username  string  `json:"username"`
-	FirstName string  `json:"first_name"`
-	LastName  string  `json:"last_name"`
-	Email     string  `json:"email"`
-	Phone     *string `json:"phone"`
-	Photo     *string `json:"photo"`
-	Bio       *string `json:"bio"`
+	Username        string  `json:"username"`
+	FirstName       string  `json:"first_name"`
+	LastName        string  `json:"last_name"`
+	Email           string  `json:"email"`
+	Phone           *string `json:"phone"`
+	IsPhoneVerified bool    `json:"is_phone_verified"`
+	Photo           *string `json:"photo"`
+	Bio             *string `json:"bio"`
 }
 
 type UpdateProfileRequest struct {
@@ -60,6 +74,14 @@ type UpdateProfileRequest struct {
 	Bio       *string `json:"bio"`
 }
 
+type ChangeEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmOTPRequest struct {
+	OTP string `json:"otp" validate:"required"`
+}
+
 type UserProfilePhotoRequest struct {
 	Photo *multipart.FileHeader `json:"photo" form:"photo"`
 }
@@ -17,9 +17,12 @@ type Admin struct {
 	Photo        *string    `gorm:"type:varchar(255); not null"`
 	Token        string     `gorm:"-"`
 	IsSuperAdmin bool       `gorm:"default:false"`
+	IsActive     bool       `gorm:"not null;default:true"`
 	Products     []Products `gorm:"foreignKey:AuthorID"`
 	Articles     []Articles `gorm:"foreignKey:AuthorID"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	// DeactivatedAt is when a super admin last deactivated the admin
+	DeactivatedAt *time.Time
 }
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminInvitations are sent by super admins to onboard a new admin, who
+// accepts the invitation by choosing a username and password
+type AdminInvitations struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Email        string    `gorm:"type:varchar(100);not null;index"`
+	FirstName    string    `gorm:"type:varchar(100);not null"`
+	LastName     string    `gorm:"type:varchar(100);not null"`
+	IsSuperAdmin bool      `gorm:"not null;default:false"`
+	// InvitedBy is the super admin who sent the invitation, empty for the
+	// invitation of the first super admin
+	InvitedBy  *uuid.UUID `gorm:"type:uuid"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permissions an admin role can grant. Super admins have all of them.
+const (
+	PermissionCatalogManage    = "catalog:manage"
+	PermissionEventsManage     = "events:manage"
+	PermissionContentManage    = "content:manage"
+	PermissionCommentsModerate = "comments:moderate"
+	PermissionReportsView      = "reports:view"
+)
+
+// Permissions describes every permission that can be granted
+var Permissions = map[string]string{
+	PermissionCatalogManage:    "manage products and product categories",
+	PermissionEventsManage:     "manage events, venues, prices and tickets",
+	PermissionContentManage:    "write and publish articles and manage tags",
+	PermissionCommentsModerate: "moderate comments and banned words",
+	PermissionReportsView:      "view sales reports and dashboards",
+}
+
+// AdminRoles are named sets of permissions assigned to admins
+type AdminRoles struct {
+	ID          uuid.UUID              `gorm:"primaryKey;type:uuid"`
+	Name        string                 `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Description string                 `gorm:"type:varchar(255)"`
+	Permissions []AdminRolePermissions `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type AdminRolePermissions struct {
+	RoleID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Permission string    `gorm:"primaryKey;type:varchar(50)"`
+}
+
+type AdminRoleAssignments struct {
+	AdminID   uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	RoleID    uuid.UUID  `gorm:"primaryKey;type:uuid;index"`
+	Admin     Admin      `gorm:"constraint:OnDelete:CASCADE"`
+	Role      AdminRoles `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
@@ -7,15 +7,32 @@ import (
 	"gorm.io/gorm"
 )
 
+// Article workflow states
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusInReview  = "in_review"
+	ArticleStatusScheduled = "scheduled"
+	ArticleStatusPublished = "published"
+	ArticleStatusArchived  = "archived"
+)
+
 type Articles struct {
-	ID            uuid.UUID                `gorm:"primaryKey;type:uuid"`
-	Title         string                   `gorm:"type:varchar(100);not null"`
-	Image         string                   `gorm:"type:varchar(255)"`
-	Content       string                   `gorm:"type:text"`
-	Tags          string                   `gorm:"type:varchar(100)"`
-	LikesCount    int                      `gorm:"type:int"`
-	CommentsCount int                      `gorm:"type:int"`
-	AuthorID      uuid.UUID                `gorm:"type:uuid;not null"`
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	Title         string     `gorm:"type:varchar(100);not null"`
+	Slug          string     `gorm:"type:varchar(120);not null;default:'';index:idx_articles_slug,unique,where:slug <> ''"`
+	Image         string     `gorm:"type:varchar(255)"`
+	Content       string     `gorm:"type:text"`
+	Excerpt       string     `gorm:"type:varchar(300);not null;default:''"`
+	ReadingTime   int        `gorm:"not null;default:0"`
+	Tags          string     `gorm:"type:text"`
+	LikesCount    int        `gorm:"type:int"`
+	CommentsCount int        `gorm:"type:int"`
+	ViewsCount    int        `gorm:"not null;default:0"`
+	SharesCount   int        `gorm:"not null;default:0"`
+	AuthorID      uuid.UUID  `gorm:"type:uuid;not null"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'published';index"`
+	PublishAt     *time.Time `gorm:"index"`
+	PublishedAt   *time.Time
 	Comments      *[]ArticleComments       `gorm:"foreignKey:ArticleID"`
 	Replies       *[]ArticleCommentReplies `gorm:"foreignKey:ArticleID"`
 	CreatedAt     time.Time
@@ -24,26 +41,55 @@ type Articles struct {
 	Author        *Admin
 }
 
+// ArticleRevisions is a snapshot of an article taken every time it is saved
+type ArticleRevisions struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ArticleID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_article_revisions_version"`
+	Version        int        `gorm:"not null;uniqueIndex:idx_article_revisions_version"`
+	EditorID       uuid.UUID  `gorm:"type:uuid;not null"`
+	Title          string     `gorm:"type:varchar(100);not null"`
+	Image          string     `gorm:"type:varchar(255)"`
+	Content        string     `gorm:"type:text"`
+	Tags           string     `gorm:"type:text"`
+	RestoredFromID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time
+	Editor         *Admin `gorm:"foreignKey:EditorID"`
+}
+
 type ArticleComments struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null"`
-	ArticleID uuid.UUID `gorm:"type:uuid;not null"`
-	Content   string    `gorm:"type:text"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt           `gorm:"index"`
-	Replies   *[]ArticleCommentReplies `gorm:"foreignKey:CommentID"`
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null"`
+	ArticleID  uuid.UUID `gorm:"type:uuid;not null"`
+	Content    string    `gorm:"type:text"`
+	LikesCount int       `gorm:"not null;default:0"`
+	Hidden     bool      `gorm:"not null;default:false;index"`
+	HiddenAt   *time.Time
+	EditedAt   *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt           `gorm:"index"`
+	Replies    *[]ArticleCommentReplies `gorm:"foreignKey:CommentID"`
+	// RepliesCount is the number of visible replies, only loaded when listing comments
+	RepliesCount int `gorm:"->;-:migration"`
 }
 
+// ArticleCommentReplies are the replies of a comment thread. Replies to other
+// replies stay in the thread of the comment and mention the author they answer.
 type ArticleCommentReplies struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null"`
-	ArticleID uuid.UUID `gorm:"type:uuid;not null"`
-	CommentID uuid.UUID `gorm:"type:uuid;not null"`
-	Content   string    `gorm:"type:text"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null"`
+	ArticleID       uuid.UUID  `gorm:"type:uuid;not null"`
+	CommentID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ParentReplyID   *uuid.UUID `gorm:"type:uuid;index"`
+	MentionedUserID *uuid.UUID `gorm:"type:uuid"`
+	Content         string     `gorm:"type:text"`
+	LikesCount      int        `gorm:"not null;default:0"`
+	Hidden          bool       `gorm:"not null;default:false;index"`
+	HiddenAt        *time.Time
+	EditedAt        *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
 }
 
 type ArticleLikes struct {
@@ -52,3 +98,19 @@ type ArticleLikes struct {
 	ArticleID uuid.UUID `gorm:"type:uuid;not null"`
 	CreatedAt time.Time
 }
+
+// ArticleCommentLikes are likes on comments and replies. TargetType is one of
+// the comment target types.
+type ArticleCommentLikes struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TargetType string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_comment_likes_user"`
+	TargetID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_comment_likes_user"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_comment_likes_user"`
+	CreatedAt  time.Time
+}
+
+// CommentCursor points at the last reply of a page of replies
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
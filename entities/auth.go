@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokens are the refresh tokens of a session. Every refresh replaces
+// the token with a new one of the same session, so a token that is used twice
+// was stolen and ends its whole session. Only the token hash is stored.
+// UserID holds the id of a user or an admin, Role tells which.
+type RefreshTokens struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	SessionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Role      string    `gorm:"type:varchar(20);not null"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// UserSessions are the logins of users and admins, one per device. The access
+// tokens of a session carry its ID so it can be revoked on its own.
+type UserSessions struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Role       string    `gorm:"type:varchar(20);not null"`
+	DeviceName string    `gorm:"type:varchar(100)"`
+	UserAgent  string    `gorm:"type:varchar(255)"`
+	IPAddress  string    `gorm:"type:varchar(45)"`
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Kinds of security events
+const (
+	SecurityEventAccountLocked    = "account_locked"
+	SecurityEventIPLocked         = "ip_locked"
+	SecurityEventOTPInvalidated   = "otp_invalidated"
+	SecurityEventTwoFactorReset   = "two_factor_reset"
+	SecurityEventAdminDeactivated = "admin_deactivated"
+	SecurityEventAdminActivated   = "admin_activated"
+)
+
+// SecurityEvents is the audit trail of lockouts and other defensive actions.
+// Account is the email address the attempts were made for.
+type SecurityEvents struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Type      string    `gorm:"type:varchar(30);not null;index"`
+	Scope     string    `gorm:"type:varchar(30);not null"`
+	Account   string    `gorm:"type:varchar(100);index"`
+	IPAddress string    `gorm:"type:varchar(45)"`
+	Details   string    `gorm:"type:text"`
+	CreatedAt time.Time
+}
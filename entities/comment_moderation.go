@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of article discussion posts that can be reported and moderated
+const (
+	CommentTargetComment = "comment"
+	CommentTargetReply   = "reply"
+)
+
+// Report states
+const (
+	CommentReportOpen      = "open"
+	CommentReportResolved  = "resolved"
+	CommentReportDismissed = "dismissed"
+)
+
+// ArticleCommentReports is a user's report of an abusive comment or reply
+type ArticleCommentReports struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TargetType string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_comment_reports_reporter"`
+	TargetID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_comment_reports_reporter"`
+	ReporterID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_comment_reports_reporter"`
+	ArticleID  uuid.UUID `gorm:"type:uuid;not null"`
+	Reason     string    `gorm:"type:varchar(255);not null"`
+	Status     string    `gorm:"type:varchar(20);not null;default:'open';index"`
+	ResolvedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// BannedWords are words and phrases that comments and replies may not contain
+type BannedWords struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Word      string    `gorm:"type:varchar(50);uniqueIndex;not null"`
+	CreatedAt time.Time
+}
+
+// CommentModerationItem is a reported or hidden comment or reply in the moderation queue
+type CommentModerationItem struct {
+	TargetType     string
+	TargetID       uuid.UUID
+	ArticleID      uuid.UUID
+	UserID         uuid.UUID
+	Content        string
+	Hidden         bool
+	ReportCount    int
+	Reasons        string // separated by the ASCII unit separator
+	LastReportedAt *time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Channels content can be shared through
+const (
+	ShareChannelWhatsApp = "whatsapp"
+	ShareChannelFacebook = "facebook"
+	ShareChannelX        = "x"
+	ShareChannelTelegram = "telegram"
+	ShareChannelEmail    = "email"
+	ShareChannelCopyLink = "copy_link"
+	ShareChannelOther    = "other"
+)
+
+// ContentShares records a user sharing a product, article or event. The
+// object types are the slug types.
+type ContentShares struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ObjectType string    `gorm:"type:varchar(20);not null;index:idx_content_shares_object"`
+	ObjectID   uuid.UUID `gorm:"type:uuid;not null;index:idx_content_shares_object"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null"`
+	Channel    string    `gorm:"type:varchar(20);not null"`
+	CreatedAt  time.Time
+}
+
+// ShareChannelCount is the number of shares made through a channel
+type ShareChannelCount struct {
+	Channel string
+	Count   int
+}
+
+// EngagementTotals sums the views and shares of all products, articles and events
+type EngagementTotals struct {
+	Views  int
+	Shares int
+}
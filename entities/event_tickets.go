@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTicket is a single admission issued from a paid event transaction.
+// HolderUserID is nil when the ticket was handed to an email without an account.
+type EventTicket struct {
+	ID                 uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	EventTransactionID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EventID            uuid.UUID  `gorm:"type:uuid;not null;index"`
+	HolderUserID       *uuid.UUID `gorm:"type:uuid;index"`
+	Code               string     `gorm:"type:varchar(32);not null;uniqueIndex"`
+	IdentityNumber     string     `gorm:"type:varchar(100);not null"`
+	FullName           string     `gorm:"type:varchar(100);not null"`
+	Email              string     `gorm:"type:varchar(100);not null;index"`
+	Phone              string     `gorm:"type:varchar(100);not null"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Event              Events
+}
+
+type EventTicketTransfer struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	EventTicketID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	FromUserID    *uuid.UUID `gorm:"type:uuid"`
+	FromFullName  string     `gorm:"type:varchar(100);not null"`
+	FromEmail     string     `gorm:"type:varchar(100);not null"`
+	ToUserID      *uuid.UUID `gorm:"type:uuid"`
+	ToFullName    string     `gorm:"type:varchar(100);not null"`
+	ToEmail       string     `gorm:"type:varchar(100);not null"`
+	RevokedCode   string     `gorm:"type:varchar(32);not null"`
+	CreatedAt     time.Time
+}
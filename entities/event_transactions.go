@@ -19,6 +19,17 @@ type EventTransaction struct {
 	Buyer             EventTransactionBuyer
 }
 
+// BookingResult is the outcome of storing an event transaction while its
+// ticket tier is locked
+type BookingResult string
+
+const (
+	BookingCreated            BookingResult = "created"
+	BookingSoldOut            BookingResult = "sold_out"
+	BookingReservationInvalid BookingResult = "reservation_invalid"
+	BookingAlreadyRSVPed      BookingResult = "already_rsvped"
+)
+
 type EventTransactionBuyer struct {
 	ID                 uuid.UUID `gorm:"primary_key;type:uuid"`
 	EventTransactionID uuid.UUID `gorm:"type:uuid;not null"`
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusNotified  = "notified"
+	WaitlistStatusConverted = "converted"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+type EventWaitlist struct {
+	ID               uuid.UUID `gorm:"primaryKey;type:uuid"`
+	EventPriceID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	Quantity         int       `gorm:"type:int;not null"`
+	Status           string    `gorm:"type:varchar(20);not null;default:waiting"`
+	ReservationToken *string   `gorm:"type:varchar(64);uniqueIndex"`
+	ReservedUntil    *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	EventPrice       EventPrices `gorm:"foreignKey:EventPriceID"`
+	User             User        `gorm:"foreignKey:UserID"`
+}
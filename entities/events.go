@@ -8,20 +8,25 @@ import (
 )
 
 type Events struct {
-	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
-	Name        string    `gorm:"type:varchar(100);not null"`
-	CategoryID  int       `gorm:"type:int;not null"`
-	LocationID  uuid.UUID `gorm:"type:uuid;not null"`
-	Status      bool      `gorm:"default:true"`
-	Date        time.Time
-	Photos      []EventPhotos `gorm:"foreignKey:EventID;references:ID"`
-	Description string        `gorm:"type:text;not null"`
-	Prices      []EventPrices `gorm:"foreignKey:EventID;references:ID"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-	Category    EventCategories
-	Location    EventLocations
+	ID                  uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name                string    `gorm:"type:varchar(100);not null"`
+	Slug                string    `gorm:"type:varchar(120);not null;default:'';index:idx_events_slug,unique,where:slug <> ''"`
+	CategoryID          int       `gorm:"type:int;not null"`
+	LocationID          uuid.UUID `gorm:"type:uuid;not null"`
+	Status              bool      `gorm:"default:true"`
+	AllowTicketTransfer bool      `gorm:"default:true"`
+	RSVPOnly            bool      `gorm:"default:false"`
+	Date                time.Time
+	Photos              []EventPhotos `gorm:"foreignKey:EventID;references:ID"`
+	Description         string        `gorm:"type:text;not null"`
+	Prices              []EventPrices `gorm:"foreignKey:EventID;references:ID"`
+	ViewsCount          int           `gorm:"not null;default:0"`
+	SharesCount         int           `gorm:"not null;default:0"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
+	Category            EventCategories
+	Location            EventLocations
 }
 
 type EventCategories struct {
@@ -41,12 +46,27 @@ type EventLocations struct {
 	City        string    `gorm:"type:varchar(100);not null"`
 	Subdistrict string    `gorm:"type:varchar(100);not null"`
 	PostalCode  string    `gorm:"type:varchar(100);not null"`
+	Latitude    *float64  `gorm:"type:double precision"`
+	Longitude   *float64  `gorm:"type:double precision"`
 	Events      []Events  `gorm:"foreignKey:LocationID"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
+type PostalCodeCentroids struct {
+	PostalCode string  `gorm:"primaryKey;type:varchar(10)"`
+	Latitude   float64 `gorm:"type:double precision;not null"`
+	Longitude  float64 `gorm:"type:double precision;not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type EventDistance struct {
+	EventID  uuid.UUID `gorm:"type:uuid"`
+	Distance float64
+}
+
 type EventPhotos struct {
 	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
 	EventID   uuid.UUID `gorm:"type:uuid;not null"`
@@ -69,6 +89,29 @@ type EventPrices struct {
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
 
+type EventReviews struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_reviews_user_event"`
+	EventID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_reviews_user_event"`
+	Rating    int       `gorm:"type:int;not null"`
+	Review    string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time
+	User      User
+}
+
+type EventRatingSummary struct {
+	EventID       uuid.UUID `gorm:"type:uuid"`
+	AverageRating float64
+	TotalReview   int `gorm:"type:int"`
+}
+
+type EventCategoryRatingSummary struct {
+	CategoryID    int
+	Name          string
+	AverageRating float64
+	TotalReview   int `gorm:"type:int"`
+}
+
 type EventTicketType struct {
 	ID        int           `gorm:"primaryKey;autoIncrement"`
 	Name      string        `gorm:"type:varchar(100);not null"`
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedStamp identifies a version of an article feed. It changes whenever an
+// article in the feed is published, updated or removed.
+type FeedStamp struct {
+	Count        int64
+	LastModified time.Time
+}
+
+// ArticleTagName is a tag of an article, used to list the tags of many articles at once
+type ArticleTagName struct {
+	ArticleID uuid.UUID
+	Name      string
+	Slug      string
+}
@@ -10,6 +10,7 @@ import (
 type Products struct {
 	ID              uuid.UUID          `gorm:"primaryKey;type:uuid"`
 	Name            string             `gorm:"type:varchar(100)"`
+	Slug            string             `gorm:"type:varchar(120);not null;default:'';index:idx_products_slug,unique,where:slug <> ''"`
 	Description     string             `gorm:"type:varchar(255)"`
 	MinOrder        int                `gorm:"type:int"`
 	AuthorID        uuid.UUID          `gorm:"type:uuid"`
@@ -19,6 +20,8 @@ type Products struct {
 	ProductImages   []ProductImages    `gorm:"foreignKey:ProductID;references:ID"`
 	ProductVideos   []ProductVideos    `gorm:"foreignKey:ProductID;references:ID"`
 	ProductReviews  *[]ProductReviews  `gorm:"foreignKey:ProductID;references:ID"`
+	ViewsCount      int                `gorm:"not null;default:0"`
+	SharesCount     int                `gorm:"not null;default:0"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
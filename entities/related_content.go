@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleProducts links an article to the products featured in it, in the
+// order chosen by the admin
+type ArticleProducts struct {
+	ArticleID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProductID uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	Position  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time
+}
+
+// RelatedArticleCandidate is a published article that may be suggested next
+// to another one, with the number of tags both share
+type RelatedArticleCandidate struct {
+	ID          uuid.UUID
+	Slug        string
+	Title       string
+	Image       string
+	Excerpt     string
+	ReadingTime int
+	CreatedAt   time.Time
+	SharedTags  int
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of public content addressable by slug
+const (
+	SlugTypeProduct = "product"
+	SlugTypeArticle = "article"
+	SlugTypeEvent   = "event"
+)
+
+// SlugRedirects keeps the previous slugs of renamed content so old links still resolve
+type SlugRedirects struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ObjectType string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_slug_redirects_slug"`
+	Slug       string    `gorm:"type:varchar(120);not null;uniqueIndex:idx_slug_redirects_slug"`
+	ObjectID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt  time.Time
+}
+
+// SlugSource is the ID and name of content that still needs a slug
+type SlugSource struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// SitemapEntry is a public page listed in the sitemap
+type SitemapEntry struct {
+	Slug      string
+	UpdatedAt time.Time
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Tags struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name      string    `gorm:"type:varchar(50);not null"`
+	Slug      string    `gorm:"type:varchar(60);uniqueIndex;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ArticleTags struct {
+	ArticleID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TagID     uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	CreatedAt time.Time
+}
+
+type ProductTags struct {
+	ProductID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TagID     uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	CreatedAt time.Time
+}
+
+// TagSummary is a tag along with the number of articles and products using it
+type TagSummary struct {
+	ID           uuid.UUID
+	Name         string
+	Slug         string
+	ArticleCount int
+	ProductCount int
+}
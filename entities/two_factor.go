@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of accounts that can use two-factor authentication. Users and admins
+// live in different tables, so an account is its ID and its type.
+const (
+	AccountTypeUser  = "user"
+	AccountTypeAdmin = "admin"
+)
+
+// TwoFactorAuth is the authenticator app secret of an account. It is pending
+// until EnabledAt is set by confirming a first code. LastUsedStep is the
+// time step of the last accepted code, so a code cannot be used twice.
+type TwoFactorAuth struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
+	AccountID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_two_factor_account"`
+	AccountType   string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_two_factor_account"`
+	Secret        string    `gorm:"type:varchar(64);not null"`
+	EnabledAt     *time.Time
+	LastUsedStep  int64                    `gorm:"not null;default:0"`
+	RecoveryCodes []TwoFactorRecoveryCodes `gorm:"foreignKey:TwoFactorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TwoFactorRecoveryCodes are single-use codes for when the authenticator app
+// is lost. Only their hashes are stored.
+type TwoFactorRecoveryCodes struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TwoFactorID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CodeHash    string    `gorm:"type:varchar(64);not null"`
+	UsedAt      *time.Time
+	CreatedAt   time.Time
+}
@@ -18,6 +18,7 @@ type User struct {
 	Photo                 *string                  `gorm:"type:varchar(255)"`
 	Bio                   *string                  `gorm:"type:varchar(255)"`
 	IsVerified            bool                     `gorm:"default:false"`
+	IsPhoneVerified       bool                     `gorm:"default:false"`
 	Addresses             *[]UserAddresses         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	ArticleComments       *[]ArticleComments       `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	ArticleCommentReplies *[]ArticleCommentReplies `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
@@ -26,4 +27,7 @@ type User struct {
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 	DeletedAt             gorm.DeletedAt `gorm:"index"`
+	// VerifyBy is when an unverified account gets deleted. Accounts registered
+	// before email verification was required have none and are kept.
+	VerifyBy *time.Time `gorm:"index"`
 }
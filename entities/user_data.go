@@ -0,0 +1,18 @@
+package entities
+
+// UserData is everything stored about a user, gathered for a data export
+type UserData struct {
+	User                User
+	Addresses           []UserAddresses
+	ProductTransactions []ProductTransaction
+	Carts               []Cart
+	EventTransactions   []EventTransaction
+	Tickets             []EventTicket
+	Comments            []ArticleComments
+	Replies             []ArticleCommentReplies
+	ProductReviews      []ProductReviews
+	EventReviews        []EventReviews
+	Waitlist            []EventWaitlist
+	Identities          []UserIdentities
+	Sessions            []UserSessions
+}
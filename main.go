@@ -23,6 +23,7 @@ func init() {
 
 func main() {
 	e := echo.New()
+	e.IPExtractor = config.InitIPExtractor()
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{
 			"http://localhost:5173",
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	msg "kreasi-nusantara-api/constants/message"
+	http_util "kreasi-nusantara-api/utils/http"
+	"kreasi-nusantara-api/utils/token"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PermissionChecker tells whether the roles of an admin grant a permission
+type PermissionChecker interface {
+	HasPermission(c echo.Context, adminID uuid.UUID, permission string) (bool, error)
+}
+
+// RequirePermission lets super admins through, and admins with a role that
+// grants the permission
+func RequirePermission(checker PermissionChecker, permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := token.NewTokenUtil().GetClaims(c)
+			switch strings.ToLower(claims.Role) {
+			case "super_admin":
+				return next(c)
+			case "admin":
+			default:
+				return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
+			}
+
+			allowed, err := checker.HasPermission(c, claims.ID, permission)
+			if err != nil {
+				return http_util.HandleErrorResponse(c, http.StatusInternalServerError, msg.FAILED_CHECK_PERMISSION)
+			}
+			if !allowed {
+				return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.PERMISSION_DENIED)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/entities"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -19,6 +20,10 @@ type AdminRepository interface {
 	UpdateAdmin(ctx context.Context, admin *entities.Admin) error
 	DeleteAdmin(ctx context.Context, adminID uuid.UUID) error
 	SearchAdminByUsername(ctx context.Context, req *dto_base.SearchRequest) ([]entities.Admin, int64, error)
+	EmailExists(ctx context.Context, email string) (bool, error)
+	UsernameExists(ctx context.Context, username string) (bool, error)
+	SetActive(ctx context.Context, adminID uuid.UUID, active bool) error
+	HasActiveSuperAdmin(ctx context.Context) (bool, error)
 }
 
 type adminRepository struct {
@@ -149,7 +154,8 @@ func (ar *adminRepository) UpdateAdmin(ctx context.Context, admin *entities.Admi
 	if err := ctx.Err(); err != nil {
 		return err
 	}
-	return ar.DB.Updates(admin).Error
+	// Selecting the columns also saves false, so revoking super admin rights sticks
+	return ar.DB.WithContext(ctx).Select("Username", "FirstName", "LastName", "Email", "Password", "Photo", "IsSuperAdmin").Updates(admin).Error
 }
 
 func (ar *adminRepository) DeleteAdmin(ctx context.Context, adminID uuid.UUID) error {
@@ -160,3 +166,54 @@ func (ar *adminRepository) DeleteAdmin(ctx context.Context, adminID uuid.UUID) e
 
 	return nil
 }
+
+// EmailExists also counts deleted admins, their email stays taken
+func (ar *adminRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Unscoped().Model(&entities.Admin{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+func (ar *adminRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Unscoped().Model(&entities.Admin{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+func (ar *adminRepository) SetActive(ctx context.Context, adminID uuid.UUID, active bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"is_active": active}
+	if !active {
+		updates["deactivated_at"] = time.Now()
+	}
+
+	result := ar.DB.WithContext(ctx).Model(&entities.Admin{}).Where("id = ?", adminID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (ar *adminRepository) HasActiveSuperAdmin(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.Admin{}).Where("is_super_admin = ? AND is_active = ?", true, true).Count(&count).Error
+	return count > 0, err
+}
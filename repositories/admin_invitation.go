@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AdminInvitationRepository interface {
+	CreateInvitation(ctx context.Context, invitation *entities.AdminInvitations) error
+	GetInvitation(ctx context.Context, id uuid.UUID) (*entities.AdminInvitations, error)
+	GetPendingInvitations(ctx context.Context) ([]entities.AdminInvitations, error)
+	RevokeInvitation(ctx context.Context, id uuid.UUID) error
+	AcceptInvitation(ctx context.Context, id uuid.UUID, admin *entities.Admin) error
+	HasPendingInvitation(ctx context.Context, email string) (bool, error)
+}
+
+type adminInvitationRepository struct {
+	DB *gorm.DB
+}
+
+func NewAdminInvitationRepository(db *gorm.DB) *adminInvitationRepository {
+	return &adminInvitationRepository{
+		DB: db,
+	}
+}
+
+// pendingInvitations narrows a query to invitations that can still be accepted
+func pendingInvitations(db *gorm.DB) *gorm.DB {
+	return db.Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", time.Now())
+}
+
+// CreateInvitation stores a new invitation and revokes earlier ones sent to
+// the same email, so only the latest invite link works
+func (ar *adminInvitationRepository) CreateInvitation(ctx context.Context, invitation *entities.AdminInvitations) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.AdminInvitations{}).Scopes(pendingInvitations).
+			Where("email = ?", invitation.Email).
+			Update("revoked_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(invitation).Error
+	})
+}
+
+func (ar *adminInvitationRepository) GetInvitation(ctx context.Context, id uuid.UUID) (*entities.AdminInvitations, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	invitation := new(entities.AdminInvitations)
+	if err := ar.DB.WithContext(ctx).Where("id = ?", id).First(invitation).Error; err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (ar *adminInvitationRepository) GetPendingInvitations(ctx context.Context) ([]entities.AdminInvitations, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var invitations []entities.AdminInvitations
+	err := ar.DB.WithContext(ctx).Scopes(pendingInvitations).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+func (ar *adminInvitationRepository) RevokeInvitation(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	result := ar.DB.WithContext(ctx).Model(&entities.AdminInvitations{}).Scopes(pendingInvitations).
+		Where("id = ?", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation marks the invitation as accepted and creates its admin.
+// An invitation that was already accepted, revoked or expired is not found.
+func (ar *adminInvitationRepository) AcceptInvitation(ctx context.Context, id uuid.UUID, admin *entities.Admin) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.AdminInvitations{}).Scopes(pendingInvitations).
+			Where("id = ?", id).
+			Update("accepted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(admin).Error
+	})
+}
+
+func (ar *adminInvitationRepository) HasPendingInvitation(ctx context.Context, email string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.AdminInvitations{}).Scopes(pendingInvitations).
+		Where("email = ?", email).
+		Count(&count).Error
+	return count > 0, err
+}
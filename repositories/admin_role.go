@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminRoleRepository interface {
+	GetRoles(ctx context.Context) ([]entities.AdminRoles, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (*entities.AdminRoles, error)
+	RoleNameExists(ctx context.Context, name string, excludeID uuid.UUID) (bool, error)
+	CreateRole(ctx context.Context, role *entities.AdminRoles) error
+	UpdateRole(ctx context.Context, role *entities.AdminRoles) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	CountRoles(ctx context.Context, ids []uuid.UUID) (int64, error)
+	GetAdminRoles(ctx context.Context, adminID uuid.UUID) ([]entities.AdminRoles, error)
+	SetAdminRoles(ctx context.Context, adminID uuid.UUID, roleIDs []uuid.UUID) error
+	HasPermission(ctx context.Context, adminID uuid.UUID, permission string) (bool, error)
+	SeedRoles(ctx context.Context, roles []entities.AdminRoles) error
+}
+
+type adminRoleRepository struct {
+	DB *gorm.DB
+}
+
+func NewAdminRoleRepository(db *gorm.DB) *adminRoleRepository {
+	return &adminRoleRepository{
+		DB: db,
+	}
+}
+
+func (ar *adminRoleRepository) GetRoles(ctx context.Context) ([]entities.AdminRoles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var roles []entities.AdminRoles
+	err := ar.DB.WithContext(ctx).Preload("Permissions").Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (ar *adminRoleRepository) GetRoleByID(ctx context.Context, id uuid.UUID) (*entities.AdminRoles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	role := new(entities.AdminRoles)
+	if err := ar.DB.WithContext(ctx).Preload("Permissions").Where("id = ?", id).First(role).Error; err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (ar *adminRoleRepository) RoleNameExists(ctx context.Context, name string, excludeID uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.AdminRoles{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateRole stores a role together with its permissions
+func (ar *adminRoleRepository) CreateRole(ctx context.Context, role *entities.AdminRoles) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Create(role).Error
+}
+
+// UpdateRole renames a role and replaces its permissions
+func (ar *adminRoleRepository) UpdateRole(ctx context.Context, role *entities.AdminRoles) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.AdminRoles{}).Where("id = ?", role.ID).Updates(map[string]interface{}{
+			"name":        role.Name,
+			"description": role.Description,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&entities.AdminRolePermissions{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+// DeleteRole deletes a role and takes it away from the admins it was assigned to
+func (ar *adminRoleRepository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&entities.AdminRoleAssignments{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&entities.AdminRolePermissions{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&entities.AdminRoles{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (ar *adminRoleRepository) CountRoles(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.AdminRoles{}).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
+
+func (ar *adminRoleRepository) GetAdminRoles(ctx context.Context, adminID uuid.UUID) ([]entities.AdminRoles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var roles []entities.AdminRoles
+	err := ar.DB.WithContext(ctx).Preload("Permissions").
+		Where("id IN (?)", ar.DB.Model(&entities.AdminRoleAssignments{}).Select("role_id").Where("admin_id = ?", adminID)).
+		Order("name ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+// SetAdminRoles replaces the roles assigned to an admin
+func (ar *adminRoleRepository) SetAdminRoles(ctx context.Context, adminID uuid.UUID, roleIDs []uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("admin_id = ?", adminID).Delete(&entities.AdminRoleAssignments{}).Error; err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+
+		assignments := make([]entities.AdminRoleAssignments, len(roleIDs))
+		for i, roleID := range roleIDs {
+			assignments[i] = entities.AdminRoleAssignments{AdminID: adminID, RoleID: roleID}
+		}
+		return tx.Omit(clause.Associations).Create(&assignments).Error
+	})
+}
+
+// HasPermission reports whether any role assigned to the admin grants the permission
+func (ar *adminRoleRepository) HasPermission(ctx context.Context, adminID uuid.UUID, permission string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.AdminRoleAssignments{}).
+		Joins("JOIN admin_role_permissions ON admin_role_permissions.role_id = admin_role_assignments.role_id").
+		Where("admin_role_assignments.admin_id = ? AND admin_role_permissions.permission = ?", adminID, permission).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SeedRoles creates the given roles when there are no roles yet and assigns
+// them to every existing admin, so admins keep the access they had before
+// roles were introduced
+func (ar *adminRoleRepository) SeedRoles(ctx context.Context, roles []entities.AdminRoles) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entities.AdminRoles{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(&roles).Error; err != nil {
+			return err
+		}
+
+		var adminIDs []uuid.UUID
+		if err := tx.Model(&entities.Admin{}).Where("is_super_admin = ?", false).Pluck("id", &adminIDs).Error; err != nil {
+			return err
+		}
+
+		var assignments []entities.AdminRoleAssignments
+		for _, adminID := range adminIDs {
+			for _, role := range roles {
+				assignments = append(assignments, entities.AdminRoleAssignments{AdminID: adminID, RoleID: role.ID})
+			}
+		}
+		if len(assignments) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&assignments).Error
+	})
+}
@@ -19,8 +19,21 @@ type ArticleRepository interface {
 	GetCommentsByArticleID(ctx context.Context, articleId uuid.UUID, req *dto_base.PaginationRequest) ([]entities.ArticleComments, int64, error)
 	AddCommentToArticle(ctx context.Context, comment *entities.ArticleComments) error
 	ReplyToComment(ctx context.Context, reply *entities.ArticleCommentReplies) error
+	GetRepliesByCommentID(ctx context.Context, commentId uuid.UUID, cursor *entities.CommentCursor, limit int) ([]entities.ArticleCommentReplies, error)
+	GetCommentByID(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID) (*entities.ArticleComments, error)
+	GetReplyByID(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) (*entities.ArticleCommentReplies, error)
+	UpdateComment(ctx context.Context, comment *entities.ArticleComments) error
+	UpdateReply(ctx context.Context, reply *entities.ArticleCommentReplies) error
+	DeleteComment(ctx context.Context, comment *entities.ArticleComments) error
+	DeleteReply(ctx context.Context, reply *entities.ArticleCommentReplies) error
+	LikeComment(ctx context.Context, like *entities.ArticleCommentLikes) error
+	UnlikeComment(ctx context.Context, targetType string, targetId uuid.UUID, userId uuid.UUID) error
+	GetLikedCommentIDs(ctx context.Context, userId uuid.UUID, targetType string, targetIds []uuid.UUID) ([]uuid.UUID, error)
 	LikeArticle(ctx context.Context, userId uuid.UUID, articleId uuid.UUID) error
 	UnlikeArticle(ctx context.Context, userId uuid.UUID, articleId uuid.UUID) error
+
+	GetRelatedArticleCandidates(ctx context.Context, articleId uuid.UUID, limit int) ([]entities.RelatedArticleCandidate, error)
+	GetArticleProducts(ctx context.Context, articleId uuid.UUID) ([]entities.Products, error)
 }
 
 type articleRepository struct {
@@ -42,7 +55,7 @@ func (ar *articleRepository) GetArticles(ctx context.Context, req *dto_base.Pagi
 	var totalData int64
 
 	offset := (req.Page - 1) * req.Limit
-	query := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Order(req.SortBy).Count(&totalData).Limit(req.Limit).Offset(offset)
+	query := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("status = ?", entities.ArticleStatusPublished).Order(req.SortBy).Count(&totalData).Limit(req.Limit).Offset(offset)
 
 	err := query.Find(&articles).Error
 	if err != nil {
@@ -59,7 +72,7 @@ func (ar *articleRepository) GetArticleByID(ctx context.Context, articleId uuid.
 
 	var article entities.Articles
 
-	err := ar.DB.WithContext(ctx).Preload(clause.Associations).Where("id = ?", articleId).Find(&article).Error
+	err := ar.DB.WithContext(ctx).Preload(clause.Associations).Where("id = ? AND status = ?", articleId, entities.ArticleStatusPublished).First(&article).Error
 	if err != nil {
 		return nil, err
 	}
@@ -77,12 +90,12 @@ func (ar *articleRepository) SearchArticles(ctx context.Context, req *dto_base.S
 
 	offset := *req.Offset
 
-	countQuery := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("title ILIKE ?", "%"+req.Item+"%")
+	countQuery := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("status = ?", entities.ArticleStatusPublished).Where("title ILIKE ?", "%"+req.Item+"%")
 	if err := countQuery.Count(&totalData).Error; err != nil {
 		return nil, 0, err
 	}
 
-	query := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("title ILIKE ?", "%"+req.Item+"%").Order(req.SortBy).Limit(req.Limit).Offset(offset)
+	query := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("status = ?", entities.ArticleStatusPublished).Where("title ILIKE ?", "%"+req.Item+"%").Order(req.SortBy).Limit(req.Limit).Offset(offset)
 	if err := query.Find(&articles).Error; err != nil {
 		return nil, 0, err
 	}
@@ -98,19 +111,20 @@ func (ar *articleRepository) GetCommentsByArticleID(ctx context.Context, article
 	var comments []entities.ArticleComments
 	var totalData int64
 
-	db := ar.DB.WithContext(ctx).Where("article_id = ?", articleId)
+	db := ar.DB.WithContext(ctx).Model(&entities.ArticleComments{}).Where("article_id = ? AND hidden = ?", articleId, false)
 
-	err := db.Find(&comments).Count(&totalData).Error
+	err := db.Count(&totalData).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	if req.SortBy != "" {
-		db = db.Order(req.SortBy)
-	}
-
 	offset := (req.Page - 1) * req.Limit
-	err = db.Offset(offset).Limit(req.Limit).Find(&comments).Error
+	err = db.
+		Select(`article_comments.*, (SELECT COUNT(*) FROM article_comment_replies
+			WHERE article_comment_replies.comment_id = article_comments.id
+			AND article_comment_replies.hidden = false AND article_comment_replies.deleted_at IS NULL) AS replies_count`).
+		Order(commentSortOrder(req.SortBy)).
+		Offset(offset).Limit(req.Limit).Find(&comments).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -123,35 +137,128 @@ func (ar *articleRepository) AddCommentToArticle(ctx context.Context, comment *e
 		return err
 	}
 
-	err := ar.DB.WithContext(ctx).Create(comment).Error
-	if err != nil {
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+
+		return refreshCommentsCount(tx, comment.ArticleID)
+	})
+}
+
+func (ar *articleRepository) ReplyToComment(ctx context.Context, reply *entities.ArticleCommentReplies) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	err = ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("id = ?", comment.ArticleID).UpdateColumn("comments_count", gorm.Expr("comments_count + ?", 1)).Error
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reply).Error; err != nil {
+			return err
+		}
+
+		return refreshCommentsCount(tx, reply.ArticleID)
+	})
+}
+
+// GetCommentByID returns a visible comment of the article
+func (ar *articleRepository) GetCommentByID(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID) (*entities.ArticleComments, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var comment entities.ArticleComments
+	err := ar.DB.WithContext(ctx).Where("id = ? AND article_id = ? AND hidden = ?", commentId, articleId, false).First(&comment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// GetReplyByID returns a visible reply to a comment of the article
+func (ar *articleRepository) GetReplyByID(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) (*entities.ArticleCommentReplies, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var reply entities.ArticleCommentReplies
+	err := ar.DB.WithContext(ctx).Where("id = ? AND comment_id = ? AND article_id = ? AND hidden = ?", replyId, commentId, articleId, false).First(&reply).Error
 	if err != nil {
+		return nil, err
+	}
+
+	return &reply, nil
+}
+
+func (ar *articleRepository) UpdateComment(ctx context.Context, comment *entities.ArticleComments) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	return err
+	return ar.DB.WithContext(ctx).Model(comment).Updates(map[string]interface{}{
+		"content":   comment.Content,
+		"edited_at": comment.EditedAt,
+	}).Error
 }
 
-func (ar *articleRepository) ReplyToComment(ctx context.Context, reply *entities.ArticleCommentReplies) error {
+func (ar *articleRepository) UpdateReply(ctx context.Context, reply *entities.ArticleCommentReplies) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	err := ar.DB.WithContext(ctx).Create(reply).Error
-	if err != nil {
+	return ar.DB.WithContext(ctx).Model(reply).Updates(map[string]interface{}{
+		"content":   reply.Content,
+		"edited_at": reply.EditedAt,
+	}).Error
+}
+
+// DeleteComment deletes a comment together with its replies
+func (ar *articleRepository) DeleteComment(ctx context.Context, comment *entities.ArticleComments) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	err = ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("id = ?", reply.ArticleID).UpdateColumn("comments_count", gorm.Expr("comments_count + ?", 1)).Error
-	if err != nil {
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&entities.ArticleCommentReplies{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(comment).Error; err != nil {
+			return err
+		}
+
+		return refreshCommentsCount(tx, comment.ArticleID)
+	})
+}
+
+func (ar *articleRepository) DeleteReply(ctx context.Context, reply *entities.ArticleCommentReplies) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	return err
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(reply).Error; err != nil {
+			return err
+		}
+
+		return refreshCommentsCount(tx, reply.ArticleID)
+	})
+}
+
+// refreshCommentsCount recounts the visible comments and replies of an article.
+// Replies under a hidden or deleted comment are not counted.
+func refreshCommentsCount(tx *gorm.DB, articleId uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE articles SET comments_count = (
+			SELECT COUNT(*) FROM article_comments c
+			WHERE c.article_id = articles.id AND c.deleted_at IS NULL AND c.hidden = false
+		) + (
+			SELECT COUNT(*) FROM article_comment_replies r
+			JOIN article_comments c ON c.id = r.comment_id
+			WHERE r.article_id = articles.id AND r.deleted_at IS NULL AND r.hidden = false
+				AND c.deleted_at IS NULL AND c.hidden = false
+		)
+		WHERE id = ?`, articleId).Error
 }
 
 func (ar *articleRepository) LikeArticle(ctx context.Context, userId uuid.UUID, articleId uuid.UUID) error {
@@ -211,3 +318,157 @@ func (ar *articleRepository) UnlikeArticle(ctx context.Context, userId uuid.UUID
 
 	return nil
 }
+
+// GetRelatedArticleCandidates returns other published articles, those sharing
+// the most tags with the article first and the most recent ones after them
+func (ar *articleRepository) GetRelatedArticleCandidates(ctx context.Context, articleId uuid.UUID, limit int) ([]entities.RelatedArticleCandidate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var candidates []entities.RelatedArticleCandidate
+	err := ar.DB.WithContext(ctx).
+		Model(&entities.Articles{}).
+		Select(`articles.id, articles.slug, articles.title, articles.image, articles.excerpt, articles.reading_time, articles.created_at,
+			(SELECT COUNT(*) FROM article_tags candidate_tags
+				JOIN article_tags source_tags ON source_tags.tag_id = candidate_tags.tag_id
+				WHERE candidate_tags.article_id = articles.id AND source_tags.article_id = ?) AS shared_tags`, articleId).
+		Where("articles.id <> ? AND articles.status = ?", articleId, entities.ArticleStatusPublished).
+		Order("shared_tags DESC, COALESCE(articles.published_at, articles.created_at) DESC").
+		Limit(limit).
+		Scan(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (ar *articleRepository) GetArticleProducts(ctx context.Context, articleId uuid.UUID) ([]entities.Products, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return findArticleProducts(ar.DB.WithContext(ctx), articleId)
+}
+
+// findArticleProducts returns the products featured in an article in their
+// display order, skipping deleted products
+func findArticleProducts(db *gorm.DB, articleId uuid.UUID) ([]entities.Products, error) {
+	var products []entities.Products
+	err := db.
+		Joins("JOIN article_products ON article_products.product_id = products.id").
+		Where("article_products.article_id = ?", articleId).
+		Preload("ProductPricing").
+		Preload("ProductImages").
+		Order("article_products.position ASC").
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// commentSortOrder returns the order of a comment sort option, newest first by default
+func commentSortOrder(sortBy string) string {
+	switch sortBy {
+	case "top":
+		return "likes_count DESC, created_at DESC"
+	case "oldest":
+		return "created_at ASC"
+	default:
+		return "created_at DESC"
+	}
+}
+
+// GetRepliesByCommentID returns up to limit visible replies of a comment in the
+// order they were written, starting after the cursor when one is given
+func (ar *articleRepository) GetRepliesByCommentID(ctx context.Context, commentId uuid.UUID, cursor *entities.CommentCursor, limit int) ([]entities.ArticleCommentReplies, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	db := ar.DB.WithContext(ctx).Where("comment_id = ? AND hidden = ?", commentId, false)
+	if cursor != nil {
+		db = db.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	var replies []entities.ArticleCommentReplies
+	if err := db.Order("created_at ASC, id ASC").Limit(limit).Find(&replies).Error; err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
+
+// LikeComment likes a comment or reply. Liking twice has no effect.
+func (ar *articleRepository) LikeComment(ctx context.Context, like *entities.ArticleCommentLikes) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(like)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(commentTargetModel(like.TargetType)).Where("id = ?", like.TargetID).
+			UpdateColumn("likes_count", gorm.Expr("likes_count + 1")).Error
+	})
+}
+
+// UnlikeComment removes a like from a comment or reply. Removing a missing like has no effect.
+func (ar *articleRepository) UnlikeComment(ctx context.Context, targetType string, targetId uuid.UUID, userId uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("target_type = ? AND target_id = ? AND user_id = ?", targetType, targetId, userId).
+			Delete(&entities.ArticleCommentLikes{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(commentTargetModel(targetType)).Where("id = ? AND likes_count > 0", targetId).
+			UpdateColumn("likes_count", gorm.Expr("likes_count - 1")).Error
+	})
+}
+
+// GetLikedCommentIDs returns which of the given comments or replies the user liked
+func (ar *articleRepository) GetLikedCommentIDs(ctx context.Context, userId uuid.UUID, targetType string, targetIds []uuid.UUID) ([]uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var liked []uuid.UUID
+	if len(targetIds) == 0 {
+		return liked, nil
+	}
+
+	err := ar.DB.WithContext(ctx).Model(&entities.ArticleCommentLikes{}).
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", userId, targetType, targetIds).
+		Pluck("target_id", &liked).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return liked, nil
+}
+
+func commentTargetModel(targetType string) interface{} {
+	if targetType == entities.CommentTargetReply {
+		return &entities.ArticleCommentReplies{}
+	}
+	return &entities.ArticleComments{}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/entities"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -11,12 +12,26 @@ import (
 )
 
 type ArticleAdminRepository interface {
-	GetArticlesAdmin(ctx context.Context, req *dto_base.PaginationRequest) ([]entities.Articles, int64, error)
+	GetArticlesAdmin(ctx context.Context, req *dto_base.PaginationRequest, status string) ([]entities.Articles, int64, error)
 	GetArticleByIDAdmin(ctx context.Context, articleID uuid.UUID) (*entities.Articles, error)
 	CreateArticleAdmin(ctx context.Context, article *entities.Articles) error
+	CreateArticleWithRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, revision *entities.ArticleRevisions) error
 	SearchArticleAdmin(ctx context.Context, req *dto_base.SearchRequest) ([]entities.Articles, int64, error)
 	UpdateArticleAdmin(ctx context.Context, articleID uuid.UUID, article *entities.Articles) error
+	UpdateArticleWithRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, previousSlug string, revision *entities.ArticleRevisions) error
 	DeleteArticleAdmin(ctx context.Context, articleID uuid.UUID) error
+	UpdateArticleStatus(ctx context.Context, article *entities.Articles) error
+	PublishScheduledArticles(ctx context.Context, now time.Time) (int64, error)
+	CreateArticleRevision(ctx context.Context, revision *entities.ArticleRevisions) error
+	CountArticleRevisions(ctx context.Context, articleID uuid.UUID) (int64, error)
+	GetArticleRevisions(ctx context.Context, articleID uuid.UUID) ([]entities.ArticleRevisions, error)
+	GetArticleRevisionByID(ctx context.Context, articleID uuid.UUID, revisionID uuid.UUID) (*entities.ArticleRevisions, error)
+	RestoreArticleRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, previousSlug string, revision *entities.ArticleRevisions) error
+	GetArticlesWithoutSummary(ctx context.Context) ([]entities.Articles, error)
+	UpdateArticleSummary(ctx context.Context, article *entities.Articles) error
+	GetArticleProducts(ctx context.Context, articleID uuid.UUID) ([]entities.Products, error)
+	CountProductsByIDs(ctx context.Context, productIDs []uuid.UUID) (int64, error)
+	ReplaceArticleProducts(ctx context.Context, articleID uuid.UUID, productIDs []uuid.UUID) error
 }
 
 type articleAdminRepository struct {
@@ -29,7 +44,7 @@ func NewArticleAdminRepository(db *gorm.DB) *articleAdminRepository {
 	}
 }
 
-func (ar *articleAdminRepository) GetArticlesAdmin(ctx context.Context, req *dto_base.PaginationRequest) ([]entities.Articles, int64, error) {
+func (ar *articleAdminRepository) GetArticlesAdmin(ctx context.Context, req *dto_base.PaginationRequest, status string) ([]entities.Articles, int64, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, 0, err
 	}
@@ -38,12 +53,18 @@ func (ar *articleAdminRepository) GetArticlesAdmin(ctx context.Context, req *dto
 	var totalData int64
 
 	offset := (req.Page - 1) * req.Limit
+	db := ar.DB.WithContext(ctx).Model(&entities.Articles{})
+	// Filter berdasarkan status artikel
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
 	// Menghitung total data
-	if err := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Count(&totalData).Error; err != nil {
+	if err := db.Session(&gorm.Session{}).Count(&totalData).Error; err != nil {
 		return nil, 0, err
 	}
 
-	query := ar.DB.WithContext(ctx).Model(&entities.Articles{}).Order(req.SortBy).Count(&totalData).Limit(req.Limit).Offset(offset).Find(&articles)
+	query := db.Order(req.SortBy).Limit(req.Limit).Offset(offset).Find(&articles)
 
 	if query.Error != nil {
 		return nil, 0, query.Error
@@ -110,9 +131,279 @@ func (ar *articleAdminRepository) UpdateArticleAdmin(ctx context.Context, articl
 	return ar.DB.Model(&entities.Articles{}).Where("id = ?", articleID).Updates(&article).Error
 }
 
+// CreateArticleWithRevision stores a new article together with its tags, its
+// slug and its first revision in a single transaction.
+func (ar *articleAdminRepository) CreateArticleWithRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, revision *entities.ArticleRevisions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+
+		if err := replaceArticleTags(tx, article.ID, tagIDs); err != nil {
+			return err
+		}
+
+		if err := saveSlug(tx, entities.SlugTypeArticle, article.ID, slug, ""); err != nil {
+			return err
+		}
+
+		return createArticleRevision(tx, revision)
+	})
+}
+
+// UpdateArticleWithRevision saves an edited article together with its tags, its
+// slug when it changed and the revision recording the edit in a single transaction.
+func (ar *articleAdminRepository) UpdateArticleWithRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, previousSlug string, revision *entities.ArticleRevisions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.Articles{}).Where("id = ?", article.ID).Updates(article).Error; err != nil {
+			return err
+		}
+
+		if err := replaceArticleTags(tx, article.ID, tagIDs); err != nil {
+			return err
+		}
+
+		if slug != previousSlug {
+			if err := saveSlug(tx, entities.SlugTypeArticle, article.ID, slug, previousSlug); err != nil {
+				return err
+			}
+		}
+
+		return createArticleRevision(tx, revision)
+	})
+}
+
 func (ar *articleAdminRepository) DeleteArticleAdmin(ctx context.Context, articleID uuid.UUID) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 	return ar.DB.Model(&entities.Articles{}).Where("id = ?", articleID).Delete(&entities.Articles{}).Error
 }
+
+func (ar *articleAdminRepository) UpdateArticleStatus(ctx context.Context, article *entities.Articles) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("id = ?", article.ID).Updates(map[string]interface{}{
+		"status":       article.Status,
+		"publish_at":   article.PublishAt,
+		"published_at": article.PublishedAt,
+		"updated_at":   time.Now(),
+	}).Error
+}
+
+// PublishScheduledArticles publishes every scheduled article whose publish time has passed.
+func (ar *articleAdminRepository) PublishScheduledArticles(ctx context.Context, now time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	result := ar.DB.WithContext(ctx).Model(&entities.Articles{}).
+		Where("status = ? AND publish_at <= ?", entities.ArticleStatusScheduled, now).
+		Updates(map[string]interface{}{
+			"status":       entities.ArticleStatusPublished,
+			"published_at": gorm.Expr("publish_at"),
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (ar *articleAdminRepository) CreateArticleRevision(ctx context.Context, revision *entities.ArticleRevisions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createArticleRevision(tx, revision)
+	})
+}
+
+func (ar *articleAdminRepository) CountArticleRevisions(ctx context.Context, articleID uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	if err := ar.DB.WithContext(ctx).Model(&entities.ArticleRevisions{}).Where("article_id = ?", articleID).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (ar *articleAdminRepository) GetArticleRevisions(ctx context.Context, articleID uuid.UUID) ([]entities.ArticleRevisions, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var revisions []entities.ArticleRevisions
+	if err := ar.DB.WithContext(ctx).Preload("Editor").Where("article_id = ?", articleID).Order("version DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (ar *articleAdminRepository) GetArticleRevisionByID(ctx context.Context, articleID uuid.UUID, revisionID uuid.UUID) (*entities.ArticleRevisions, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var revision entities.ArticleRevisions
+	if err := ar.DB.WithContext(ctx).Preload("Editor").Where("id = ? AND article_id = ?", revisionID, articleID).First(&revision).Error; err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// RestoreArticleRevision copies the revision back onto the article, together with
+// its tags and slug, and records the result as a new revision in a single transaction.
+func (ar *articleAdminRepository) RestoreArticleRevision(ctx context.Context, article *entities.Articles, tagIDs []uuid.UUID, slug string, previousSlug string, revision *entities.ArticleRevisions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.Articles{}).Where("id = ?", article.ID).Updates(map[string]interface{}{
+			"title":        article.Title,
+			"image":        article.Image,
+			"content":      article.Content,
+			"excerpt":      article.Excerpt,
+			"reading_time": article.ReadingTime,
+			"tags":         article.Tags,
+			"updated_at":   article.UpdatedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := replaceArticleTags(tx, article.ID, tagIDs); err != nil {
+			return err
+		}
+
+		if slug != previousSlug {
+			if err := saveSlug(tx, entities.SlugTypeArticle, article.ID, slug, previousSlug); err != nil {
+				return err
+			}
+		}
+
+		return createArticleRevision(tx, revision)
+	})
+}
+
+// createArticleRevision stores the revision as the next version of its article.
+// The article row stays locked until the transaction ends, so concurrent saves
+// of the same article number their revisions one after another.
+func createArticleRevision(tx *gorm.DB, revision *entities.ArticleRevisions) error {
+	var article entities.Articles
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", revision.ArticleID).First(&article).Error
+	if err != nil {
+		return err
+	}
+
+	var latest int
+	err = tx.Model(&entities.ArticleRevisions{}).
+		Where("article_id = ?", revision.ArticleID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return err
+	}
+
+	revision.Version = latest + 1
+	return tx.Create(revision).Error
+}
+
+// GetArticlesWithoutSummary returns articles written before excerpts and reading
+// times were stored.
+func (ar *articleAdminRepository) GetArticlesWithoutSummary(ctx context.Context) ([]entities.Articles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var articles []entities.Articles
+	if err := ar.DB.WithContext(ctx).Where("reading_time = 0").Find(&articles).Error; err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (ar *articleAdminRepository) UpdateArticleSummary(ctx context.Context, article *entities.Articles) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Model(&entities.Articles{}).Where("id = ?", article.ID).UpdateColumns(map[string]interface{}{
+		"excerpt":      article.Excerpt,
+		"reading_time": article.ReadingTime,
+	}).Error
+}
+
+func (ar *articleAdminRepository) GetArticleProducts(ctx context.Context, articleID uuid.UUID) ([]entities.Products, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return findArticleProducts(ar.DB.WithContext(ctx), articleID)
+}
+
+func (ar *articleAdminRepository) CountProductsByIDs(ctx context.Context, productIDs []uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if len(productIDs) == 0 {
+		return count, nil
+	}
+
+	if err := ar.DB.WithContext(ctx).Model(&entities.Products{}).Where("id IN ?", productIDs).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ReplaceArticleProducts sets the products featured in an article, keeping
+// the order of productIDs
+func (ar *articleAdminRepository) ReplaceArticleProducts(ctx context.Context, articleID uuid.UUID, productIDs []uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&entities.ArticleProducts{}).Error; err != nil {
+			return err
+		}
+
+		if len(productIDs) == 0 {
+			return nil
+		}
+
+		links := make([]entities.ArticleProducts, len(productIDs))
+		for i, productID := range productIDs {
+			links[i] = entities.ArticleProducts{
+				ArticleID: articleID,
+				ProductID: productID,
+				Position:  i,
+			}
+		}
+
+		return tx.Create(&links).Error
+	})
+}
@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuthRepository interface {
+	CreateSession(ctx context.Context, session *entities.UserSessions, token *entities.RefreshTokens) error
+	CountSessions(ctx context.Context, userID uuid.UUID, userAgent string) (int64, int64, error)
+	GetActiveSessions(ctx context.Context, userID uuid.UUID, seenSince time.Time) ([]entities.UserSessions, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entities.RefreshTokens, error)
+	GetAdminByID(ctx context.Context, adminID uuid.UUID) (*entities.Admin, error)
+	UserExists(ctx context.Context, userID uuid.UUID) (bool, error)
+	RotateRefreshToken(ctx context.Context, usedID uuid.UUID, token *entities.RefreshTokens, ipAddress string) (bool, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	DeleteExpiredRefreshTokens(ctx context.Context) (int64, error)
+	CreateSecurityEvent(ctx context.Context, event *entities.SecurityEvents) error
+}
+
+type authRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuthRepository(db *gorm.DB) *authRepository {
+	return &authRepository{
+		DB: db,
+	}
+}
+
+// CreateSession stores a new session together with its first refresh token
+func (ar *authRepository) CreateSession(ctx context.Context, session *entities.UserSessions, token *entities.RefreshTokens) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(token).Error
+	})
+}
+
+// CountSessions returns how many sessions a user ever started and how many of
+// them came from the given user agent
+func (ar *authRepository) CountSessions(ctx context.Context, userID uuid.UUID, userAgent string) (int64, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	var counts struct {
+		Total      int64
+		FromDevice int64
+	}
+	err := ar.DB.WithContext(ctx).Model(&entities.UserSessions{}).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE user_agent = ?) AS from_device", userAgent).
+		Where("user_id = ?", userID).
+		Scan(&counts).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return counts.Total, counts.FromDevice, nil
+}
+
+// GetActiveSessions returns the sessions of a user that are not revoked and
+// were used since seenSince, most recently used first
+func (ar *authRepository) GetActiveSessions(ctx context.Context, userID uuid.UUID, seenSince time.Time) ([]entities.UserSessions, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sessions []entities.UserSessions
+	err := ar.DB.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, seenSince).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (ar *authRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entities.RefreshTokens, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var token entities.RefreshTokens
+	if err := ar.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (ar *authRepository) GetAdminByID(ctx context.Context, adminID uuid.UUID) (*entities.Admin, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var admin entities.Admin
+	if err := ar.DB.WithContext(ctx).Where("id = ?", adminID).First(&admin).Error; err != nil {
+		return nil, err
+	}
+
+	return &admin, nil
+}
+
+func (ar *authRepository) UserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ar.DB.WithContext(ctx).Model(&entities.User{}).Where("id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+// RotateRefreshToken marks a refresh token as used, stores its replacement and
+// records the session as last seen from ipAddress. It reports false without
+// storing anything when the token was already used or revoked by a concurrent
+// request.
+func (ar *authRepository) RotateRefreshToken(ctx context.Context, usedID uuid.UUID, token *entities.RefreshTokens, ipAddress string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	rotated := false
+	err := ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RefreshTokens{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", usedID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(token).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&entities.UserSessions{}).Where("id = ?", token.SessionID).UpdateColumns(map[string]interface{}{
+			"last_seen_at": time.Now(),
+			"ip_address":   ipAddress,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rotated, nil
+}
+
+// RevokeSession revokes one session of a user and its refresh tokens. It
+// returns gorm.ErrRecordNotFound when the user has no such active session.
+func (ar *authRepository) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.UserSessions{}).
+			Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&entities.RefreshTokens{}).
+			Where("session_id = ? AND revoked_at IS NULL", sessionID).
+			Update("revoked_at", now).Error
+	})
+}
+
+// RevokeUserSessions revokes every session of a user with their refresh
+// tokens and returns the IDs of the sessions it revoked
+func (ar *authRepository) RevokeUserSessions(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var sessionIDs []uuid.UUID
+	now := time.Now()
+	err := ar.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.UserSessions{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("id", &sessionIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entities.UserSessions{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.RefreshTokens{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionIDs, nil
+}
+
+// DeleteExpiredRefreshTokens removes refresh tokens that can no longer be used
+func (ar *authRepository) DeleteExpiredRefreshTokens(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	result := ar.DB.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&entities.RefreshTokens{})
+	return result.RowsAffected, result.Error
+}
+
+func (ar *authRepository) CreateSecurityEvent(ctx context.Context, event *entities.SecurityEvents) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ar.DB.WithContext(ctx).Create(event).Error
+}
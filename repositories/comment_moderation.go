@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentModerationRepository interface {
+	CreateReport(ctx context.Context, report *entities.ArticleCommentReports) (bool, error)
+	GetModerationQueue(ctx context.Context, hidden bool) ([]entities.CommentModerationItem, error)
+	SetHidden(ctx context.Context, targetType string, targetID uuid.UUID, hidden bool) error
+
+	GetBannedWords(ctx context.Context) ([]entities.BannedWords, error)
+	CreateBannedWord(ctx context.Context, word *entities.BannedWords) (bool, error)
+	DeleteBannedWord(ctx context.Context, id uuid.UUID) error
+}
+
+type commentModerationRepository struct {
+	DB *gorm.DB
+}
+
+func NewCommentModerationRepository(db *gorm.DB) *commentModerationRepository {
+	return &commentModerationRepository{
+		DB: db,
+	}
+}
+
+// CreateReport stores a report and reports false when the user already
+// reported the same comment or reply
+func (cr *commentModerationRepository) CreateReport(ctx context.Context, report *entities.ArticleCommentReports) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	result := cr.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetModerationQueue lists comments and replies with open reports, or the hidden
+// ones when hidden is true, most reported first
+func (cr *commentModerationRepository) GetModerationQueue(ctx context.Context, hidden bool) ([]entities.CommentModerationItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := "reports.report_count > 0 AND posts.hidden = false"
+	if hidden {
+		filter = "posts.hidden = true"
+	}
+
+	var items []entities.CommentModerationItem
+	err := cr.DB.WithContext(ctx).Raw(`
+		SELECT posts.target_type, posts.target_id, posts.article_id, posts.user_id, posts.content, posts.hidden, posts.created_at,
+			COALESCE(reports.report_count, 0) AS report_count, COALESCE(reports.reasons, '') AS reasons, reports.last_reported_at
+		FROM (
+			SELECT 'comment' AS target_type, id AS target_id, article_id, user_id, content, hidden, created_at
+			FROM article_comments WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT 'reply', id, article_id, user_id, content, hidden, created_at
+			FROM article_comment_replies WHERE deleted_at IS NULL
+		) posts
+		LEFT JOIN (
+			SELECT target_type, target_id, COUNT(*) AS report_count,
+				STRING_AGG(reason, CHR(31) ORDER BY created_at) AS reasons, MAX(created_at) AS last_reported_at
+			FROM article_comment_reports WHERE status = ?
+			GROUP BY target_type, target_id
+		) reports ON reports.target_type = posts.target_type AND reports.target_id = posts.target_id
+		WHERE `+filter+`
+		ORDER BY report_count DESC, reports.last_reported_at DESC NULLS LAST, posts.created_at DESC`,
+		entities.CommentReportOpen).Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SetHidden hides or restores a comment or reply, closes its open reports and
+// recounts the comments of its article
+func (cr *commentModerationRepository) SetHidden(ctx context.Context, targetType string, targetID uuid.UUID, hidden bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	model := commentTargetModel(targetType)
+
+	now := time.Now()
+	var hiddenAt *time.Time
+	reportStatus := entities.CommentReportDismissed
+	if hidden {
+		hiddenAt = &now
+		reportStatus = entities.CommentReportResolved
+	}
+
+	return cr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var articleIDs []uuid.UUID
+		if err := tx.Model(model).Where("id = ?", targetID).Pluck("article_id", &articleIDs).Error; err != nil {
+			return err
+		}
+		if len(articleIDs) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Model(model).Where("id = ?", targetID).UpdateColumns(map[string]interface{}{
+			"hidden":    hidden,
+			"hidden_at": hiddenAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entities.ArticleCommentReports{}).
+			Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, entities.CommentReportOpen).
+			Updates(map[string]interface{}{"status": reportStatus, "resolved_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		return refreshCommentsCount(tx, articleIDs[0])
+	})
+}
+
+func (cr *commentModerationRepository) GetBannedWords(ctx context.Context) ([]entities.BannedWords, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var words []entities.BannedWords
+	if err := cr.DB.WithContext(ctx).Order("word ASC").Find(&words).Error; err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// CreateBannedWord stores a banned word and reports false when it already exists
+func (cr *commentModerationRepository) CreateBannedWord(ctx context.Context, word *entities.BannedWords) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	result := cr.DB.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "word"}}, DoNothing: true}).Create(word)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (cr *commentModerationRepository) DeleteBannedWord(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	result := cr.DB.WithContext(ctx).Where("id = ?", id).Delete(&entities.BannedWords{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ type ProductDashboardRepository interface {
 	GetCartItems(ctx context.Context) ([]entities.Cart, error)
 	GetEventItems(ctx context.Context) ([]entities.Events, error)
 	GetArticleItems(ctx context.Context) ([]entities.Articles, error)
+	GetEngagementTotals(ctx context.Context) (*entities.EngagementTotals, error)
 }
 
 func (pr *productDashboardRepository) GetProducts(ctx context.Context, req *dto_base.PaginationRequest) ([]entities.ProductTransaction, int64, error) {
@@ -108,3 +109,26 @@ func (pr *productDashboardRepository) GetArticleItems(ctx context.Context) ([]en
 
 	return articles, nil
 }
+
+// GetEngagementTotals sums the views and shares of all products, articles and events
+func (pr *productDashboardRepository) GetEngagementTotals(ctx context.Context) (*entities.EngagementTotals, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var totals entities.EngagementTotals
+	err := pr.DB.WithContext(ctx).Raw(`
+		SELECT COALESCE(SUM(views_count), 0) AS views, COALESCE(SUM(shares_count), 0) AS shares
+		FROM (
+			SELECT views_count, shares_count FROM products WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT views_count, shares_count FROM articles WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT views_count, shares_count FROM events WHERE deleted_at IS NULL
+		) counters`).Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
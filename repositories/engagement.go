@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EngagementRepository interface {
+	ContentExists(ctx context.Context, objectType string, objectID uuid.UUID) (bool, error)
+	CreateShare(ctx context.Context, share *entities.ContentShares) error
+	AddViews(ctx context.Context, objectType string, views map[uuid.UUID]int) error
+	GetShareChannels(ctx context.Context, objectType string, objectID uuid.UUID) ([]entities.ShareChannelCount, error)
+	GetArticleCounters(ctx context.Context, articleID uuid.UUID) (*entities.Articles, error)
+}
+
+// engagementTables maps the tracked object types to their tables
+var engagementTables = map[string]string{
+	entities.SlugTypeProduct: "products",
+	entities.SlugTypeArticle: "articles",
+	entities.SlugTypeEvent:   "events",
+}
+
+type engagementRepository struct {
+	DB *gorm.DB
+}
+
+func NewEngagementRepository(db *gorm.DB) *engagementRepository {
+	return &engagementRepository{
+		DB: db,
+	}
+}
+
+func getEngagementTable(objectType string) (string, error) {
+	table, ok := engagementTables[objectType]
+	if !ok {
+		return "", fmt.Errorf("unknown content type %q", objectType)
+	}
+	return table, nil
+}
+
+func (er *engagementRepository) ContentExists(ctx context.Context, objectType string, objectID uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	table, err := getEngagementTable(objectType)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	err = er.DB.WithContext(ctx).Table(table).Where("id = ? AND deleted_at IS NULL", objectID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CreateShare stores a share and increments the shares count of the shared content
+func (er *engagementRepository) CreateShare(ctx context.Context, share *entities.ContentShares) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	table, err := getEngagementTable(share.ObjectType)
+	if err != nil {
+		return err
+	}
+
+	return er.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(share).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(table).Where("id = ?", share.ObjectID).
+			UpdateColumn("shares_count", gorm.Expr("shares_count + 1")).Error
+	})
+}
+
+// AddViews adds the buffered view counts to the views count of each object
+func (er *engagementRepository) AddViews(ctx context.Context, objectType string, views map[uuid.UUID]int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	table, err := getEngagementTable(objectType)
+	if err != nil {
+		return err
+	}
+
+	return er.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for objectID, count := range views {
+			err := tx.Table(table).Where("id = ?", objectID).
+				UpdateColumn("views_count", gorm.Expr("views_count + ?", count)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (er *engagementRepository) GetShareChannels(ctx context.Context, objectType string, objectID uuid.UUID) ([]entities.ShareChannelCount, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var channels []entities.ShareChannelCount
+	err := er.DB.WithContext(ctx).Model(&entities.ContentShares{}).
+		Select("channel, COUNT(*) AS count").
+		Where("object_type = ? AND object_id = ?", objectType, objectID).
+		Group("channel").
+		Order("count DESC, channel ASC").
+		Scan(&channels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// GetArticleCounters returns an article with only its ID and engagement counters loaded
+func (er *engagementRepository) GetArticleCounters(ctx context.Context, articleID uuid.UUID) (*entities.Articles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var article entities.Articles
+	err := er.DB.WithContext(ctx).
+		Select("id", "views_count", "shares_count", "likes_count", "comments_count").
+		Where("id = ?", articleID).
+		First(&article).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &article, nil
+}
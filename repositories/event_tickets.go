@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EventTicketRepository interface {
+	CreateTickets(ctx context.Context, tickets []entities.EventTicket) error
+	CountTicketsByTransactionID(ctx context.Context, transactionId uuid.UUID) (int64, error)
+	CountTransfersByTransactionID(ctx context.Context, transactionId uuid.UUID) (int64, error)
+	DeleteTicketsByTransactionID(ctx context.Context, transactionId uuid.UUID) error
+	GetTicketsByHolder(ctx context.Context, userId uuid.UUID, email string) ([]entities.EventTicket, error)
+	GetTicketByID(ctx context.Context, ticketId uuid.UUID) (*entities.EventTicket, error)
+	GetTicketByCode(ctx context.Context, code string) (*entities.EventTicket, error)
+	TransferTicket(ctx context.Context, ticket *entities.EventTicket, previousHolderID *uuid.UUID, transfer *entities.EventTicketTransfer) error
+	GetTransfersByEventID(ctx context.Context, eventId uuid.UUID) ([]entities.EventTicketTransfer, error)
+	UpdateTicketTransferSetting(ctx context.Context, eventId uuid.UUID, allowed bool) error
+}
+
+type eventTicketRepository struct {
+	DB *gorm.DB
+}
+
+func NewEventTicketRepository(db *gorm.DB) *eventTicketRepository {
+	return &eventTicketRepository{
+		DB: db,
+	}
+}
+
+func (tr *eventTicketRepository) CreateTickets(ctx context.Context, tickets []entities.EventTicket) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Create(&tickets).Error
+}
+
+func (tr *eventTicketRepository) CountTicketsByTransactionID(ctx context.Context, transactionId uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err := tr.DB.WithContext(ctx).Model(&entities.EventTicket{}).Where("event_transaction_id = ?", transactionId).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (tr *eventTicketRepository) CountTransfersByTransactionID(ctx context.Context, transactionId uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err := tr.DB.WithContext(ctx).Model(&entities.EventTicketTransfer{}).
+		Joins("JOIN event_tickets ON event_tickets.id = event_ticket_transfers.event_ticket_id").
+		Where("event_tickets.event_transaction_id = ?", transactionId).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (tr *eventTicketRepository) DeleteTicketsByTransactionID(ctx context.Context, transactionId uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Where("event_transaction_id = ?", transactionId).Delete(&entities.EventTicket{}).Error
+}
+
+// GetTicketsByHolder returns the tickets held by the user, including tickets
+// sent to their email before they had an account.
+func (tr *eventTicketRepository) GetTicketsByHolder(ctx context.Context, userId uuid.UUID, email string) ([]entities.EventTicket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tickets []entities.EventTicket
+	err := tr.DB.WithContext(ctx).
+		Preload("Event").
+		Where("holder_user_id = ? OR (holder_user_id IS NULL AND LOWER(email) = LOWER(?))", userId, email).
+		Order("created_at DESC").
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+func (tr *eventTicketRepository) GetTicketByID(ctx context.Context, ticketId uuid.UUID) (*entities.EventTicket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var ticket entities.EventTicket
+	err := tr.DB.WithContext(ctx).Preload("Event").Where("id = ?", ticketId).First(&ticket).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (tr *eventTicketRepository) GetTicketByCode(ctx context.Context, code string) (*entities.EventTicket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var ticket entities.EventTicket
+	err := tr.DB.WithContext(ctx).Preload("Event").Where("code = ?", code).First(&ticket).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// TransferTicket moves the ticket to its new holder and records the transfer
+// in a single transaction, so the audit trail never misses a holder change.
+// The ticket must still have the code and holder the transfer was based on,
+// otherwise gorm.ErrRecordNotFound is returned.
+func (tr *eventTicketRepository) TransferTicket(ctx context.Context, ticket *entities.EventTicket, previousHolderID *uuid.UUID, transfer *entities.EventTicketTransfer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&entities.EventTicket{}).Where("id = ? AND code = ?", ticket.ID, transfer.RevokedCode)
+		if previousHolderID != nil {
+			query = query.Where("holder_user_id = ?", *previousHolderID)
+		} else {
+			query = query.Where("holder_user_id IS NULL")
+		}
+
+		result := query.Updates(map[string]interface{}{
+			"holder_user_id":  ticket.HolderUserID,
+			"code":            ticket.Code,
+			"identity_number": ticket.IdentityNumber,
+			"full_name":       ticket.FullName,
+			"email":           ticket.Email,
+			"phone":           ticket.Phone,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(transfer).Error
+	})
+}
+
+func (tr *eventTicketRepository) GetTransfersByEventID(ctx context.Context, eventId uuid.UUID) ([]entities.EventTicketTransfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var transfers []entities.EventTicketTransfer
+	err := tr.DB.WithContext(ctx).Where("event_id = ?", eventId).Order("created_at DESC").Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (tr *eventTicketRepository) UpdateTicketTransferSetting(ctx context.Context, eventId uuid.UUID, allowed bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	result := tr.DB.WithContext(ctx).Model(&entities.Events{}).Where("id = ?", eventId).Update("allow_ticket_transfer", allowed)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
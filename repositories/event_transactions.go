@@ -2,16 +2,22 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"kreasi-nusantara-api/entities"
 	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type EventTransactionRepository interface {
-	CreateTransaction(ctx context.Context, transaction *entities.EventTransaction) error
+	BookTransaction(ctx context.Context, transaction *entities.EventTransaction, reservationID *uuid.UUID, rsvpEventID *uuid.UUID) (entities.BookingResult, error)
+	UpdateSnapURL(ctx context.Context, transactionId uuid.UUID, snapURL string) error
 	GetTransactionByID(ctx context.Context, transactionId string) (*entities.EventTransaction, error)
+	CountSoldTickets(ctx context.Context, priceId uuid.UUID) (int64, error)
+	UpdateTransactionStatus(ctx context.Context, transactionId uuid.UUID, status string) error
 }
 
 type eventTransactionRepository struct {
@@ -24,12 +30,100 @@ func NewEventTransactionRepository(db *gorm.DB) *eventTransactionRepository {
 	}
 }
 
-func (er *eventTransactionRepository) CreateTransaction(ctx context.Context, transaction *entities.EventTransaction) error {
-	if err := er.DB.WithContext(ctx).Create(transaction).Error; err != nil {
-		log.Printf("Error while creating transaction in database: %v", err)
+// errBookingRejected rolls back a booking that cannot be stored
+var errBookingRejected = errors.New("booking rejected")
+
+// BookTransaction stores the transaction only while its ticket tier has enough
+// free seats, counting sold seats and seats reserved for the waitlist. The tier
+// stays locked until the transaction is stored, so concurrent checkouts cannot
+// sell the same seats. A waitlist reservation is converted in the same
+// transaction and must still be open. For RSVP-only events, rsvpEventID is
+// locked as well and the user must not hold another RSVP for it.
+func (er *eventTransactionRepository) BookTransaction(ctx context.Context, transaction *entities.EventTransaction, reservationID *uuid.UUID, rsvpEventID *uuid.UUID) (entities.BookingResult, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	result := entities.BookingCreated
+	err := er.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if rsvpEventID != nil {
+			var event entities.Events
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", *rsvpEventID).First(&event).Error
+			if err != nil {
+				return err
+			}
+
+			rsvps, err := countActiveRSVPs(tx, transaction.UserId, event.ID)
+			if err != nil {
+				return err
+			}
+			if rsvps > 0 {
+				result = entities.BookingAlreadyRSVPed
+				return errBookingRejected
+			}
+		}
+
+		var price entities.EventPrices
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", transaction.EventPriceID).First(&price).Error
+		if err != nil {
+			return err
+		}
+
+		// A converted reservation no longer counts as reserved below
+		if reservationID != nil {
+			converted := tx.Model(&entities.EventWaitlist{}).
+				Where("id = ? AND status = ? AND reserved_until > ?", *reservationID, entities.WaitlistStatusNotified, time.Now()).
+				Updates(map[string]interface{}{
+					"status":     entities.WaitlistStatusConverted,
+					"updated_at": time.Now(),
+				})
+			if converted.Error != nil {
+				return converted.Error
+			}
+			if converted.RowsAffected == 0 {
+				result = entities.BookingReservationInvalid
+				return errBookingRejected
+			}
+		}
+
+		sold, err := countSoldTickets(tx, price.ID)
+		if err != nil {
+			return err
+		}
+		reserved, err := countReservedTickets(tx, price.ID, nil)
+		if err != nil {
+			return err
+		}
+		if int64(price.NoOfTicket)-sold-reserved < int64(transaction.Quantity) {
+			result = entities.BookingSoldOut
+			return errBookingRejected
+		}
+
+		if err := tx.Create(transaction).Error; err != nil {
+			log.Printf("Error while creating transaction in database: %v", err)
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, errBookingRejected) {
+		return result, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// UpdateSnapURL attaches the payment page of a transaction once it was charged
+func (er *eventTransactionRepository) UpdateSnapURL(ctx context.Context, transactionId uuid.UUID, snapURL string) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
-	return nil
+
+	return er.DB.WithContext(ctx).Model(&entities.EventTransaction{}).
+		Where("id = ?", transactionId).
+		Update("snap_url", snapURL).Error
 }
 
 func (er *eventTransactionRepository) GetTransactionByID(ctx context.Context, transactionId string) (*entities.EventTransaction, error) {
@@ -45,3 +139,43 @@ func (er *eventTransactionRepository) GetTransactionByID(ctx context.Context, tr
 
 	return &transaction, nil
 }
+
+// CountSoldTickets sums the quantity of every transaction that still holds a seat
+// of the given ticket tier, i.e. paid or awaiting payment.
+func (er *eventTransactionRepository) CountSoldTickets(ctx context.Context, priceId uuid.UUID) (int64, error) {
+	return countSoldTickets(er.DB.WithContext(ctx), priceId)
+}
+
+func countSoldTickets(db *gorm.DB, priceId uuid.UUID) (int64, error) {
+	var total int64
+	err := db.Model(&entities.EventTransaction{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("event_price_id = ? AND transaction_status IN ?", priceId, []string{"pending", "paid", "challenge"}).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// countActiveRSVPs counts the user's confirmed free bookings for the event.
+func countActiveRSVPs(db *gorm.DB, userId uuid.UUID, eventId uuid.UUID) (int64, error) {
+	var count int64
+	err := db.Model(&entities.EventTransaction{}).
+		Joins("JOIN event_prices ON event_prices.id = event_transactions.event_price_id").
+		Where("event_transactions.user_id = ? AND event_prices.event_id = ?", userId, eventId).
+		Where("event_transactions.total_amount = 0 AND event_transactions.transaction_status = ?", "paid").
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (er *eventTransactionRepository) UpdateTransactionStatus(ctx context.Context, transactionId uuid.UUID, status string) error {
+	return er.DB.WithContext(ctx).Model(&entities.EventTransaction{}).
+		Where("id = ?", transactionId).
+		Update("transaction_status", status).Error
+}
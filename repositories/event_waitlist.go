@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EventWaitlistRepository interface {
+	CreateWaitlist(ctx context.Context, waitlist *entities.EventWaitlist) error
+	UpdateWaitlist(ctx context.Context, waitlist *entities.EventWaitlist) error
+	GetActiveWaitlist(ctx context.Context, priceId uuid.UUID, userId uuid.UUID) (*entities.EventWaitlist, error)
+	GetWaitlistsByUserID(ctx context.Context, userId uuid.UUID) ([]entities.EventWaitlist, error)
+	GetWaitingList(ctx context.Context, priceId uuid.UUID) ([]entities.EventWaitlist, error)
+	GetReservationByToken(ctx context.Context, token string) (*entities.EventWaitlist, error)
+	CountWaitingAhead(ctx context.Context, waitlist *entities.EventWaitlist) (int64, error)
+	CountReservedTickets(ctx context.Context, priceId uuid.UUID, excludeId *uuid.UUID) (int64, error)
+	ExpireReservations(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+}
+
+type eventWaitlistRepository struct {
+	DB *gorm.DB
+}
+
+func NewEventWaitlistRepository(db *gorm.DB) *eventWaitlistRepository {
+	return &eventWaitlistRepository{
+		DB: db,
+	}
+}
+
+func (wr *eventWaitlistRepository) CreateWaitlist(ctx context.Context, waitlist *entities.EventWaitlist) error {
+	return wr.DB.WithContext(ctx).Create(waitlist).Error
+}
+
+func (wr *eventWaitlistRepository) UpdateWaitlist(ctx context.Context, waitlist *entities.EventWaitlist) error {
+	return wr.DB.WithContext(ctx).Model(&entities.EventWaitlist{}).Where("id = ?", waitlist.ID).Updates(map[string]interface{}{
+		"status":            waitlist.Status,
+		"reservation_token": waitlist.ReservationToken,
+		"reserved_until":    waitlist.ReservedUntil,
+		"updated_at":        time.Now(),
+	}).Error
+}
+
+func (wr *eventWaitlistRepository) GetActiveWaitlist(ctx context.Context, priceId uuid.UUID, userId uuid.UUID) (*entities.EventWaitlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var waitlist entities.EventWaitlist
+	err := wr.DB.WithContext(ctx).
+		Where("event_price_id = ? AND user_id = ?", priceId, userId).
+		Where("status IN ?", []string{entities.WaitlistStatusWaiting, entities.WaitlistStatusNotified}).
+		First(&waitlist).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &waitlist, nil
+}
+
+func (wr *eventWaitlistRepository) GetWaitlistsByUserID(ctx context.Context, userId uuid.UUID) ([]entities.EventWaitlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var waitlists []entities.EventWaitlist
+	err := wr.DB.WithContext(ctx).
+		Preload("EventPrice.TicketType").
+		Where("user_id = ?", userId).
+		Order("created_at desc").
+		Find(&waitlists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return waitlists, nil
+}
+
+func (wr *eventWaitlistRepository) GetWaitingList(ctx context.Context, priceId uuid.UUID) ([]entities.EventWaitlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var waitlists []entities.EventWaitlist
+	err := wr.DB.WithContext(ctx).
+		Preload("User").
+		Where("event_price_id = ? AND status = ?", priceId, entities.WaitlistStatusWaiting).
+		Order("created_at asc").
+		Find(&waitlists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return waitlists, nil
+}
+
+func (wr *eventWaitlistRepository) GetReservationByToken(ctx context.Context, token string) (*entities.EventWaitlist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var waitlist entities.EventWaitlist
+	if err := wr.DB.WithContext(ctx).Where("reservation_token = ?", token).First(&waitlist).Error; err != nil {
+		return nil, err
+	}
+
+	return &waitlist, nil
+}
+
+func (wr *eventWaitlistRepository) CountWaitingAhead(ctx context.Context, waitlist *entities.EventWaitlist) (int64, error) {
+	var count int64
+	err := wr.DB.WithContext(ctx).Model(&entities.EventWaitlist{}).
+		Where("event_price_id = ? AND status = ? AND created_at < ?", waitlist.EventPriceID, entities.WaitlistStatusWaiting, waitlist.CreatedAt).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (wr *eventWaitlistRepository) CountReservedTickets(ctx context.Context, priceId uuid.UUID, excludeId *uuid.UUID) (int64, error) {
+	return countReservedTickets(wr.DB.WithContext(ctx), priceId, excludeId)
+}
+
+func countReservedTickets(db *gorm.DB, priceId uuid.UUID, excludeId *uuid.UUID) (int64, error) {
+	var total int64
+	query := db.Model(&entities.EventWaitlist{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("event_price_id = ? AND status = ? AND reserved_until > ?", priceId, entities.WaitlistStatusNotified, time.Now())
+	if excludeId != nil {
+		query = query.Where("id <> ?", *excludeId)
+	}
+
+	if err := query.Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ExpireReservations marks every reservation that passed its deadline as expired
+// and returns the ticket tiers whose seats were released.
+func (wr *eventWaitlistRepository) ExpireReservations(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	var expired []entities.EventWaitlist
+
+	err := wr.DB.WithContext(ctx).
+		Where("status = ? AND reserved_until <= ?", entities.WaitlistStatusNotified, now).
+		Find(&expired).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(expired))
+	seen := make(map[uuid.UUID]bool)
+	var priceIds []uuid.UUID
+	for i, waitlist := range expired {
+		ids[i] = waitlist.ID
+		if !seen[waitlist.EventPriceID] {
+			seen[waitlist.EventPriceID] = true
+			priceIds = append(priceIds, waitlist.EventPriceID)
+		}
+	}
+
+	err = wr.DB.WithContext(ctx).Model(&entities.EventWaitlist{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     entities.WaitlistStatusExpired,
+			"updated_at": now,
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return priceIds, nil
+}
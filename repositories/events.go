@@ -20,8 +20,23 @@ type EventRepository interface {
 
 	GetEventsByMonthYear(ctx context.Context, year int, month int) ([]entities.Events, error)
 	GetEventsByDate(ctx context.Context, date time.Time) ([]entities.Events, error)
+
+	GetNearbyEvents(ctx context.Context, latitude, longitude, radius float64, limit int) ([]entities.EventDistance, error)
+	GetEventsByIDs(ctx context.Context, eventIds []uuid.UUID) ([]entities.Events, error)
+
+	// Reviews
+	CreateEventReview(ctx context.Context, review *entities.EventReviews) error
+	GetEventReviews(ctx context.Context, eventId uuid.UUID, req *dto_base.PaginationRequest) ([]entities.EventReviews, int64, error)
+	GetLatestEventReviews(ctx context.Context, eventId uuid.UUID) ([]entities.EventReviews, error)
+	GetEventRatingSummaries(ctx context.Context, eventIds []uuid.UUID) ([]entities.EventRatingSummary, error)
+	GetEventCategoryRatingSummaries(ctx context.Context) ([]entities.EventCategoryRatingSummary, error)
+	HasAttendedEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) (bool, error)
+	HasReviewedEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) (bool, error)
 }
 
+// attendedTransactionStatuses are the event transaction states that grant attendance.
+var attendedTransactionStatuses = []string{"paid"}
+
 type eventRepository struct {
 	DB *gorm.DB
 }
@@ -160,3 +175,198 @@ func (er *eventRepository) GetEventsByDate(ctx context.Context, date time.Time)
 	}
 	return events, nil
 }
+
+// haversineDistance computes the great-circle distance in kilometres between
+// the given point (lat, lng, lat) and the venue of an event.
+const haversineDistance = `6371 * acos(LEAST(1, GREATEST(-1,
+	cos(radians(?)) * cos(radians(event_locations.latitude)) * cos(radians(event_locations.longitude) - radians(?)) +
+	sin(radians(?)) * sin(radians(event_locations.latitude)))))`
+
+func (er *eventRepository) GetNearbyEvents(ctx context.Context, latitude, longitude, radius float64, limit int) ([]entities.EventDistance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var distances []entities.EventDistance
+
+	err := er.DB.WithContext(ctx).Model(&entities.Events{}).
+		Select("events.id AS event_id, "+haversineDistance+" AS distance", latitude, longitude, latitude).
+		Joins("JOIN event_locations ON event_locations.id = events.location_id").
+		Where("event_locations.latitude IS NOT NULL AND event_locations.longitude IS NOT NULL").
+		Where("events.date >= ?", time.Now().Truncate(24*time.Hour)).
+		Where(haversineDistance+" <= ?", latitude, longitude, latitude, radius).
+		Order("distance asc").
+		Limit(limit).
+		Scan(&distances).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return distances, nil
+}
+
+func (er *eventRepository) GetEventsByIDs(ctx context.Context, eventIds []uuid.UUID) ([]entities.Events, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var events []entities.Events
+	if err := er.DB.WithContext(ctx).Preload(clause.Associations).Where("id IN ?", eventIds).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Reviews
+func (er *eventRepository) CreateEventReview(ctx context.Context, review *entities.EventReviews) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return er.DB.WithContext(ctx).Create(review).Error
+}
+
+func (er *eventRepository) GetEventReviews(ctx context.Context, eventId uuid.UUID, req *dto_base.PaginationRequest) ([]entities.EventReviews, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []entities.EventReviews
+	var totalData int64
+
+	offset := (req.Page - 1) * req.Limit
+	query := er.DB.WithContext(ctx).Model(&entities.EventReviews{}).Preload("User").Where("event_id = ?", eventId).Order(reviewSortOrder(req.SortBy)).Count(&totalData).Limit(req.Limit).Offset(offset)
+
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, totalData, nil
+}
+
+// reviewSortOrder returns the order of a review sort option, newest first by default
+func reviewSortOrder(sortBy string) string {
+	switch sortBy {
+	case "oldest":
+		return "created_at ASC"
+	case "highest":
+		return "rating DESC, created_at DESC"
+	case "lowest":
+		return "rating ASC, created_at DESC"
+	default:
+		return "created_at DESC"
+	}
+}
+
+func (er *eventRepository) GetLatestEventReviews(ctx context.Context, eventId uuid.UUID) ([]entities.EventReviews, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var reviews []entities.EventReviews
+	err := er.DB.WithContext(ctx).Model(&entities.EventReviews{}).
+		Preload("User").
+		Where("event_id = ?", eventId).
+		Order("created_at DESC").
+		Limit(3).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (er *eventRepository) GetEventRatingSummaries(ctx context.Context, eventIds []uuid.UUID) ([]entities.EventRatingSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var summaries []entities.EventRatingSummary
+	if len(eventIds) == 0 {
+		return summaries, nil
+	}
+
+	err := er.DB.WithContext(ctx).Model(&entities.EventReviews{}).
+		Select("event_id, AVG(rating) as average_rating, COUNT(*) as total_review").
+		Where("event_id IN ?", eventIds).
+		Group("event_id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+func (er *eventRepository) GetEventCategoryRatingSummaries(ctx context.Context) ([]entities.EventCategoryRatingSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var summaries []entities.EventCategoryRatingSummary
+	err := er.DB.WithContext(ctx).Model(&entities.EventCategories{}).
+		Select("event_categories.id as category_id, event_categories.name, COALESCE(AVG(event_reviews.rating), 0) as average_rating, COUNT(event_reviews.id) as total_review").
+		Joins("LEFT JOIN events ON events.category_id = event_categories.id AND events.deleted_at IS NULL").
+		Joins("LEFT JOIN event_reviews ON event_reviews.event_id = events.id").
+		Group("event_categories.id, event_categories.name").
+		Order("event_categories.id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// HasAttendedEvent reports whether the user holds a ticket of the event. Paid
+// transactions without issued tickets still count for their buyer. Tickets
+// have no check-in state, and are only valid while their transaction is paid.
+func (er *eventRepository) HasAttendedEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := er.DB.WithContext(ctx).Model(&entities.EventTicket{}).
+		Joins("JOIN event_transactions ON event_transactions.id = event_tickets.event_transaction_id").
+		Where("event_tickets.holder_user_id = ? AND event_tickets.event_id = ?", userId, eventId).
+		Where("event_transactions.transaction_status IN ?", attendedTransactionStatuses).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	err = er.DB.WithContext(ctx).Model(&entities.EventTransaction{}).
+		Joins("JOIN event_prices ON event_prices.id = event_transactions.event_price_id").
+		Where("event_transactions.user_id = ? AND event_prices.event_id = ?", userId, eventId).
+		Where("event_transactions.transaction_status IN ?", attendedTransactionStatuses).
+		Where("NOT EXISTS (SELECT 1 FROM event_tickets WHERE event_tickets.event_transaction_id = event_transactions.id)").
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (er *eventRepository) HasReviewedEvent(ctx context.Context, userId uuid.UUID, eventId uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := er.DB.WithContext(ctx).Model(&entities.EventReviews{}).
+		Where("user_id = ? AND event_id = ?", userId, eventId).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EventAdminRepository interface {
@@ -37,6 +38,10 @@ type EventAdminRepository interface {
 	UpdateLocation(ctx context.Context, location *entities.EventLocations) error
 	DeleteLocation(ctx context.Context, locationId uuid.UUID) error
 	GetLocation(ctx context.Context) ([]entities.EventLocations, error)
+	CountEventsByLocationID(ctx context.Context, locationId uuid.UUID) (int64, error)
+	// Postal Code Centroids
+	GetPostalCodeCentroid(ctx context.Context, postalCode string) (*entities.PostalCodeCentroids, error)
+	UpsertPostalCodeCentroids(ctx context.Context, centroids []entities.PostalCodeCentroids) error
 	// TicketType
 	CreateTicketType(ctx context.Context, ticketType *entities.EventTicketType) error
 	GetTicketTypeByID(ctx context.Context, ticketTypeId int) (*entities.EventTicketType, error)
@@ -147,6 +152,7 @@ func (r *eventAdminRepository) UpdateEventsAdmin(ctx context.Context, eventID uu
 		"category_id": event.CategoryID,
 		"location_id": event.LocationID,
 		"status":      event.Status,
+		"rsvp_only":   event.RSVPOnly,
 		"date":        event.Date,
 		"updated_at":  time.Now(),
 	}
@@ -189,7 +195,7 @@ func (r *eventAdminRepository) DeleteEventsAdmin(ctx context.Context, eventId uu
 		}
 	}()
 
-	// Cari lokasi ID dari event
+	// Pastikan event ada
 	var event entities.Events
 	if err := tx.Where("id = ?", eventId).First(&event).Error; err != nil {
 		tx.Rollback()
@@ -214,19 +220,7 @@ func (r *eventAdminRepository) DeleteEventsAdmin(ctx context.Context, eventId uu
 		return err
 	}
 
-	// Periksa apakah lokasi digunakan oleh event lain
-	var count int64
-	if err := tx.Model(&entities.Events{}).Where("location_id = ?", event.LocationID).Count(&count).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-	if count == 0 {
-		// Hapus lokasi jika tidak digunakan oleh event lain
-		if err := tx.Where("id = ?", event.LocationID).Delete(&entities.EventLocations{}).Error; err != nil {
-			tx.Rollback()
-			return err
-		}
-	}
+	// Lokasi (venue) tidak dihapus karena dapat digunakan kembali oleh event lain
 
 	// Commit transaksi
 	return tx.Commit().Error
@@ -350,6 +344,30 @@ func (r *eventAdminRepository) GetLocation(ctx context.Context) ([]entities.Even
 	return locations, nil
 }
 
+func (r *eventAdminRepository) CountEventsByLocationID(ctx context.Context, locationId uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.DB.WithContext(ctx).Model(&entities.Events{}).Where("location_id = ?", locationId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Postal Code Centroids
+func (r *eventAdminRepository) GetPostalCodeCentroid(ctx context.Context, postalCode string) (*entities.PostalCodeCentroids, error) {
+	var centroid entities.PostalCodeCentroids
+	if err := r.DB.WithContext(ctx).Where("postal_code = ?", postalCode).First(&centroid).Error; err != nil {
+		return nil, err
+	}
+	return &centroid, nil
+}
+
+func (r *eventAdminRepository) UpsertPostalCodeCentroids(ctx context.Context, centroids []entities.PostalCodeCentroids) error {
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "postal_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"latitude", "longitude", "updated_at"}),
+	}).Create(&centroids).Error
+}
+
 // Ticket Type
 func (r *eventAdminRepository) CreateTicketType(ctx context.Context, ticketType *entities.EventTicketType) error {
 	return r.DB.WithContext(ctx).Create(ticketType).Error
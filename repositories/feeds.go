@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FeedRepository interface {
+	GetFeedStamp(ctx context.Context, tagID *uuid.UUID) (*entities.FeedStamp, error)
+	GetFeedArticles(ctx context.Context, tagID *uuid.UUID, limit int) ([]entities.Articles, error)
+	GetArticleTagNames(ctx context.Context, articleIDs []uuid.UUID) ([]entities.ArticleTagName, error)
+}
+
+type feedRepository struct {
+	DB *gorm.DB
+}
+
+func NewFeedRepository(db *gorm.DB) *feedRepository {
+	return &feedRepository{
+		DB: db,
+	}
+}
+
+// publishedArticles selects the published articles, limited to those tagged
+// with tagID when it is set
+func (fr *feedRepository) publishedArticles(ctx context.Context, tagID *uuid.UUID) *gorm.DB {
+	query := fr.DB.WithContext(ctx).Model(&entities.Articles{}).
+		Where("articles.status = ?", entities.ArticleStatusPublished)
+
+	if tagID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM article_tags WHERE article_tags.article_id = articles.id AND article_tags.tag_id = ?)", *tagID)
+	}
+
+	return query
+}
+
+// GetFeedStamp counts the articles of a feed and returns their latest
+// modification time, without loading the articles themselves
+func (fr *feedRepository) GetFeedStamp(ctx context.Context, tagID *uuid.UUID) (*entities.FeedStamp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var stamp struct {
+		Count        int64
+		LastModified *time.Time
+	}
+	err := fr.publishedArticles(ctx, tagID).
+		Select("COUNT(*) AS count, MAX(GREATEST(articles.updated_at, COALESCE(articles.published_at, articles.created_at))) AS last_modified").
+		Scan(&stamp).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.FeedStamp{Count: stamp.Count}
+	if stamp.LastModified != nil {
+		result.LastModified = stamp.LastModified.UTC()
+	}
+
+	return result, nil
+}
+
+// GetFeedArticles returns the most recently published articles of a feed with their authors
+func (fr *feedRepository) GetFeedArticles(ctx context.Context, tagID *uuid.UUID, limit int) ([]entities.Articles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var articles []entities.Articles
+	err := fr.publishedArticles(ctx, tagID).
+		Preload("Author").
+		Order("COALESCE(articles.published_at, articles.created_at) DESC").
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (fr *feedRepository) GetArticleTagNames(ctx context.Context, articleIDs []uuid.UUID) ([]entities.ArticleTagName, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tags []entities.ArticleTagName
+	if len(articleIDs) == 0 {
+		return tags, nil
+	}
+
+	err := fr.DB.WithContext(ctx).
+		Table("article_tags").
+		Select("article_tags.article_id, tags.name, tags.slug").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("article_tags.article_id IN ?", articleIDs).
+		Order("tags.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider string, subject string) (*entities.UserIdentities, error)
+	GetIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]entities.UserIdentities, error)
+	CreateIdentity(ctx context.Context, identity *entities.UserIdentities) error
+	CreateUserWithIdentity(ctx context.Context, user *entities.User, identity *entities.UserIdentities) error
+	ClaimUnverifiedUser(ctx context.Context, userID uuid.UUID, identity *entities.UserIdentities) error
+	DeleteIdentity(ctx context.Context, userID uuid.UUID, provider string) error
+}
+
+type identityRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdentityRepository(db *gorm.DB) *identityRepository {
+	return &identityRepository{
+		DB: db,
+	}
+}
+
+func (ir *identityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*entities.UserIdentities, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var identity entities.UserIdentities
+	err := ir.DB.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (ir *identityRepository) GetIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]entities.UserIdentities, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var identities []entities.UserIdentities
+	err := ir.DB.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (ir *identityRepository) CreateIdentity(ctx context.Context, identity *entities.UserIdentities) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ir.DB.WithContext(ctx).Omit("User").Create(identity).Error
+}
+
+// CreateUserWithIdentity registers a user who signed up with a social login
+func (ir *identityRepository) CreateUserWithIdentity(ctx context.Context, user *entities.User, identity *entities.UserIdentities) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ir.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("User").Create(identity).Error
+	})
+}
+
+// ClaimUnverifiedUser links an identity to an account whose email was never
+// verified and verifies it. The password is cleared since it was set by
+// someone who never proved they own the email.
+func (ir *identityRepository) ClaimUnverifiedUser(ctx context.Context, userID uuid.UUID, identity *entities.UserIdentities) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ir.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"is_verified": true, "password": ""}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Omit("User").Create(identity).Error
+	})
+}
+
+func (ir *identityRepository) DeleteIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	result := ir.DB.WithContext(ctx).Where("user_id = ? AND provider = ?", userID, provider).Delete(&entities.UserIdentities{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
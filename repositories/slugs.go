@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SlugRepository interface {
+	IsSlugTaken(ctx context.Context, objectType string, slug string, excludeID uuid.UUID) (bool, error)
+	GetSlugByID(ctx context.Context, objectType string, objectID uuid.UUID) (string, error)
+	GetIDBySlug(ctx context.Context, objectType string, slug string) (uuid.UUID, error)
+	GetRedirect(ctx context.Context, objectType string, slug string) (*entities.SlugRedirects, error)
+	SaveSlug(ctx context.Context, objectType string, objectID uuid.UUID, slug string, previousSlug string) error
+	GetMissingSlugs(ctx context.Context, objectType string) ([]entities.SlugSource, error)
+	GetSitemapEntries(ctx context.Context, objectType string) ([]entities.SitemapEntry, error)
+	GetTagSitemapEntries(ctx context.Context) ([]entities.SitemapEntry, error)
+}
+
+// sluggedTable describes where the slugs of a kind of content are stored
+type sluggedTable struct {
+	table      string
+	nameColumn string
+	// publicFilter limits lookups and the sitemap to publicly visible rows
+	publicFilter string
+}
+
+var sluggedTables = map[string]sluggedTable{
+	entities.SlugTypeProduct: {table: "products", nameColumn: "name", publicFilter: "deleted_at IS NULL"},
+	entities.SlugTypeArticle: {table: "articles", nameColumn: "title", publicFilter: fmt.Sprintf("deleted_at IS NULL AND status = '%s'", entities.ArticleStatusPublished)},
+	entities.SlugTypeEvent:   {table: "events", nameColumn: "name", publicFilter: "deleted_at IS NULL"},
+}
+
+type slugRepository struct {
+	DB *gorm.DB
+}
+
+func NewSlugRepository(db *gorm.DB) *slugRepository {
+	return &slugRepository{
+		DB: db,
+	}
+}
+
+func getSluggedTable(objectType string) (sluggedTable, error) {
+	table, ok := sluggedTables[objectType]
+	if !ok {
+		return sluggedTable{}, fmt.Errorf("unknown slug type %q", objectType)
+	}
+	return table, nil
+}
+
+// IsSlugTaken reports whether another row, deleted or not, already uses the slug
+func (sr *slugRepository) IsSlugTaken(ctx context.Context, objectType string, slug string, excludeID uuid.UUID) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return false, err
+	}
+
+	var total int64
+	if err := sr.DB.WithContext(ctx).Table(table.table).Where("slug = ? AND id <> ?", slug, excludeID).Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (sr *slugRepository) GetSlugByID(ctx context.Context, objectType string, objectID uuid.UUID) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return "", err
+	}
+
+	var slugs []string
+	if err := sr.DB.WithContext(ctx).Table(table.table).Where("id = ?", objectID).Limit(1).Pluck("slug", &slugs).Error; err != nil {
+		return "", err
+	}
+
+	if len(slugs) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return slugs[0], nil
+}
+
+func (sr *slugRepository) GetIDBySlug(ctx context.Context, objectType string, slug string) (uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var ids []uuid.UUID
+	if err := sr.DB.WithContext(ctx).Table(table.table).Where("slug = ?", slug).Where(table.publicFilter).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(ids) == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+
+	return ids[0], nil
+}
+
+func (sr *slugRepository) GetRedirect(ctx context.Context, objectType string, slug string) (*entities.SlugRedirects, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var redirect entities.SlugRedirects
+	if err := sr.DB.WithContext(ctx).Where("object_type = ? AND slug = ?", objectType, slug).First(&redirect).Error; err != nil {
+		return nil, err
+	}
+
+	return &redirect, nil
+}
+
+// SaveSlug stores the new slug of a row and keeps its previous slug as a redirect.
+// A redirect left behind by other content under the new slug is dropped.
+func (sr *slugRepository) SaveSlug(ctx context.Context, objectType string, objectID uuid.UUID, slug string, previousSlug string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return sr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveSlug(tx, objectType, objectID, slug, previousSlug)
+	})
+}
+
+func saveSlug(tx *gorm.DB, objectType string, objectID uuid.UUID, slug string, previousSlug string) error {
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Table(table.table).Where("id = ?", objectID).UpdateColumn("slug", slug).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("object_type = ? AND slug = ?", objectType, slug).Delete(&entities.SlugRedirects{}).Error; err != nil {
+		return err
+	}
+
+	if previousSlug == "" || previousSlug == slug {
+		return nil
+	}
+
+	redirect := entities.SlugRedirects{
+		ID:         uuid.New(),
+		ObjectType: objectType,
+		Slug:       previousSlug,
+		ObjectID:   objectID,
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "object_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"object_id", "created_at"}),
+	}).Create(&redirect).Error
+}
+
+func (sr *slugRepository) GetMissingSlugs(ctx context.Context, objectType string) ([]entities.SlugSource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []entities.SlugSource
+	err = sr.DB.WithContext(ctx).
+		Table(table.table).
+		Select(fmt.Sprintf("id, %s AS name", table.nameColumn)).
+		Where("slug = '' AND deleted_at IS NULL").
+		Order("created_at ASC").
+		Scan(&sources).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func (sr *slugRepository) GetSitemapEntries(ctx context.Context, objectType string) ([]entities.SitemapEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	table, err := getSluggedTable(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entities.SitemapEntry
+	err = sr.DB.WithContext(ctx).
+		Table(table.table).
+		Select("slug, updated_at").
+		Where("slug <> ''").
+		Where(table.publicFilter).
+		Order("updated_at DESC").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (sr *slugRepository) GetTagSitemapEntries(ctx context.Context) ([]entities.SitemapEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var entries []entities.SitemapEntry
+	if err := sr.DB.WithContext(ctx).Model(&entities.Tags{}).Select("slug, updated_at").Order("slug ASC").Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,406 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	CreateTags(ctx context.Context, tags []entities.Tags) error
+	GetTagsBySlugs(ctx context.Context, slugs []string) ([]entities.Tags, error)
+	GetTagByID(ctx context.Context, tagID uuid.UUID) (*entities.Tags, error)
+	GetTagBySlug(ctx context.Context, slug string) (*entities.Tags, error)
+	SearchTags(ctx context.Context, query string, limit int) ([]entities.Tags, error)
+	GetTagSummaries(ctx context.Context) ([]entities.TagSummary, error)
+	RenameTag(ctx context.Context, tag *entities.Tags) error
+	DeleteTag(ctx context.Context, tagID uuid.UUID) error
+	MergeTags(ctx context.Context, sourceIDs []uuid.UUID, targetID uuid.UUID) error
+
+	GetTagsByArticleID(ctx context.Context, articleID uuid.UUID) ([]entities.Tags, error)
+	GetTagsByProductID(ctx context.Context, productID uuid.UUID) ([]entities.Tags, error)
+	ReplaceArticleTags(ctx context.Context, articleID uuid.UUID, tagIDs []uuid.UUID) error
+	ReplaceProductTags(ctx context.Context, productID uuid.UUID, tagIDs []uuid.UUID) error
+	GetArticlesByTagID(ctx context.Context, tagID uuid.UUID) ([]entities.Articles, error)
+	GetProductsByTagID(ctx context.Context, tagID uuid.UUID) ([]entities.Products, error)
+	GetUntaggedArticles(ctx context.Context) ([]entities.Articles, error)
+}
+
+type tagRepository struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *tagRepository {
+	return &tagRepository{
+		DB: db,
+	}
+}
+
+// CreateTags inserts the tags, skipping those whose slug already exists
+func (tr *tagRepository) CreateTags(ctx context.Context, tags []entities.Tags) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return tr.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+}
+
+func (tr *tagRepository) GetTagsBySlugs(ctx context.Context, slugs []string) ([]entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tags []entities.Tags
+	if err := tr.DB.WithContext(ctx).Where("slug IN ?", slugs).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tr *tagRepository) GetTagByID(ctx context.Context, tagID uuid.UUID) (*entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tag entities.Tags
+	if err := tr.DB.WithContext(ctx).Where("id = ?", tagID).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (tr *tagRepository) GetTagBySlug(ctx context.Context, slug string) (*entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tag entities.Tags
+	if err := tr.DB.WithContext(ctx).Where("slug = ?", slug).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// SearchTags returns the tags whose name or slug starts with query, for autocomplete
+func (tr *tagRepository) SearchTags(ctx context.Context, query string, limit int) ([]entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tags []entities.Tags
+	err := tr.DB.WithContext(ctx).
+		Where("name ILIKE ? OR slug LIKE ?", query+"%", query+"%").
+		Order("name ASC").
+		Limit(limit).
+		Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tr *tagRepository) GetTagSummaries(ctx context.Context) ([]entities.TagSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var summaries []entities.TagSummary
+	err := tr.DB.WithContext(ctx).
+		Table("tags").
+		Select(`tags.id, tags.name, tags.slug,
+			(SELECT COUNT(*) FROM article_tags WHERE article_tags.tag_id = tags.id) AS article_count,
+			(SELECT COUNT(*) FROM product_tags WHERE product_tags.tag_id = tags.id) AS product_count`).
+		Order("tags.name ASC").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+func (tr *tagRepository) RenameTag(ctx context.Context, tag *entities.Tags) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.Tags{}).Where("id = ?", tag.ID).Updates(map[string]interface{}{
+			"name":       tag.Name,
+			"slug":       tag.Slug,
+			"updated_at": tag.UpdatedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return syncArticleTagNames(tx, []uuid.UUID{tag.ID})
+	})
+}
+
+func (tr *tagRepository) DeleteTag(ctx context.Context, tagID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		articleIDs, err := taggedArticleIDs(tx, []uuid.UUID{tagID})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("tag_id = ?", tagID).Delete(&entities.ArticleTags{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("tag_id = ?", tagID).Delete(&entities.ProductTags{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", tagID).Delete(&entities.Tags{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return updateArticleTagNames(tx, articleIDs)
+	})
+}
+
+// MergeTags moves every article and product of the source tags to the target tag
+// and removes the source tags.
+func (tr *tagRepository) MergeTags(ctx context.Context, sourceIDs []uuid.UUID, targetID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		articleIDs, err := taggedArticleIDs(tx, sourceIDs)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`INSERT INTO article_tags (article_id, tag_id, created_at)
+			SELECT DISTINCT article_id, ?, NOW() FROM article_tags WHERE tag_id IN ?
+			ON CONFLICT DO NOTHING`, targetID, sourceIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`INSERT INTO product_tags (product_id, tag_id, created_at)
+			SELECT DISTINCT product_id, ?, NOW() FROM product_tags WHERE tag_id IN ?
+			ON CONFLICT DO NOTHING`, targetID, sourceIDs).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("tag_id IN ?", sourceIDs).Delete(&entities.ArticleTags{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("tag_id IN ?", sourceIDs).Delete(&entities.ProductTags{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("id IN ?", sourceIDs).Delete(&entities.Tags{}).Error; err != nil {
+			return err
+		}
+
+		return updateArticleTagNames(tx, articleIDs)
+	})
+}
+
+func (tr *tagRepository) GetTagsByArticleID(ctx context.Context, articleID uuid.UUID) ([]entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tags []entities.Tags
+	err := tr.DB.WithContext(ctx).
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Where("article_tags.article_id = ?", articleID).
+		Order("tags.name ASC").
+		Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tr *tagRepository) GetTagsByProductID(ctx context.Context, productID uuid.UUID) ([]entities.Tags, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var tags []entities.Tags
+	err := tr.DB.WithContext(ctx).
+		Joins("JOIN product_tags ON product_tags.tag_id = tags.id").
+		Where("product_tags.product_id = ?", productID).
+		Order("tags.name ASC").
+		Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ReplaceArticleTags sets the tags of an article and refreshes its tags column
+func (tr *tagRepository) ReplaceArticleTags(ctx context.Context, articleID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceArticleTags(tx, articleID, tagIDs)
+	})
+}
+
+func replaceArticleTags(tx *gorm.DB, articleID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := tx.Where("article_id = ?", articleID).Delete(&entities.ArticleTags{}).Error; err != nil {
+		return err
+	}
+
+	if len(tagIDs) > 0 {
+		links := make([]entities.ArticleTags, len(tagIDs))
+		for i, tagID := range tagIDs {
+			links[i] = entities.ArticleTags{ArticleID: articleID, TagID: tagID}
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+			return err
+		}
+	}
+
+	return updateArticleTagNames(tx, []uuid.UUID{articleID})
+}
+
+func (tr *tagRepository) ReplaceProductTags(ctx context.Context, productID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&entities.ProductTags{}).Error; err != nil {
+			return err
+		}
+
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		links := make([]entities.ProductTags, len(tagIDs))
+		for i, tagID := range tagIDs {
+			links[i] = entities.ProductTags{ProductID: productID, TagID: tagID}
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+func (tr *tagRepository) GetArticlesByTagID(ctx context.Context, tagID uuid.UUID) ([]entities.Articles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var articles []entities.Articles
+	err := tr.DB.WithContext(ctx).
+		Joins("JOIN article_tags ON article_tags.article_id = articles.id").
+		Where("article_tags.tag_id = ? AND articles.status = ?", tagID, entities.ArticleStatusPublished).
+		Order("articles.created_at DESC").
+		Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (tr *tagRepository) GetProductsByTagID(ctx context.Context, tagID uuid.UUID) ([]entities.Products, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var products []entities.Products
+	err := tr.DB.WithContext(ctx).
+		Preload("ProductPricing").
+		Preload("ProductImages").
+		Joins("JOIN product_tags ON product_tags.product_id = products.id").
+		Where("product_tags.tag_id = ?", tagID).
+		Order("products.created_at DESC").
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// GetUntaggedArticles returns articles that still only have the legacy tags column
+func (tr *tagRepository) GetUntaggedArticles(ctx context.Context) ([]entities.Articles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var articles []entities.Articles
+	err := tr.DB.WithContext(ctx).
+		Where("tags <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM article_tags WHERE article_tags.article_id = articles.id)").
+		Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func taggedArticleIDs(tx *gorm.DB, tagIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var articleIDs []uuid.UUID
+	if err := tx.Model(&entities.ArticleTags{}).Where("tag_id IN ?", tagIDs).Distinct().Pluck("article_id", &articleIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return articleIDs, nil
+}
+
+func syncArticleTagNames(tx *gorm.DB, tagIDs []uuid.UUID) error {
+	articleIDs, err := taggedArticleIDs(tx, tagIDs)
+	if err != nil {
+		return err
+	}
+
+	return updateArticleTagNames(tx, articleIDs)
+}
+
+// updateArticleTagNames rebuilds the comma separated tags column of the articles
+// from their linked tags, so older clients keep seeing up to date tag names.
+func updateArticleTagNames(tx *gorm.DB, articleIDs []uuid.UUID) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+
+	return tx.Exec(`UPDATE articles SET tags = LEFT(COALESCE((
+			SELECT string_agg(tags.name, ', ' ORDER BY tags.name)
+			FROM article_tags JOIN tags ON tags.id = article_tags.tag_id
+			WHERE article_tags.article_id = articles.id
+		), ''), 255)
+		WHERE id IN ?`, articleIDs).Error
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, accountID uuid.UUID, accountType string) (*entities.TwoFactorAuth, error)
+	SavePendingTwoFactor(ctx context.Context, twoFactor *entities.TwoFactorAuth) error
+	EnableTwoFactor(ctx context.Context, id uuid.UUID, step int64, codes []entities.TwoFactorRecoveryCodes) error
+	UseStep(ctx context.Context, id uuid.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codes []entities.TwoFactorRecoveryCodes) error
+	CountRecoveryCodes(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteTwoFactor(ctx context.Context, accountID uuid.UUID, accountType string) error
+}
+
+type twoFactorRepository struct {
+	DB *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) *twoFactorRepository {
+	return &twoFactorRepository{
+		DB: db,
+	}
+}
+
+func (tr *twoFactorRepository) GetTwoFactor(ctx context.Context, accountID uuid.UUID, accountType string) (*entities.TwoFactorAuth, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var twoFactor entities.TwoFactorAuth
+	err := tr.DB.WithContext(ctx).Where("account_id = ? AND account_type = ?", accountID, accountType).First(&twoFactor).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &twoFactor, nil
+}
+
+// SavePendingTwoFactor replaces the pending secret of an account, enrolments
+// that were started but never confirmed are dropped
+func (tr *twoFactorRepository) SavePendingTwoFactor(ctx context.Context, twoFactor *entities.TwoFactorAuth) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("account_id = ? AND account_type = ? AND enabled_at IS NULL", twoFactor.AccountID, twoFactor.AccountType).
+			Delete(&entities.TwoFactorAuth{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(twoFactor).Error
+	})
+}
+
+// EnableTwoFactor confirms a pending secret and stores its recovery codes
+func (tr *twoFactorRepository) EnableTwoFactor(ctx context.Context, id uuid.UUID, step int64, codes []entities.TwoFactorRecoveryCodes) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.TwoFactorAuth{}).
+			Where("id = ? AND enabled_at IS NULL", id).
+			Updates(map[string]interface{}{"enabled_at": time.Now(), "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseStep accepts the time step of a code once, it returns false when a code
+// of the same or a later step was already used
+func (tr *twoFactorRepository) UseStep(ctx context.Context, id uuid.UUID, step int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	result := tr.DB.WithContext(ctx).Model(&entities.TwoFactorAuth{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UseRecoveryCode marks an unused recovery code as used, it returns false
+// when there is no such code
+func (tr *twoFactorRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	result := tr.DB.WithContext(ctx).Model(&entities.TwoFactorRecoveryCodes{}).
+		Where("two_factor_id = ? AND code_hash = ? AND used_at IS NULL", id, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (tr *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codes []entities.TwoFactorRecoveryCodes) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return tr.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("two_factor_id = ?", id).Delete(&entities.TwoFactorRecoveryCodes{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+// CountRecoveryCodes returns how many recovery codes are left
+func (tr *twoFactorRepository) CountRecoveryCodes(ctx context.Context, id uuid.UUID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err := tr.DB.WithContext(ctx).Model(&entities.TwoFactorRecoveryCodes{}).
+		Where("two_factor_id = ? AND used_at IS NULL", id).
+		Count(&count).Error
+	return count, err
+}
+
+func (tr *twoFactorRepository) DeleteTwoFactor(ctx context.Context, accountID uuid.UUID, accountType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	result := tr.DB.WithContext(ctx).
+		Where("account_id = ? AND account_type = ?", accountID, accountType).
+		Delete(&entities.TwoFactorAuth{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"kreasi-nusantara-api/entities"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,6 +18,10 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	UpdateProfile(ctx context.Context, user *entities.User) error
 	DeleteProfile(ctx context.Context, id uuid.UUID) error
+	DeleteUnverifiedUsers(ctx context.Context, now time.Time) (int64, error)
+	UsernameExists(ctx context.Context, username string) (bool, error)
+	UpdateEmail(ctx context.Context, id uuid.UUID, email string) error
+	SetPhoneVerified(ctx context.Context, id uuid.UUID, verified bool) error
 }
 
 type userRepository struct {
@@ -95,4 +100,50 @@ func (ur *userRepository) DeleteProfile(ctx context.Context, id uuid.UUID) error
 		return err
 	}
 	return ur.DB.Where("id = ?", id).Delete(&entities.User{}).Error
-}
\ No newline at end of file
+}
+
+// DeleteUnverifiedUsers permanently deletes unverified accounts whose
+// verification deadline passed before now, so they can be registered again.
+// Accounts without a deadline predate required verification and are kept, as
+// are accounts that already have orders, carts, addresses or comments.
+func (ur *userRepository) DeleteUnverifiedUsers(ctx context.Context, now time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	query := ur.DB.WithContext(ctx).Unscoped().
+		Where("is_verified = ? AND verify_by IS NOT NULL AND verify_by < ?", false, now)
+	for _, table := range []string{"product_transactions", "event_transactions", "carts", "user_addresses", "article_comments", "article_comment_replies"} {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM " + table + " WHERE " + table + ".user_id = users.id)")
+	}
+
+	result := query.Delete(&entities.User{})
+	return result.RowsAffected, result.Error
+}
+
+// UsernameExists also counts deleted users, their usernames stay reserved
+func (ur *userRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := ur.DB.WithContext(ctx).Unscoped().Model(&entities.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+func (ur *userRepository) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ur.DB.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Update("email", email).Error
+}
+
+func (ur *userRepository) SetPhoneVerified(ctx context.Context, id uuid.UUID, verified bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ur.DB.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Update("is_phone_verified", verified).Error
+}
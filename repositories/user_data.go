@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// deletedUserName is shown instead of the name of a deleted user
+const deletedUserName = "Deleted User"
+
+type UserDataRepository interface {
+	GetUserData(ctx context.Context, userID uuid.UUID) (*entities.UserData, error)
+	AnonymizeUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type userDataRepository struct {
+	DB *gorm.DB
+}
+
+func NewUserDataRepository(db *gorm.DB) *userDataRepository {
+	return &userDataRepository{
+		DB: db,
+	}
+}
+
+// GetUserData loads the profile of a user and every record that belongs to them
+func (ur *userDataRepository) GetUserData(ctx context.Context, userID uuid.UUID) (*entities.UserData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	db := ur.DB.WithContext(ctx)
+	data := new(entities.UserData)
+
+	if err := db.Where("id = ?", userID).First(&data.User).Error; err != nil {
+		return nil, err
+	}
+
+	// Records of deleted products and events are still part of the user's history
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	queries := []*gorm.DB{
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Addresses),
+		db.Where("user_id = ?", userID).Order("tracsaction_date ASC").Find(&data.ProductTransactions),
+		db.Where("user_id = ?", userID).
+			Preload("Items.ProductVariant", unscoped).
+			Preload("Items.ProductVariant.Products", unscoped).
+			Order("created_at ASC").Find(&data.Carts),
+		db.Where("user_id = ?", userID).Preload("Buyer").Order("transaction_date ASC").Find(&data.EventTransactions),
+		db.Where("holder_user_id = ?", userID).Preload("Event", unscoped).Order("created_at ASC").Find(&data.Tickets),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Comments),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Replies),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.ProductReviews),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.EventReviews),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Waitlist),
+		db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Identities),
+		db.Where("user_id = ? AND role = ?", userID, "user").Order("created_at ASC").Find(&data.Sessions),
+	}
+	for _, query := range queries {
+		if query.Error != nil {
+			return nil, query.Error
+		}
+	}
+
+	return data, nil
+}
+
+// AnonymizeUser deletes a user's account by removing their personal data.
+// Transactions, tickets and the carts they were paid for are kept for the
+// books with the buyer's personal details blanked out. Comments and reviews
+// stay under the anonymized account, everything else is removed.
+func (ur *userDataRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return ur.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"username":          "deleted_" + userID.String(),
+			"first_name":        "Deleted",
+			"last_name":         "User",
+			"email":             fmt.Sprintf("deleted+%s@deleted.invalid", userID),
+			"password":          "",
+			"phone":             nil,
+			"photo":             nil,
+			"bio":               nil,
+			"is_phone_verified": false,
+			"deleted_at":        time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&entities.UserAddresses{}).Error; err != nil {
+			return err
+		}
+
+		// Carts that were never paid for are not a financial record
+		unpaidCarts := tx.Model(&entities.Cart{}).Select("id").
+			Where("user_id = ? AND id NOT IN (?)", userID, tx.Model(&entities.ProductTransaction{}).Select("cart_id"))
+		if err := tx.Where("cart_id IN (?)", unpaidCarts).Delete(&entities.CartItems{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? AND id NOT IN (?)", userID, tx.Model(&entities.ProductTransaction{}).Select("cart_id")).
+			Delete(&entities.Cart{}).Error; err != nil {
+			return err
+		}
+
+		buyerDetails := map[string]interface{}{
+			"identity_number": "",
+			"full_name":       deletedUserName,
+			"email":           "",
+			"phone":           "",
+		}
+		err := tx.Model(&entities.EventTransactionBuyer{}).
+			Where("event_transaction_id IN (?)", tx.Model(&entities.EventTransaction{}).Select("id").Where("user_id = ?", userID)).
+			Updates(buyerDetails).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.EventTicket{}).Where("holder_user_id = ?", userID).Updates(buyerDetails).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(&entities.EventTicketTransfer{}).Where("from_user_id = ?", userID).
+			Updates(map[string]interface{}{"from_full_name": deletedUserName, "from_email": ""}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&entities.EventTicketTransfer{}).Where("to_user_id = ?", userID).
+			Updates(map[string]interface{}{"to_full_name": deletedUserName, "to_email": ""}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entities.EventWaitlist{}).
+			Where("user_id = ? AND status IN ?", userID, []string{entities.WaitlistStatusWaiting, entities.WaitlistStatusNotified}).
+			Updates(map[string]interface{}{"status": entities.WaitlistStatusCancelled, "reservation_token": nil, "reserved_until": nil}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.UserIdentities{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id = ? AND account_type = ?", userID, entities.AccountTypeUser).Delete(&entities.TwoFactorAuth{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&entities.ContentShares{}).Error
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 type WebhookRepository interface {
 	HandleNotification(ctx context.Context, webhook entities.PaymentNotification, transaction entities.UpdateTransaction, tableName string) error
+	IsEventTransaction(ctx context.Context, orderID string) (bool, error)
 }
 
 type webhookRepository struct {
@@ -41,3 +42,14 @@ func (wr *webhookRepository) HandleNotification(ctx context.Context, webhook ent
 	return tx.Commit().Error
 
 }
+
+// IsEventTransaction reports whether a payment belongs to an event transaction
+func (wr *webhookRepository) IsEventTransaction(ctx context.Context, orderID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var count int64
+	err := wr.DB.WithContext(ctx).Model(&entities.EventTransaction{}).Where("id = ?", orderID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+	"kreasi-nusantara-api/config"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/cloudinary"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
+	"kreasi-nusantara-api/utils/password"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitAdminInvitationRoute registers the invite-only onboarding of admins
+func InitAdminInvitationRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tokenUtil := token.NewTokenUtil()
+	cloudinaryInstance, _ := config.SetupCloudinary()
+
+	invitationUseCase := usecases.NewAdminInvitationUseCase(
+		repositories.NewAdminInvitationRepository(db),
+		repositories.NewAdminRepository(db),
+		password.NewPasswordUtil(),
+		cloudinary.NewCloudinaryService(cloudinaryInstance),
+		tokenUtil,
+		email.NewEmailUtil(),
+	)
+	invitationController := controllers.NewAdminInvitationController(invitationUseCase, v, tokenUtil)
+
+	go invitationUseCase.InviteFirstSuperAdmin(context.Background())
+
+	// Public routes, authorized by the signed invite token
+	g.POST("/admin/invitations/accept", invitationController.AcceptInvitation)
+
+	// Protected routes
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsSuperAdmin)
+	g.POST("/admin/invitations", invitationController.InviteAdmin)
+	g.GET("/admin/invitations", invitationController.GetPendingInvitations)
+	g.DELETE("/admin/invitations/:id", invitationController.RevokeInvitation)
+}
@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"context"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitAdminRoleRoute registers the management of admin roles and permissions
+func InitAdminRoleRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tokenUtil := token.NewTokenUtil()
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	roleController := controllers.NewAdminRoleController(roleUseCase, v, tokenUtil)
+
+	go roleUseCase.SeedDefaultRoles(context.Background())
+
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	g.GET("/admin/me/permissions", roleController.GetMyPermissions, middlewares.IsAdminOrSuperAdmin)
+
+	superAdminGroup := g.Group("/admin", middlewares.IsSuperAdmin)
+	superAdminGroup.GET("/permissions", roleController.GetPermissions)
+	superAdminGroup.GET("/roles", roleController.GetRoles)
+	superAdminGroup.POST("/roles", roleController.CreateRole)
+	superAdminGroup.PUT("/roles/:id", roleController.UpdateRole)
+	superAdminGroup.DELETE("/roles/:id", roleController.DeleteRole)
+	superAdminGroup.GET("/:id/roles", roleController.GetAdminRoles)
+	superAdminGroup.PUT("/:id/roles", roleController.SetAdminRoles)
+}
@@ -4,11 +4,13 @@ import (
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/cloudinary"
+	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/middlewares"
 
 	// "kreasi-nusantara-api/middlewares"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
 	"kreasi-nusantara-api/utils/password"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
@@ -26,12 +28,15 @@ func InitAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	cloudinaryService := cloudinary.NewCloudinaryService(cloudinaryInstance)
 
 	adminRepo := repositories.NewAdminRepository(db)
-	adminUseCase := usecases.NewAdminUsecase(adminRepo, passwordUtil, cloudinaryService, tokenUtil)
+	redisClient := redis.NewRedisClient()
+	emailUtil := email.NewEmailUtil()
+	authUseCase := usecases.NewAuthUseCase(repositories.NewAuthRepository(db), tokenUtil, *redisClient, emailUtil)
+	twoFactorUseCase := usecases.NewTwoFactorUseCase(repositories.NewTwoFactorRepository(db), repositories.NewUserRepository(db), adminRepo, *redisClient, authUseCase)
+	adminUseCase := usecases.NewAdminUsecase(adminRepo, passwordUtil, cloudinaryService, tokenUtil, authUseCase, twoFactorUseCase)
 	adminController := controllers.NewAdminController(adminUseCase, v, tokenUtil)
 
 	// Public routes
 	g.POST("/admin/login", adminController.Login)
-	g.POST("/admin/register", adminController.Register)
 
 	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsAdminOrSuperAdmin)
 	g.GET("/admin/avatar", adminController.GetAvatarAdmin)
@@ -42,5 +47,7 @@ func InitAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	g.DELETE("/admin/:id", adminController.DeleteAdmin)
 	g.PUT("/admin/:id", adminController.UpdateAdmin)
 	g.GET("/admin/search", adminController.SearchAdminByUsername)
+	g.POST("/admin/:id/deactivate", adminController.DeactivateAdmin)
+	g.POST("/admin/:id/activate", adminController.ActivateAdmin)
 
 }
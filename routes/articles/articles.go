@@ -2,8 +2,10 @@ package articles
 
 import (
 	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
 
@@ -14,19 +16,41 @@ import (
 
 func InitArticlesRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	articleRepo := repositories.NewArticleRepository(db)
-	articleUseCase := usecases.NewArticleUseCase(articleRepo)
+	tagUseCase := usecases.NewTagUseCase(repositories.NewTagRepository(db), repositories.NewProductRepository(db))
+	moderationUseCase := usecases.NewCommentModerationUseCase(repositories.NewCommentModerationRepository(db))
+	userRepo := repositories.NewUserRepository(db)
+	emailUtil := email.NewEmailUtil()
+	articleUseCase := usecases.NewArticleUseCase(articleRepo, tagUseCase, moderationUseCase, userRepo, emailUtil)
 
 	tokenUtil := token.NewTokenUtil()
 
-	articleController := controllers.NewArticleController(articleUseCase, v, tokenUtil)
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+
+	redisClient := redis.NewRedisClient()
+	engagementUseCase := usecases.NewEngagementUseCase(repositories.NewEngagementRepository(db), *redisClient)
+
+	articleController := controllers.NewArticleController(articleUseCase, v, tokenUtil, slugUseCase, engagementUseCase)
 
 	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
 	g.GET("/articles", articleController.GetArticles)
 	g.GET("/articles/:article_id", articleController.GetArticleByID)
+	g.GET("/articles/slug/:slug", articleController.GetArticleBySlug)
 	g.GET("/articles/search", articleController.SearchArticles)
 	g.GET("/articles/:article_id/comments", articleController.GetCommentsByArticleID)
 	g.POST("/articles/:article_id/comments", articleController.AddCommentToArticle)
 	g.POST("/articles/:article_id/comments/:comment_id/reply", articleController.ReplyToComment)
+	g.GET("/articles/:article_id/comments/:comment_id/replies", articleController.GetReplies)
+	g.POST("/articles/:article_id/comments/:comment_id/like", articleController.LikeComment)
+	g.POST("/articles/:article_id/comments/:comment_id/unlike", articleController.UnlikeComment)
+	g.PUT("/articles/:article_id/comments/:comment_id", articleController.EditComment)
+	g.DELETE("/articles/:article_id/comments/:comment_id", articleController.DeleteComment)
+	g.POST("/articles/:article_id/comments/:comment_id/report", articleController.ReportComment)
+	g.PUT("/articles/:article_id/comments/:comment_id/replies/:reply_id", articleController.EditReply)
+	g.DELETE("/articles/:article_id/comments/:comment_id/replies/:reply_id", articleController.DeleteReply)
+	g.POST("/articles/:article_id/comments/:comment_id/replies/:reply_id/report", articleController.ReportReply)
+	g.POST("/articles/:article_id/comments/:comment_id/replies/:reply_id/like", articleController.LikeReply)
+	g.POST("/articles/:article_id/comments/:comment_id/replies/:reply_id/unlike", articleController.UnlikeReply)
 	g.POST("/articles/:article_id/like", articleController.LikeArticle)
 	g.POST("/articles/:article_id/unlike", articleController.UnlikeArticle)
+	g.POST("/articles/:article_id/share", articleController.ShareArticle)
 }
@@ -1,17 +1,22 @@
 package articles_admin
 
 import (
+	"context"
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/cloudinary"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/middlewares"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
+	"time"
 
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -23,14 +28,36 @@ func InitArticleAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator)
 	adminRepo := repositories.NewAdminRepository(db)
 
 	articleAdminRepo := repositories.NewArticleAdminRepository(db)
-	articleAdminUsecase := usecases.NewArticleUseCaseAdmin(articleAdminRepo, tokenUtil, adminRepo)
-	articleAdminController := controllers.NewArticlesAdminController(articleAdminUsecase, v, cloudinaryService, tokenUtil)
+	tagUseCase := usecases.NewTagUseCase(repositories.NewTagRepository(db), repositories.NewProductRepository(db))
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+	articleAdminUsecase := usecases.NewArticleUseCaseAdmin(articleAdminRepo, tokenUtil, adminRepo, tagUseCase, slugUseCase)
+	engagementUseCase := usecases.NewEngagementUseCase(repositories.NewEngagementRepository(db), *redis.NewRedisClient())
+	articleAdminController := controllers.NewArticlesAdminController(articleAdminUsecase, v, cloudinaryService, tokenUtil, engagementUseCase)
 
-	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsAdminOrSuperAdmin)
+	// Publish scheduled articles once their publish time arrives
+	go articleAdminUsecase.RunScheduledPublishing(context.Background(), time.Minute)
+
+	// Fill in excerpts and reading times of articles written before they were stored
+	go func() {
+		if err := articleAdminUsecase.BackfillArticleSummaries(context.Background()); err != nil {
+			logrus.WithError(err).Error("Failed to backfill article summaries")
+		}
+	}()
+
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), adminRepo)
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionContentManage))
 	g.GET("/articles", articleAdminController.GetArticles)
 	g.POST("/articles", articleAdminController.CreateArticlesAdmin)
+	g.POST("/articles/images", articleAdminController.UploadArticleImage)
 	g.DELETE("/articles/:id", articleAdminController.DeleteArticlesAdmin)
 	g.PUT("/articles/:id", articleAdminController.UpdateArticlesAdmin)
+	g.PUT("/articles/:id/status", articleAdminController.UpdateArticleStatus)
+	g.GET("/articles/:id/stats", articleAdminController.GetArticleStats)
+	g.GET("/articles/:id/products", articleAdminController.GetArticleProducts)
+	g.PUT("/articles/:id/products", articleAdminController.SetArticleProducts)
+	g.GET("/articles/:id/revisions", articleAdminController.GetArticleRevisions)
+	g.GET("/articles/:id/revisions/diff", articleAdminController.DiffArticleRevisions)
+	g.POST("/articles/:id/revisions/:revision_id/restore", articleAdminController.RestoreArticleRevision)
 	g.GET("/articles/search", articleAdminController.SearchArticles)
 	g.GET("/articles/:id", articleAdminController.GetArticleByID)
 }
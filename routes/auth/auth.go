@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+	"time"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitAuthRoute registers token refresh, logout and session management, shared
+// by users and admins
+func InitAuthRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tokenUtil := token.NewTokenUtil()
+	redisClient := redis.NewRedisClient()
+	emailUtil := email.NewEmailUtil()
+	authUseCase := usecases.NewAuthUseCase(repositories.NewAuthRepository(db), tokenUtil, *redisClient, emailUtil)
+	authController := controllers.NewAuthController(authUseCase, v, tokenUtil)
+
+	go authUseCase.RunTokenCleanup(context.Background(), time.Hour)
+
+	// Public routes
+	g.POST("/auth/refresh", authController.RefreshToken)
+
+	// Protected routes
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	g.POST("/logout", authController.Logout)
+	g.POST("/logout/all", authController.LogoutAll)
+	g.GET("/users/me/sessions", authController.GetSessions)
+	g.DELETE("/users/me/sessions/:id", authController.RevokeSession)
+
+	adminGroup := g.Group("/admin", middlewares.IsAdminOrSuperAdmin)
+	adminGroup.GET("/me/sessions", authController.GetSessions)
+	adminGroup.DELETE("/me/sessions/:id", authController.RevokeSession)
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"kreasi-nusantara-api/config"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
+	"kreasi-nusantara-api/utils/oidc"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitSocialLoginRoute registers login with OpenID Connect providers and the
+// management of the provider accounts linked to a user
+func InitSocialLoginRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	var providers []oidc.Provider
+	for _, providerConfig := range config.InitConfigOIDC() {
+		providers = append(providers, oidc.NewProvider(providerConfig))
+	}
+
+	tokenUtil := token.NewTokenUtil()
+	redisClient := redis.NewRedisClient()
+	authUseCase := usecases.NewAuthUseCase(repositories.NewAuthRepository(db), tokenUtil, *redisClient, email.NewEmailUtil())
+	twoFactorUseCase := usecases.NewTwoFactorUseCase(repositories.NewTwoFactorRepository(db), repositories.NewUserRepository(db), repositories.NewAdminRepository(db), *redisClient, authUseCase)
+	socialLoginUseCase := usecases.NewSocialLoginUseCase(providers, repositories.NewIdentityRepository(db), repositories.NewUserRepository(db), *redisClient, authUseCase, twoFactorUseCase)
+	socialLoginController := controllers.NewSocialLoginController(socialLoginUseCase, v, tokenUtil)
+
+	// Public routes
+	g.GET("/auth/oidc/providers", socialLoginController.GetProviders)
+	g.GET("/auth/oidc/:provider/authorize", socialLoginController.GetAuthorizationURL)
+	g.POST("/auth/oidc/:provider/callback", socialLoginController.Login)
+
+	// Protected routes
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	g.GET("/users/me/identities", socialLoginController.GetIdentities)
+	g.GET("/users/me/identities/:provider/authorize", socialLoginController.GetLinkURL)
+	g.POST("/users/me/identities/:provider", socialLoginController.LinkIdentity)
+	g.DELETE("/users/me/identities/:provider", socialLoginController.UnlinkIdentity)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitTwoFactorRoute registers the second login step and the enrolment of
+// authenticator apps, shared by users and admins
+func InitTwoFactorRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tokenUtil := token.NewTokenUtil()
+	redisClient := redis.NewRedisClient()
+	authUseCase := usecases.NewAuthUseCase(repositories.NewAuthRepository(db), tokenUtil, *redisClient, email.NewEmailUtil())
+	twoFactorUseCase := usecases.NewTwoFactorUseCase(repositories.NewTwoFactorRepository(db), repositories.NewUserRepository(db), repositories.NewAdminRepository(db), *redisClient, authUseCase)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorUseCase, v, tokenUtil)
+
+	// Public routes, authorized by the challenge token of the first login step
+	g.POST("/auth/2fa/verify", twoFactorController.VerifyLogin)
+	g.POST("/auth/2fa/setup", twoFactorController.SetupLogin)
+	g.POST("/auth/2fa/enable", twoFactorController.EnableLogin)
+
+	// Protected routes
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	g.GET("/users/me/2fa", twoFactorController.GetStatus)
+	g.POST("/users/me/2fa/setup", twoFactorController.Setup)
+	g.POST("/users/me/2fa/enable", twoFactorController.Enable)
+	g.POST("/users/me/2fa/disable", twoFactorController.Disable)
+	g.POST("/users/me/2fa/recovery-codes", twoFactorController.RegenerateRecoveryCodes)
+
+	adminGroup := g.Group("/admin", middlewares.IsAdminOrSuperAdmin)
+	adminGroup.GET("/me/2fa", twoFactorController.GetStatus)
+	adminGroup.POST("/me/2fa/setup", twoFactorController.Setup)
+	adminGroup.POST("/me/2fa/enable", twoFactorController.Enable)
+	adminGroup.POST("/me/2fa/disable", twoFactorController.Disable)
+	adminGroup.POST("/me/2fa/recovery-codes", twoFactorController.RegenerateRecoveryCodes)
+
+	superAdminGroup := g.Group("/admin", middlewares.IsSuperAdmin)
+	superAdminGroup.DELETE("/:id/2fa", twoFactorController.ResetAdmin)
+}
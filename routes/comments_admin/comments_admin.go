@@ -0,0 +1,32 @@
+package comments_admin
+
+import (
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func InitCommentsAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	moderationRepo := repositories.NewCommentModerationRepository(db)
+	moderationUseCase := usecases.NewCommentModerationUseCase(moderationRepo)
+	moderationController := controllers.NewCommentModerationController(moderationUseCase, v)
+
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionCommentsModerate))
+	g.GET("/comments/moderation", moderationController.GetModerationQueue)
+	g.POST("/comments/:id/hide", moderationController.HideComment)
+	g.POST("/comments/:id/restore", moderationController.RestoreComment)
+	g.POST("/comment-replies/:id/hide", moderationController.HideReply)
+	g.POST("/comment-replies/:id/restore", moderationController.RestoreReply)
+	g.GET("/banned-words", moderationController.GetBannedWords)
+	g.POST("/banned-words", moderationController.CreateBannedWord)
+	g.DELETE("/banned-words/:id", moderationController.DeleteBannedWord)
+}
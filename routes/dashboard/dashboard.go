@@ -1,12 +1,16 @@
 package dashboard
 
 import (
+	"context"
 	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/middlewares"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
+	"time"
 
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
@@ -21,7 +25,12 @@ func InitProductDashboard(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	productDashboardUseCase := usecases.NewProductDashboardUseCase(productDashboardRepository, cartUseCase)
 	productDashboardController := controllers.NewProductDashboardController(productDashboardUseCase, v)
 
-	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsAdminOrSuperAdmin)
+	// Write the view counts buffered in Redis to the database
+	engagementUseCase := usecases.NewEngagementUseCase(repositories.NewEngagementRepository(db), *redis.NewRedisClient())
+	go engagementUseCase.RunViewFlushing(context.Background(), time.Minute)
+
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionReportsView))
 
 	g.GET("/products-report", productDashboardController.GetReportProducts)
 	g.GET("/dashboard-header", productDashboardController.GetHeaderProduct)
@@ -1,13 +1,16 @@
 package event_transactions
 
 import (
+	"context"
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
+	"time"
 
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
@@ -19,13 +22,33 @@ func InitEventTransactionsRoute(g *echo.Group, db *gorm.DB, v *validation.Valida
 	config := config.InitConfigMidtrans()
 	redisClient := redis.NewRedisClient()
 
+	emailUtil := email.NewEmailUtil()
+
 	eventAdminRepository := repositories.NewEventAdminRepository(db)
 	eventTransactionRepo := repositories.NewEventTransactionRepository(db)
-	eventTransactionUseCase := usecases.NewEventTransactionUseCase(eventTransactionRepo, eventAdminRepository, *redisClient,config)
+	eventWaitlistRepo := repositories.NewEventWaitlistRepository(db)
+	eventWaitlistUseCase := usecases.NewEventWaitlistUseCase(eventWaitlistRepo, eventTransactionRepo, eventAdminRepository, emailUtil)
+	eventTicketRepo := repositories.NewEventTicketRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	eventTicketUseCase := usecases.NewEventTicketUseCase(eventTicketRepo, eventTransactionRepo, eventAdminRepository, userRepo, emailUtil)
+	eventTransactionUseCase := usecases.NewEventTransactionUseCase(eventTransactionRepo, eventAdminRepository, eventWaitlistUseCase, eventTicketUseCase, *redisClient, config)
 
 	eventTransactionController := controllers.NewEventTransactionController(eventTransactionUseCase, v, tokenUtil)
+	eventWaitlistController := controllers.NewEventWaitlistController(eventWaitlistUseCase, v, tokenUtil)
+	eventTicketController := controllers.NewEventTicketController(eventTicketUseCase, v, tokenUtil)
+
+	// Expire unused waitlist reservations and pass the seats on
+	go eventWaitlistUseCase.RunReservationExpiry(context.Background(), time.Minute)
 
 	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
 	g.POST("/event-transactions", eventTransactionController.CreateEventTransaction)
 	g.GET("/event-transactions/:id", eventTransactionController.GetEventTransactionById)
+	g.POST("/event-transactions/:id/cancel", eventTransactionController.CancelFreeTransaction)
+
+	g.GET("/event-waitlist", eventWaitlistController.GetMyWaitlist)
+	g.POST("/event-prices/:price_id/waitlist", eventWaitlistController.JoinWaitlist)
+	g.DELETE("/event-prices/:price_id/waitlist", eventWaitlistController.LeaveWaitlist)
+
+	g.GET("/event-tickets", eventTicketController.GetMyTickets)
+	g.POST("/event-tickets/:ticket_id/transfer", eventTicketController.TransferTicket)
 }
@@ -2,6 +2,7 @@ package events
 
 import (
 	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
 	"kreasi-nusantara-api/utils/token"
@@ -15,14 +16,24 @@ import (
 func InitEventsRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	eventRepo := repositories.NewEventRepository(db)
 	eventUseCase := usecases.NewEventUseCase(eventRepo)
-	eventController := controllers.NewEventController(eventUseCase, v)
+	tokenUtil := token.NewTokenUtil()
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+	engagementUseCase := usecases.NewEngagementUseCase(repositories.NewEngagementRepository(db), *redis.NewRedisClient())
+	eventController := controllers.NewEventController(eventUseCase, v, tokenUtil, slugUseCase, engagementUseCase)
 
 	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
 	g.GET("/events", eventController.GetEvents)
 	g.GET("/events/:event_id", eventController.GetEventByID)
+	g.GET("/events/slug/:slug", eventController.GetEventBySlug)
 	g.GET("/events/category/:category_id", eventController.GetEventsByCategory)
 	g.GET("/events/search", eventController.SearchEvents)
 	g.GET("/events/upcoming", eventController.GetUpcomingEvents)
+	g.GET("/events/nearby", eventController.GetNearbyEvents)
+	g.GET("/events/categories/ratings", eventController.GetCategoryRatings)
+
+	g.POST("/events/:event_id/reviews", eventController.CreateEventReview)
+	g.GET("/events/:event_id/reviews", eventController.GetEventReviews)
+	g.POST("/events/:event_id/share", eventController.ShareEvent)
 
 	g.GET("/events/calendar", eventController.GetEventByMonthYear)
 	g.GET("/events/calendar/date", eventController.GetEventByDate)
@@ -4,9 +4,11 @@ import (
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/cloudinary"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/middlewares"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
 
@@ -25,23 +27,44 @@ func InitEventsAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	wilayahUsecase := usecases.NewRegionUseCase(apiKey)
 	wilayahController := controllers.NewRegionController(wilayahUsecase)
 
+	emailUtil := email.NewEmailUtil()
+
 	eventAdminRepo := repositories.NewEventAdminRepository(db)
-	eventAdminUsecase := usecases.NewEventAdminUseCase(eventAdminRepo)
+	eventTransactionRepo := repositories.NewEventTransactionRepository(db)
+	eventWaitlistRepo := repositories.NewEventWaitlistRepository(db)
+	eventWaitlistUseCase := usecases.NewEventWaitlistUseCase(eventWaitlistRepo, eventTransactionRepo, eventAdminRepo, emailUtil)
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+	eventAdminUsecase := usecases.NewEventAdminUseCase(eventAdminRepo, eventWaitlistUseCase, slugUseCase)
 	eventAdminController := controllers.NewEventsAdminController(eventAdminUsecase, v, cloudinaryService)
 
-	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsAdminOrSuperAdmin)
+	eventTicketRepo := repositories.NewEventTicketRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	eventTicketUseCase := usecases.NewEventTicketUseCase(eventTicketRepo, eventTransactionRepo, eventAdminRepo, userRepo, emailUtil)
+	eventTicketController := controllers.NewEventTicketController(eventTicketUseCase, v, token.NewTokenUtil())
+
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionEventsManage))
 	g.GET("/events", eventAdminController.GetAllEvents)
 	g.POST("/events", eventAdminController.CreateEventsAdmin)
 	g.GET("/events/search", eventAdminController.SearchEventsAdmin)
 	g.GET("/events/:event_id", eventAdminController.GetEventByID)
 	g.PUT("/events/:event_id", eventAdminController.UpdateEventsAdmin)
 	g.DELETE("/events/:event_id", eventAdminController.DeleteEventsAdmin)
+	g.PUT("/events/:event_id/ticket-transfer", eventTicketController.UpdateTicketTransferSetting)
+	g.GET("/events/:event_id/ticket-transfers", eventTicketController.GetTicketTransfers)
+	g.GET("/event-tickets/:code", eventTicketController.VerifyTicket)
 
 	g.POST("/events/categories", eventAdminController.CreateCategoriesEvent)
 	g.GET("/events/categories", eventAdminController.GetCategoriesEvent)
 	g.PUT("/events/categories/:id", eventAdminController.UpdateCategoriesEvent)
 	g.DELETE("/events/categories/:id", eventAdminController.DeleteCategoriesEvent)
 
+	g.GET("/events/venues", eventAdminController.GetVenues)
+	g.POST("/events/venues", eventAdminController.CreateVenue)
+	g.PUT("/events/venues/:venue_id", eventAdminController.UpdateVenue)
+	g.DELETE("/events/venues/:venue_id", eventAdminController.DeleteVenue)
+	g.POST("/events/postal-codes", eventAdminController.UpsertPostalCodeCentroids)
+
 	g.GET("/events/provinces", wilayahController.GetProvincesHandler)
 	g.GET("/events/districts", wilayahController.GetDistrictsHandler)
 	g.GET("/events/subdistricts", wilayahController.GetSubdistrictsHandler)
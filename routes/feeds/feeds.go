@@ -0,0 +1,22 @@
+package feeds
+
+import (
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitFeedsRoute registers the article feeds. They are public so feed readers
+// can subscribe without a token.
+func InitFeedsRoute(g *echo.Group, db *gorm.DB) {
+	feedUseCase := usecases.NewFeedUseCase(repositories.NewFeedRepository(db), repositories.NewTagRepository(db))
+	feedController := controllers.NewFeedController(feedUseCase)
+
+	g.GET("/articles/feed.rss", feedController.GetArticlesRSS)
+	g.GET("/articles/feed.atom", feedController.GetArticlesAtom)
+	g.GET("/articles/tags/:slug/feed.rss", feedController.GetTagRSS)
+	g.GET("/articles/tags/:slug/feed.atom", feedController.GetTagAtom)
+}
@@ -22,13 +22,18 @@ func InitProductsRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	redisClient := redis.NewRedisClient()
 
 	productRepo := repositories.NewProductRepository(db)
-	productUseCase := usecases.NewProductUseCase(productRepo)
+	tagUseCase := usecases.NewTagUseCase(repositories.NewTagRepository(db), productRepo)
+	productUseCase := usecases.NewProductUseCase(productRepo, tagUseCase)
 
 	tokenUtil := token.NewTokenUtil()
 
 	cartRepo := repositories.NewCartRepository(db)
 
-	productController := controllers.NewProductController(productUseCase, v, tokenUtil)
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+
+	engagementUseCase := usecases.NewEngagementUseCase(repositories.NewEngagementRepository(db), *redisClient)
+
+	productController := controllers.NewProductController(productUseCase, v, tokenUtil, slugUseCase, engagementUseCase)
 
 	recommendationUseCase := usecases.NewRecommendationUseCase(oaiService, *redisClient, productRepo, cartRepo)
 	recommendationController := controllers.NewRecommendationController(recommendationUseCase, tokenUtil)
@@ -36,10 +41,12 @@ func InitProductsRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
 	g.GET("/products", productController.GetProducts)
 	g.GET("/products/:product_id", productController.GetProductByID)
+	g.GET("/products/slug/:slug", productController.GetProductBySlug)
 	g.GET("/products/search", productController.SearchProducts)
 	g.GET("/products/category/:category_id", productController.GetProductsByCategory)
 
 	g.POST("/products/:product_id/reviews", productController.CreateProductReview)
 	g.GET("/products/:product_id/reviews", productController.GetProductReviews)
+	g.POST("/products/:product_id/share", productController.ShareProduct)
 	g.GET("/products/recommendation", recommendationController.GetProductRecommendation)
 }
\ No newline at end of file
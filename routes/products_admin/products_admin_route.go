@@ -4,6 +4,7 @@ import (
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/cloudinary"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/middlewares"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
@@ -23,12 +24,15 @@ func InitProductAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator)
 	productRepo := repositories.NewProductRepository(db)
 
 	productAdminRepository := repositories.NewProductAdminRepository(db)
-	productAdminUsecase := usecases.NewProductAdminUseCase(productAdminRepository, tokenUtil, productRepo)
+	tagUseCase := usecases.NewTagUseCase(repositories.NewTagRepository(db), productRepo)
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+	productAdminUsecase := usecases.NewProductAdminUseCase(productAdminRepository, tokenUtil, productRepo, tagUseCase, slugUseCase)
 	productAdminController := controllers.NewProductsAdminController(productAdminUsecase, v, cloudinaryService)
 	// g.DELETE("/products/:id", productAdminController.DeleteProduct)
 	// g.PUT("/products/:id", productAdminController.UpdateProduct)
 
-	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.IsAdminOrSuperAdmin)
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionCatalogManage))
 	g.GET("/categories", productAdminController.GetAllCategories)
 	g.POST("/categories", productAdminController.CreateCategory)
 	g.DELETE("/categories/:id", productAdminController.DeleteCategory)
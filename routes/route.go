@@ -4,13 +4,19 @@ import (
 	"kreasi-nusantara-api/routes/admin"
 	"kreasi-nusantara-api/routes/articles"
 	"kreasi-nusantara-api/routes/articles_admin"
+	"kreasi-nusantara-api/routes/auth"
 	"kreasi-nusantara-api/routes/cart"
+	"kreasi-nusantara-api/routes/comments_admin"
 	"kreasi-nusantara-api/routes/event_transactions"
 	"kreasi-nusantara-api/routes/events"
 	"kreasi-nusantara-api/routes/events_admin"
+	"kreasi-nusantara-api/routes/feeds"
 	"kreasi-nusantara-api/routes/product_transactions"
 	"kreasi-nusantara-api/routes/products"
 	"kreasi-nusantara-api/routes/products_admin"
+	"kreasi-nusantara-api/routes/seo"
+	"kreasi-nusantara-api/routes/tags"
+	"kreasi-nusantara-api/routes/tags_admin"
 	"kreasi-nusantara-api/routes/user"
 	"kreasi-nusantara-api/routes/webhook"
 	"kreasi-nusantara-api/utils/validation"
@@ -36,6 +42,16 @@ func InitRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
 	eventTransactionRoute := baseRoute.Group("")
 	paymentNotifRoute := baseRoute.Group("")
 	productDashboardRoute := baseRoute.Group("/admin")
+	tagsRoute := baseRoute.Group("")
+	tagsAdminRoute := baseRoute.Group("/admin")
+	seoRoute := e.Group("")
+	commentsAdminRoute := baseRoute.Group("/admin")
+	feedsRoute := baseRoute.Group("")
+	authRoute := baseRoute.Group("")
+	socialLoginRoute := baseRoute.Group("")
+	twoFactorRoute := baseRoute.Group("")
+	adminInvitationRoute := baseRoute.Group("")
+	adminRoleRoute := baseRoute.Group("")
 
 	user.InitUserRoute(userRoute, db, v)
 	user.InitUserAddressesRoute(userRoute, db, v)
@@ -52,4 +68,14 @@ func InitRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
 	event_transactions.InitEventTransactionsRoute(eventTransactionRoute, db, v)
 	webhook.InitWebhookRoute(paymentNotifRoute, db)
 	dashboard.InitProductDashboard(productDashboardRoute, db, v)
+	tags.InitTagsRoute(tagsRoute, db, v)
+	tags_admin.InitTagsAdminRoute(tagsAdminRoute, db, v)
+	seo.InitSEORoute(seoRoute, db)
+	comments_admin.InitCommentsAdminRoute(commentsAdminRoute, db, v)
+	feeds.InitFeedsRoute(feedsRoute, db)
+	auth.InitAuthRoute(authRoute, db, v)
+	auth.InitSocialLoginRoute(socialLoginRoute, db, v)
+	auth.InitTwoFactorRoute(twoFactorRoute, db, v)
+	admin.InitAdminInvitationRoute(adminInvitationRoute, db, v)
+	admin.InitAdminRoleRoute(adminRoleRoute, db, v)
 }
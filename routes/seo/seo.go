@@ -0,0 +1,26 @@
+package seo
+
+import (
+	"context"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func InitSEORoute(g *echo.Group, db *gorm.DB) {
+	slugUseCase := usecases.NewSlugUseCase(repositories.NewSlugRepository(db))
+	slugController := controllers.NewSlugController(slugUseCase)
+
+	// Give products, articles and events created before slugs existed a slug
+	go func() {
+		if err := slugUseCase.BackfillSlugs(context.Background()); err != nil {
+			logrus.WithError(err).Error("Failed to backfill slugs")
+		}
+	}()
+
+	g.GET("/sitemap.xml", slugController.GetSitemap)
+}
@@ -0,0 +1,24 @@
+package tags
+
+import (
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func InitTagsRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tagRepo := repositories.NewTagRepository(db)
+	productRepo := repositories.NewProductRepository(db)
+	tagUseCase := usecases.NewTagUseCase(tagRepo, productRepo)
+	tagController := controllers.NewTagController(tagUseCase, v)
+
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	g.GET("/tags", tagController.SearchTags)
+	g.GET("/tags/:slug", tagController.GetTagBySlug)
+}
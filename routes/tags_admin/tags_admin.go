@@ -0,0 +1,39 @@
+package tags_admin
+
+import (
+	"context"
+	"kreasi-nusantara-api/controllers"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/middlewares"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/token"
+	"kreasi-nusantara-api/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func InitTagsAdminRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
+	tagRepo := repositories.NewTagRepository(db)
+	productRepo := repositories.NewProductRepository(db)
+	tagUseCase := usecases.NewTagUseCase(tagRepo, productRepo)
+	tagController := controllers.NewTagController(tagUseCase, v)
+
+	// Link articles created before the tag table existed to their tags
+	go func() {
+		if err := tagUseCase.BackfillArticleTags(context.Background()); err != nil {
+			logrus.WithError(err).Error("Failed to backfill article tags")
+		}
+	}()
+
+	roleUseCase := usecases.NewAdminRoleUseCase(repositories.NewAdminRoleRepository(db), repositories.NewAdminRepository(db))
+	g.Use(echojwt.WithConfig(token.GetJWTConfig()), middlewares.RequirePermission(roleUseCase, entities.PermissionContentManage))
+	g.GET("/tags", tagController.GetTags)
+	g.POST("/tags", tagController.CreateTag)
+	g.POST("/tags/merge", tagController.MergeTags)
+	g.PUT("/tags/:id", tagController.RenameTag)
+	g.DELETE("/tags/:id", tagController.DeleteTag)
+}
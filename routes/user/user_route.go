@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/controllers"
 	"kreasi-nusantara-api/drivers/redis"
@@ -11,8 +12,10 @@ import (
 	"kreasi-nusantara-api/utils/email"
 	"kreasi-nusantara-api/utils/otp"
 	"kreasi-nusantara-api/utils/password"
+	"kreasi-nusantara-api/utils/sms"
 	"kreasi-nusantara-api/utils/token"
 	"kreasi-nusantara-api/utils/validation"
+	"time"
 
 	cs "kreasi-nusantara-api/drivers/cloudinary"
 	echojwt "github.com/labstack/echo-jwt/v4"
@@ -31,12 +34,18 @@ func InitUserRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	emailUtil := email.NewEmailUtil()
 	tokenUtil := token.NewTokenUtil()
 
-	userUseCase := usecases.NewUserUseCase(userRepo, passwordUtil, *redisClient, cloudinaryService, otpUtil, emailUtil, tokenUtil)
+	authUseCase := usecases.NewAuthUseCase(repositories.NewAuthRepository(db), tokenUtil, *redisClient, emailUtil)
+	twoFactorUseCase := usecases.NewTwoFactorUseCase(repositories.NewTwoFactorRepository(db), userRepo, repositories.NewAdminRepository(db), *redisClient, authUseCase)
+
+	userUseCase := usecases.NewUserUseCase(userRepo, repositories.NewUserDataRepository(db), passwordUtil, *redisClient, cloudinaryService, otpUtil, emailUtil, sms.NewLogSMSUtil(), tokenUtil, authUseCase, twoFactorUseCase)
 	userController := controllers.NewUserController(userUseCase, v, tokenUtil)
 
+	go userUseCase.RunUnverifiedCleanup(context.Background(), time.Hour)
+
 	// Public routes
 	g.POST("/register", userController.Register)
 	g.POST("/verify-otp", userController.VerifyOTP)
+	g.POST("/resend-otp", userController.ResendOTP)
 	g.POST("/login", userController.Login)
 	g.POST("/forgot-password", userController.ForgotPassword)
 	g.POST("/reset-password", userController.ResetPassword)
@@ -46,7 +55,12 @@ func InitUserRoute(g *echo.Group, db *gorm.DB, v *validation.Validator) {
 	g.GET("/users/me", userController.GetProfile)
 	g.PUT("/users/me", userController.UpdateProfile)
 	g.DELETE("/users/me", userController.DeleteProfile)
+	g.GET("/users/me/export", userController.ExportData)
 	g.PUT("/users/me/password", userController.ChangePassword)
+	g.POST("/users/me/email", userController.RequestEmailChange)
+	g.POST("/users/me/email/confirm", userController.ConfirmEmailChange)
+	g.POST("/users/me/phone/send-otp", userController.SendPhoneOTP)
+	g.POST("/users/me/phone/verify", userController.VerifyPhone)
 	g.POST("/users/me/avatar", userController.UploadPhoto)
 	g.DELETE("/users/me/avatar", userController.DeletePhoto)
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/usecases"
+	"kreasi-nusantara-api/utils/email"
 
 	// "kreasi-nusantara-api/utils/token"
 
@@ -16,8 +17,18 @@ import (
 func InitWebhookRoute(g *echo.Group, db *gorm.DB) {
 	redisClient := redis.NewRedisClient()
 
+	emailUtil := email.NewEmailUtil()
+
+	eventAdminRepo := repositories.NewEventAdminRepository(db)
+	eventTransactionRepo := repositories.NewEventTransactionRepository(db)
+	eventWaitlistRepo := repositories.NewEventWaitlistRepository(db)
+	eventWaitlistUseCase := usecases.NewEventWaitlistUseCase(eventWaitlistRepo, eventTransactionRepo, eventAdminRepo, emailUtil)
+	eventTicketRepo := repositories.NewEventTicketRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	eventTicketUseCase := usecases.NewEventTicketUseCase(eventTicketRepo, eventTransactionRepo, eventAdminRepo, userRepo, emailUtil)
+
 	webhookRepo := repositories.NewWebhookRepository(db)
-	webhookUsecase := usecases.NewWebhookUsecase(webhookRepo, *redisClient)
+	webhookUsecase := usecases.NewWebhookUsecase(webhookRepo, *redisClient, eventWaitlistUseCase, eventTicketUseCase)
 	webhookController := controllers.NewWebhookController(webhookUsecase)
 
 	// g.Use(echojwt.WithConfig(token.GetJWTConfig()))
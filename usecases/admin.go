@@ -18,10 +18,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type AdminUsecase interface {
-	Register(c echo.Context, req *dto.RegisterRequest) error
 	Login(c echo.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
 	GetAllAdmin(c echo.Context, req *dto_base.PaginationRequest) (*[]dto.AdminResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
 	UpdateAdmin(ctx echo.Context, adminID uuid.UUID, req *dto.UpdateAdminRequest) error
@@ -29,6 +29,8 @@ type AdminUsecase interface {
 	SearchAdminByUsername(c echo.Context, req *dto_base.SearchRequest) ([]dto.AdminResponse, *dto_base.MetadataResponse, error)
 	GetAdminByID(c echo.Context, adminID uuid.UUID) (*dto.AdminResponse, error)
 	GetAdminAvatar(c echo.Context, adminID uuid.UUID) (*dto.AdminAvatarResponse, error)
+	DeactivateAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error
+	ActivateAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error
 }
 
 type adminUsecase struct {
@@ -36,97 +38,77 @@ type adminUsecase struct {
 	passwordUtil      password.PasswordUtil
 	cloudinaryService cloudinary.CloudinaryService
 	tokenUtil         token.TokenUtil
+	authUseCase       AuthUseCase
+	twoFactorUseCase  TwoFactorUseCase
 }
 
-func NewAdminUsecase(adminRepo repositories.AdminRepository, passwordUtil password.PasswordUtil, cloudinaryService cloudinary.CloudinaryService, tokenUtil token.TokenUtil) *adminUsecase {
+func NewAdminUsecase(adminRepo repositories.AdminRepository, passwordUtil password.PasswordUtil, cloudinaryService cloudinary.CloudinaryService, tokenUtil token.TokenUtil, authUseCase AuthUseCase, twoFactorUseCase TwoFactorUseCase) *adminUsecase {
 	return &adminUsecase{
 		adminRepo:         adminRepo,
 		passwordUtil:      passwordUtil,
 		cloudinaryService: cloudinaryService,
 		tokenUtil:         tokenUtil,
+		authUseCase:       authUseCase,
+		twoFactorUseCase:  twoFactorUseCase,
 	}
 }
 
-func (au *adminUsecase) Register(c echo.Context, req *dto.RegisterRequest) error {
+func (au *adminUsecase) Login(c echo.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
-	formHeader, err := c.FormFile("image")
-	if err != nil {
-		fmt.Println("error getting form file")
-		return err
-	}
-	formFile, err := formHeader.Open()
-	if err != nil {
-		fmt.Println("error opening form file")
-		return err
+	if err := au.authUseCase.CheckAttempts(c, AttemptAdminLogin, req.Email); err != nil {
+		return nil, err
 	}
-	defer formFile.Close()
 
-	imageURL, err := au.cloudinaryService.UploadImage(ctx, formFile, "kreasinusantara/admin-profile")
+	admin, err := au.adminRepo.GetAdmin(ctx, &entities.Admin{Email: req.Email})
 	if err != nil {
-		return err
+		au.authUseCase.CountAttempt(c, AttemptAdminLogin, req.Email)
+		return nil, err
 	}
-
-	hashedPassword, err := au.passwordUtil.HashPassword(req.Password)
-	if err != nil {
-		return err
+	if err := au.passwordUtil.VerifyPassword(req.Password, admin.Password); err != nil {
+		au.authUseCase.CountAttempt(c, AttemptAdminLogin, req.Email)
+		return nil, err
 	}
+	au.authUseCase.ClearAttempts(AttemptAdminLogin, req.Email)
 
-	imageURLPtr := &imageURL
-
-	admin := &entities.Admin{
-		ID:           uuid.New(),
-		Photo:        imageURLPtr,
-		FirstName:    req.FirstName,
-		LastName:     req.LastName,
-		Username:     req.Username,
-		Email:        req.Email,
-		Password:     hashedPassword,
-		IsSuperAdmin: req.IsSuperAdmin,
+	if !admin.IsActive {
+		return nil, err_util.ErrAdminDeactivated
 	}
 
-	err = au.adminRepo.CreateAdmin(ctx, admin)
-	if err != nil {
-		return err
+	role := "admin"
+	if admin.IsSuperAdmin {
+		role = "super_admin"
 	}
 
-	return nil
-}
-
-func (au *adminUsecase) Login(c echo.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
-
-	ctx, cancel := context.WithCancel(c.Request().Context())
-	defer cancel()
-
-	admin, err := au.adminRepo.GetAdmin(ctx, &entities.Admin{Email: req.Email})
+	challenge, err := au.twoFactorUseCase.Challenge(c, admin.ID, entities.AccountTypeAdmin, role, admin.Email)
 	if err != nil {
 		return nil, err
 	}
-	if err := au.passwordUtil.VerifyPassword(req.Password, admin.Password); err != nil {
-		return nil, err
-	}
-
-	var token string
-
-	admin.Token = token
-
-	if admin.IsSuperAdmin {
-		token, err = au.tokenUtil.GenerateToken(admin.ID, "super_admin")
-	} else {
-		token, err = au.tokenUtil.GenerateToken(admin.ID, "admin")
+	if challenge != nil {
+		return &dto.LoginResponse{
+			Username:               admin.Username,
+			Email:                  admin.Email,
+			TwoFactorRequired:      true,
+			TwoFactorSetupRequired: challenge.SetupRequired,
+			ChallengeToken:         challenge.ChallengeToken,
+		}, nil
 	}
 
+	tokens, err := au.authUseCase.IssueTokens(c, admin.ID, role, admin.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	admin.Token = token
+	admin.Token = tokens.Token
 
 	return &dto.LoginResponse{
-		Username: admin.Username,
-		Email:    admin.Email,
-		Token:    token,
+		Username:     admin.Username,
+		Email:        admin.Email,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 	}, nil
 }
 
@@ -166,6 +148,7 @@ func (au *adminUsecase) GetAllAdmin(c echo.Context, req *dto_base.PaginationRequ
 			Username:     admin.Username,
 			Email:        admin.Email,
 			IsSuperAdmin: admin.IsSuperAdmin,
+			IsActive:     admin.IsActive,
 			Photo:        admin.Photo,
 			CreatedAt:    createdAtStr,
 		}
@@ -220,6 +203,7 @@ func (au *adminUsecase) SearchAdminByUsername(c echo.Context, req *dto_base.Sear
 			Username:     admin.Username,
 			Email:        admin.Email,
 			IsSuperAdmin: admin.IsSuperAdmin,
+			IsActive:     admin.IsActive,
 			Photo:        admin.Photo,
 			CreatedAt:    createdAtStr,
 		}
@@ -284,8 +268,14 @@ func (au *adminUsecase) UpdateAdmin(ctx echo.Context, adminID uuid.UUID, req *dt
 	if req.Email != "" {
 		admin.Email = req.Email
 	}
+	// A new password or role ends the admin's sessions
+	revokeSessions := req.Password != "" || admin.IsSuperAdmin != req.IsSuperAdmin
 	if req.Password != "" {
-		admin.Password = req.Password
+		hashedPassword, err := au.passwordUtil.HashPassword(req.Password)
+		if err != nil {
+			return err
+		}
+		admin.Password = hashedPassword
 	}
 	admin.IsSuperAdmin = req.IsSuperAdmin
 
@@ -294,6 +284,10 @@ func (au *adminUsecase) UpdateAdmin(ctx echo.Context, adminID uuid.UUID, req *dt
 		return err
 	}
 
+	if revokeSessions {
+		return au.authUseCase.RevokeAllSessions(ctx.Request().Context(), adminID)
+	}
+
 	return nil
 }
 
@@ -303,7 +297,7 @@ func (au *adminUsecase) DeleteAdmin(ctx echo.Context, adminID uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return au.authUseCase.RevokeAllSessions(ctx.Request().Context(), adminID)
 }
 
 func (au *adminUsecase) GetAdminAvatar(c echo.Context, adminID uuid.UUID) (*dto.AdminAvatarResponse, error) {
@@ -345,9 +339,63 @@ func (au *adminUsecase) GetAdminByID(c echo.Context, adminID uuid.UUID) (*dto.Ad
 		Username:     admins.Username,
 		Email:        admins.Email,
 		IsSuperAdmin: admins.IsSuperAdmin,
+		IsActive:     admins.IsActive,
 		Photo:        admins.Photo,
 		CreatedAt:    createdAtStr,
 	}
 
 	return adminResponse, nil
 }
+
+// DeactivateAdmin keeps the admin and their content but logs them out and
+// stops them from logging in until they are activated again
+func (au *adminUsecase) DeactivateAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if adminID == superAdminID {
+		return err_util.ErrCannotDeactivateSelf
+	}
+
+	admin, err := au.setActive(ctx, adminID, false)
+	if err != nil {
+		return err
+	}
+
+	au.authUseCase.RecordSecurityEvent(c, entities.SecurityEventAdminDeactivated, AttemptAdminLogin, admin.Email,
+		fmt.Sprintf("admin deactivated by super admin %s", superAdminID))
+
+	return au.authUseCase.RevokeAllSessions(ctx, adminID)
+}
+
+func (au *adminUsecase) ActivateAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	admin, err := au.setActive(ctx, adminID, true)
+	if err != nil {
+		return err
+	}
+
+	au.authUseCase.RecordSecurityEvent(c, entities.SecurityEventAdminActivated, AttemptAdminLogin, admin.Email,
+		fmt.Sprintf("admin activated by super admin %s", superAdminID))
+	return nil
+}
+
+func (au *adminUsecase) setActive(ctx context.Context, adminID uuid.UUID, active bool) (*entities.Admin, error) {
+	admin, err := au.adminRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, err_util.ErrNotFound
+	}
+
+	if err := au.adminRepo.SetActive(ctx, adminID, active); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+	return admin, nil
+}
@@ -0,0 +1,288 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/drivers/cloudinary"
+	dto "kreasi-nusantara-api/dto/admin"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/email"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/password"
+	"kreasi-nusantara-api/utils/token"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// adminInvitationTTL is how long an invite link can be used
+const adminInvitationTTL = 72 * time.Hour
+
+type AdminInvitationUseCase interface {
+	InviteAdmin(c echo.Context, inviterID uuid.UUID, req *dto.InviteAdminRequest) (*dto.InvitationResponse, error)
+	GetPendingInvitations(c echo.Context) ([]dto.InvitationResponse, error)
+	RevokeInvitation(c echo.Context, id uuid.UUID) error
+	AcceptInvitation(c echo.Context, req *dto.AcceptInvitationRequest) error
+	InviteFirstSuperAdmin(ctx context.Context)
+}
+
+type adminInvitationUseCase struct {
+	invitationRepo    repositories.AdminInvitationRepository
+	adminRepo         repositories.AdminRepository
+	passwordUtil      password.PasswordUtil
+	cloudinaryService cloudinary.CloudinaryService
+	tokenUtil         token.TokenUtil
+	emailUtil         email.EmailUtil
+}
+
+func NewAdminInvitationUseCase(
+	invitationRepo repositories.AdminInvitationRepository,
+	adminRepo repositories.AdminRepository,
+	passwordUtil password.PasswordUtil,
+	cloudinaryService cloudinary.CloudinaryService,
+	tokenUtil token.TokenUtil,
+	emailUtil email.EmailUtil,
+) *adminInvitationUseCase {
+	return &adminInvitationUseCase{
+		invitationRepo:    invitationRepo,
+		adminRepo:         adminRepo,
+		passwordUtil:      passwordUtil,
+		cloudinaryService: cloudinaryService,
+		tokenUtil:         tokenUtil,
+		emailUtil:         emailUtil,
+	}
+}
+
+// InviteAdmin emails a signed invite link to a new admin. Inviting the same
+// email again replaces the earlier invitation.
+func (au *adminInvitationUseCase) InviteAdmin(c echo.Context, inviterID uuid.UUID, req *dto.InviteAdminRequest) (*dto.InvitationResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	invitation := &entities.AdminInvitations{
+		ID:           uuid.New(),
+		Email:        req.Email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		IsSuperAdmin: req.IsSuperAdmin,
+		InvitedBy:    &inviterID,
+		ExpiresAt:    time.Now().Add(adminInvitationTTL),
+	}
+	if err := au.createInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	response := toInvitationResponse(invitation)
+	return &response, nil
+}
+
+// InviteFirstSuperAdmin invites SUPER_ADMIN_EMAIL as super admin while there
+// is none, so a fresh installation can be set up without public registration
+func (au *adminInvitationUseCase) InviteFirstSuperAdmin(ctx context.Context) {
+	superAdminEmail := os.Getenv("SUPER_ADMIN_EMAIL")
+	if superAdminEmail == "" {
+		return
+	}
+	log := logrus.WithField("email", superAdminEmail)
+
+	exists, err := au.adminRepo.HasActiveSuperAdmin(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to check for a super admin")
+		return
+	}
+	if exists {
+		return
+	}
+
+	pending, err := au.invitationRepo.HasPendingInvitation(ctx, superAdminEmail)
+	if err != nil {
+		log.WithError(err).Error("Failed to check for a super admin invitation")
+		return
+	}
+	if pending {
+		return
+	}
+
+	invitation := &entities.AdminInvitations{
+		ID:           uuid.New(),
+		Email:        superAdminEmail,
+		FirstName:    "Super",
+		LastName:     "Admin",
+		IsSuperAdmin: true,
+		ExpiresAt:    time.Now().Add(adminInvitationTTL),
+	}
+	if err := au.createInvitation(ctx, invitation); err != nil {
+		log.WithError(err).Error("Failed to invite the first super admin")
+		return
+	}
+	log.Info("Invited the first super admin")
+}
+
+func (au *adminInvitationUseCase) createInvitation(ctx context.Context, invitation *entities.AdminInvitations) error {
+	exists, err := au.adminRepo.EmailExists(ctx, invitation.Email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return err_util.ErrAdminEmailExists
+	}
+
+	inviteToken, err := au.tokenUtil.GenerateInviteToken(invitation.ID, invitation.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if err := au.invitationRepo.CreateInvitation(ctx, invitation); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/admin/invitations/accept?token=%s", os.Getenv("FRONTEND_URL"), url.QueryEscape(inviteToken))
+	body := fmt.Sprintf(
+		"Hi %s,\n\nYou have been invited to become an admin of Kreasi Nusantara. Choose your username and password before %s:\n%s\n\nIf you did not expect this invitation, you can ignore this email.",
+		invitation.FirstName,
+		invitation.ExpiresAt.Format("02-01-2006 15:04"),
+		link,
+	)
+	return au.emailUtil.SendEmail(invitation.Email, "Kreasi Nusantara Admin Invitation", body)
+}
+
+func (au *adminInvitationUseCase) GetPendingInvitations(c echo.Context) ([]dto.InvitationResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	invitations, err := au.invitationRepo.GetPendingInvitations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = toInvitationResponse(&invitation)
+	}
+	return responses, nil
+}
+
+func (au *adminInvitationUseCase) RevokeInvitation(c echo.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := au.invitationRepo.RevokeInvitation(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation creates the admin of an invite link with the username and
+// password the new admin chose
+func (au *adminInvitationUseCase) AcceptInvitation(c echo.Context, req *dto.AcceptInvitationRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	invitationID, err := au.tokenUtil.ParseInviteToken(req.Token)
+	if err != nil {
+		return err_util.ErrInvalidInvitation
+	}
+
+	invitation, err := au.invitationRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrInvalidInvitation
+		}
+		return err
+	}
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return err_util.ErrInvalidInvitation
+	}
+
+	emailExists, err := au.adminRepo.EmailExists(ctx, invitation.Email)
+	if err != nil {
+		return err
+	}
+	if emailExists {
+		return err_util.ErrAdminEmailExists
+	}
+
+	usernameExists, err := au.adminRepo.UsernameExists(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if usernameExists {
+		return err_util.ErrAdminUsernameExists
+	}
+
+	hashedPassword, err := au.passwordUtil.HashPassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	photo, err := au.uploadPhoto(c)
+	if err != nil {
+		return err
+	}
+
+	admin := &entities.Admin{
+		ID:           uuid.New(),
+		Username:     req.Username,
+		FirstName:    invitation.FirstName,
+		LastName:     invitation.LastName,
+		Email:        invitation.Email,
+		Password:     hashedPassword,
+		Photo:        &photo,
+		IsSuperAdmin: invitation.IsSuperAdmin,
+		IsActive:     true,
+	}
+	if err := au.invitationRepo.AcceptInvitation(ctx, invitation.ID, admin); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrInvalidInvitation
+		}
+		return err
+	}
+	return nil
+}
+
+// uploadPhoto uploads the optional profile photo of a new admin
+func (au *adminInvitationUseCase) uploadPhoto(c echo.Context) (string, error) {
+	formHeader, err := c.FormFile("image")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	formFile, err := formHeader.Open()
+	if err != nil {
+		return "", err
+	}
+	defer formFile.Close()
+
+	return au.cloudinaryService.UploadImage(c.Request().Context(), formFile, "kreasinusantara/admin-profile")
+}
+
+func toInvitationResponse(invitation *entities.AdminInvitations) dto.InvitationResponse {
+	response := dto.InvitationResponse{
+		ID:           invitation.ID.String(),
+		FirstName:    invitation.FirstName,
+		LastName:     invitation.LastName,
+		Email:        invitation.Email,
+		IsSuperAdmin: invitation.IsSuperAdmin,
+		ExpiresAt:    invitation.ExpiresAt,
+		CreatedAt:    invitation.CreatedAt,
+	}
+	if invitation.InvitedBy != nil {
+		invitedBy := invitation.InvitedBy.String()
+		response.InvitedBy = &invitedBy
+	}
+	return response
+}
@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	dto "kreasi-nusantara-api/dto/admin"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// defaultAdminRoles are created on the first start with roles, see SeedRoles
+var defaultAdminRoles = []struct {
+	name        string
+	description string
+	permissions []string
+}{
+	{"catalog_editor", "Manages the product catalog", []string{entities.PermissionCatalogManage}},
+	{"event_manager", "Manages events and their tickets", []string{entities.PermissionEventsManage}},
+	{"content_writer", "Writes articles and moderates comments", []string{entities.PermissionContentManage, entities.PermissionCommentsModerate}},
+	{"finance_viewer", "Views sales reports", []string{entities.PermissionReportsView}},
+}
+
+type AdminRoleUseCase interface {
+	GetPermissions(c echo.Context) []dto.PermissionResponse
+	GetRoles(c echo.Context) ([]dto.RoleResponse, error)
+	CreateRole(c echo.Context, req *dto.RoleRequest) (*dto.RoleResponse, error)
+	UpdateRole(c echo.Context, id uuid.UUID, req *dto.RoleRequest) (*dto.RoleResponse, error)
+	DeleteRole(c echo.Context, id uuid.UUID) error
+	GetAdminRoles(c echo.Context, adminID uuid.UUID) ([]dto.RoleResponse, error)
+	SetAdminRoles(c echo.Context, adminID uuid.UUID, req *dto.AssignRolesRequest) ([]dto.RoleResponse, error)
+	GetMyPermissions(c echo.Context, adminID uuid.UUID, role string) (*dto.AdminPermissionsResponse, error)
+	HasPermission(c echo.Context, adminID uuid.UUID, permission string) (bool, error)
+	SeedDefaultRoles(ctx context.Context)
+}
+
+type adminRoleUseCase struct {
+	roleRepo  repositories.AdminRoleRepository
+	adminRepo repositories.AdminRepository
+}
+
+func NewAdminRoleUseCase(roleRepo repositories.AdminRoleRepository, adminRepo repositories.AdminRepository) *adminRoleUseCase {
+	return &adminRoleUseCase{
+		roleRepo:  roleRepo,
+		adminRepo: adminRepo,
+	}
+}
+
+func (ru *adminRoleUseCase) GetPermissions(c echo.Context) []dto.PermissionResponse {
+	permissions := make([]dto.PermissionResponse, 0, len(entities.Permissions))
+	for permission, description := range entities.Permissions {
+		permissions = append(permissions, dto.PermissionResponse{
+			Permission:  permission,
+			Description: description,
+		})
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i].Permission < permissions[j].Permission
+	})
+	return permissions
+}
+
+func (ru *adminRoleUseCase) GetRoles(c echo.Context) ([]dto.RoleResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	roles, err := ru.roleRepo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleResponses(roles), nil
+}
+
+func (ru *adminRoleUseCase) CreateRole(c echo.Context, req *dto.RoleRequest) (*dto.RoleResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	role := &entities.AdminRoles{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := ru.prepareRole(ctx, role, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := ru.roleRepo.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
+
+func (ru *adminRoleUseCase) UpdateRole(c echo.Context, id uuid.UUID, req *dto.RoleRequest) (*dto.RoleResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	role, err := ru.roleRepo.GetRoleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	role.Name = req.Name
+	role.Description = req.Description
+	if err := ru.prepareRole(ctx, role, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if err := ru.roleRepo.UpdateRole(ctx, role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
+
+// prepareRole checks the name and permissions of a role before it is saved
+func (ru *adminRoleUseCase) prepareRole(ctx context.Context, role *entities.AdminRoles, permissions []string) error {
+	exists, err := ru.roleRepo.RoleNameExists(ctx, role.Name, role.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return err_util.ErrRoleNameExists
+	}
+
+	role.Permissions = nil
+	granted := make(map[string]bool)
+	for _, permission := range permissions {
+		if _, ok := entities.Permissions[permission]; !ok {
+			return err_util.ErrUnknownPermission
+		}
+		if granted[permission] {
+			continue
+		}
+		granted[permission] = true
+		role.Permissions = append(role.Permissions, entities.AdminRolePermissions{
+			RoleID:     role.ID,
+			Permission: permission,
+		})
+	}
+	return nil
+}
+
+func (ru *adminRoleUseCase) DeleteRole(c echo.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := ru.roleRepo.DeleteRole(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (ru *adminRoleUseCase) GetAdminRoles(c echo.Context, adminID uuid.UUID) ([]dto.RoleResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := ru.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	roles, err := ru.roleRepo.GetAdminRoles(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleResponses(roles), nil
+}
+
+// SetAdminRoles replaces the roles of an admin, an empty list takes all of them away
+func (ru *adminRoleUseCase) SetAdminRoles(c echo.Context, adminID uuid.UUID, req *dto.AssignRolesRequest) ([]dto.RoleResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := ru.checkAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]uuid.UUID, 0, len(req.RoleIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, roleID := range req.RoleIDs {
+		if !seen[roleID] {
+			seen[roleID] = true
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+
+	if len(roleIDs) > 0 {
+		count, err := ru.roleRepo.CountRoles(ctx, roleIDs)
+		if err != nil {
+			return nil, err
+		}
+		if count != int64(len(roleIDs)) {
+			return nil, err_util.ErrRoleNotFound
+		}
+	}
+
+	if err := ru.roleRepo.SetAdminRoles(ctx, adminID, roleIDs); err != nil {
+		return nil, err
+	}
+
+	roles, err := ru.roleRepo.GetAdminRoles(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return toRoleResponses(roles), nil
+}
+
+func (ru *adminRoleUseCase) checkAdmin(ctx context.Context, adminID uuid.UUID) error {
+	admin, err := ru.adminRepo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return err_util.ErrNotFound
+	}
+	return nil
+}
+
+// GetMyPermissions lists what the logged in admin may do, so the admin panel
+// can hide what they have no access to
+func (ru *adminRoleUseCase) GetMyPermissions(c echo.Context, adminID uuid.UUID, role string) (*dto.AdminPermissionsResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	response := &dto.AdminPermissionsResponse{
+		IsSuperAdmin: role == "super_admin",
+		Permissions:  []string{},
+	}
+	if response.IsSuperAdmin {
+		for _, permission := range ru.GetPermissions(c) {
+			response.Permissions = append(response.Permissions, permission.Permission)
+		}
+		return response, nil
+	}
+
+	roles, err := ru.roleRepo.GetAdminRoles(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]bool)
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !granted[permission.Permission] {
+				granted[permission.Permission] = true
+				response.Permissions = append(response.Permissions, permission.Permission)
+			}
+		}
+	}
+	sort.Strings(response.Permissions)
+	return response, nil
+}
+
+func (ru *adminRoleUseCase) HasPermission(c echo.Context, adminID uuid.UUID, permission string) (bool, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return ru.roleRepo.HasPermission(ctx, adminID, permission)
+}
+
+// SeedDefaultRoles creates the default roles when there are none yet
+func (ru *adminRoleUseCase) SeedDefaultRoles(ctx context.Context) {
+	roles := make([]entities.AdminRoles, len(defaultAdminRoles))
+	for i, defaultRole := range defaultAdminRoles {
+		roles[i] = entities.AdminRoles{
+			ID:          uuid.New(),
+			Name:        defaultRole.name,
+			Description: defaultRole.description,
+		}
+		for _, permission := range defaultRole.permissions {
+			roles[i].Permissions = append(roles[i].Permissions, entities.AdminRolePermissions{
+				RoleID:     roles[i].ID,
+				Permission: permission,
+			})
+		}
+	}
+
+	if err := ru.roleRepo.SeedRoles(ctx, roles); err != nil {
+		logrus.WithError(err).Error("Failed to seed default admin roles")
+	}
+}
+
+func toRoleResponses(roles []entities.AdminRoles) []dto.RoleResponse {
+	responses := make([]dto.RoleResponse, len(roles))
+	for i := range roles {
+		responses[i] = toRoleResponse(&roles[i])
+	}
+	return responses
+}
+
+func toRoleResponse(role *entities.AdminRoles) dto.RoleResponse {
+	permissions := make([]string, len(role.Permissions))
+	for i, permission := range role.Permissions {
+		permissions[i] = permission.Permission
+	}
+	sort.Strings(permissions)
+
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+	}
+}
@@ -2,17 +2,23 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/email"
 	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/markdown"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type ArticleUseCase interface {
@@ -20,20 +26,39 @@ type ArticleUseCase interface {
 	GetArticleByID(c echo.Context, articleId uuid.UUID) (*dto.ArticleDetailResponse, error)
 	SearchArticles(c echo.Context, req *dto_base.SearchRequest) ([]dto.ArticleResponse, *dto_base.MetadataResponse, error)
 
-	GetCommentsByArticleID(c echo.Context, articleId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.ArticleCommentResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
+	GetCommentsByArticleID(c echo.Context, userId uuid.UUID, articleId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.ArticleCommentResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
+	GetReplies(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, cursor string, limit int) ([]dto.ArticleReplyResponse, *dto_base.CursorMetadata, error)
 	AddCommentToArticle(c echo.Context, userId uuid.UUID, articleId uuid.UUID, req *dto.ArticleCommentRequest) error
-	ReplyToComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleCommentRequest) error
+	ReplyToComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleReplyRequest) error
+	EditComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleCommentRequest) error
+	DeleteComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error
+	EditReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID, req *dto.ArticleCommentRequest) error
+	DeleteReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error
+	ReportComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.CommentReportRequest) error
+	ReportReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID, req *dto.CommentReportRequest) error
+	LikeComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error
+	UnlikeComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error
+	LikeReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error
+	UnlikeReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error
 	LikeArticle(c echo.Context, userId uuid.UUID, articleId uuid.UUID) error
 	UnlikeArticle(c echo.Context, userId uuid.UUID, articleId uuid.UUID) error
 }
 
 type articleUseCase struct {
 	articleRepository repositories.ArticleRepository
+	tagUseCase        TagUseCase
+	moderationUseCase CommentModerationUseCase
+	userRepository    repositories.UserRepository
+	emailUtil         email.EmailUtil
 }
 
-func NewArticleUseCase(articleRepository repositories.ArticleRepository) *articleUseCase {
+func NewArticleUseCase(articleRepository repositories.ArticleRepository, tagUseCase TagUseCase, moderationUseCase CommentModerationUseCase, userRepository repositories.UserRepository, emailUtil email.EmailUtil) *articleUseCase {
 	return &articleUseCase{
 		articleRepository: articleRepository,
+		tagUseCase:        tagUseCase,
+		moderationUseCase: moderationUseCase,
+		userRepository:    userRepository,
+		emailUtil:         emailUtil,
 	}
 }
 
@@ -60,10 +85,13 @@ func (auc *articleUseCase) GetArticles(c echo.Context, req *dto_base.PaginationR
 	articleResponse := make([]dto.ArticleResponse, len(articles))
 	for i, article := range articles {
 		articleResponse[i] = dto.ArticleResponse{
-			ID:        article.ID,
-			Image:     article.Image,
-			Title:     article.Title,
-			CreatedAt: article.CreatedAt,
+			ID:          article.ID,
+			Slug:        article.Slug,
+			Image:       article.Image,
+			Title:       article.Title,
+			Excerpt:     article.Excerpt,
+			ReadingTime: article.ReadingTime,
+			CreatedAt:   article.CreatedAt,
 		}
 	}
 
@@ -103,10 +131,33 @@ func (auc *articleUseCase) GetArticleByID(c echo.Context, articleId uuid.UUID) (
 		return nil, err
 	}
 
+	tags, err := auc.tagUseCase.GetArticleTags(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := auc.articleRepository.GetRelatedArticleCandidates(ctx, article.ID, relatedCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := auc.articleRepository.GetArticleProducts(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	document := markdown.Render(article.Content)
+
 	articleDetailResponse := &dto.ArticleDetailResponse{
 		ID:            article.ID,
+		Slug:          article.Slug,
+		CanonicalURL:  CanonicalURL(entities.SlugTypeArticle, article.Slug),
 		Title:         article.Title,
 		Content:       article.Content,
+		ContentHTML:   document.HTML,
+		TOC:           document.TOC,
+		Excerpt:       article.Excerpt,
+		ReadingTime:   markdown.ReadingTime(document.WordCount),
 		LikesCount:    article.LikesCount,
 		CommentsCount: article.CommentsCount,
 		CreatedAt:     article.CreatedAt,
@@ -114,6 +165,9 @@ func (auc *articleUseCase) GetArticleByID(c echo.Context, articleId uuid.UUID) (
 			ImageURL: *article.Author.Photo,
 			Username: article.Author.Username,
 		},
+		Tags:             tags,
+		RelatedArticles:  rankRelatedArticles(article.Title, article.Excerpt, candidates, maxRelatedArticles),
+		FeaturedProducts: toArticleProductResponses(products),
 	}
 
 	return articleDetailResponse, nil
@@ -131,9 +185,12 @@ func (auc *articleUseCase) SearchArticles(c echo.Context, req *dto_base.SearchRe
 	articleResponse := make([]dto.ArticleResponse, len(articles))
 	for i, article := range articles {
 		articleResponse[i] = dto.ArticleResponse{
-			ID:        article.ID,
-			Title:     article.Title,
-			CreatedAt: article.CreatedAt,
+			ID:          article.ID,
+			Slug:        article.Slug,
+			Title:       article.Title,
+			Excerpt:     article.Excerpt,
+			ReadingTime: article.ReadingTime,
+			CreatedAt:   article.CreatedAt,
 		}
 	}
 
@@ -147,7 +204,7 @@ func (auc *articleUseCase) SearchArticles(c echo.Context, req *dto_base.SearchRe
 	return articleResponse, metadata, nil
 }
 
-func (auc *articleUseCase) GetCommentsByArticleID(c echo.Context, articleId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.ArticleCommentResponse, *dto_base.PaginationMetadata, *dto_base.Link, error) {
+func (auc *articleUseCase) GetCommentsByArticleID(c echo.Context, userId uuid.UUID, articleId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.ArticleCommentResponse, *dto_base.PaginationMetadata, *dto_base.Link, error) {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
@@ -167,12 +224,27 @@ func (auc *articleUseCase) GetCommentsByArticleID(c echo.Context, articleId uuid
 		return nil, nil, nil, err
 	}
 
+	commentIds := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		commentIds[i] = comment.ID
+	}
+
+	liked, err := auc.getLikedComments(ctx, userId, entities.CommentTargetComment, commentIds)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	articleCommentResponse := make([]dto.ArticleCommentResponse, len(comments))
 	for i, comment := range comments {
 		articleCommentResponse[i] = dto.ArticleCommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			CreatedAt: comment.CreatedAt,
+			ID:           comment.ID,
+			UserID:       comment.UserID,
+			Content:      comment.Content,
+			LikesCount:   comment.LikesCount,
+			RepliesCount: comment.RepliesCount,
+			Liked:        liked[comment.ID],
+			EditedAt:     comment.EditedAt,
+			CreatedAt:    comment.CreatedAt,
 		}
 	}
 
@@ -207,15 +279,19 @@ func (auc *articleUseCase) AddCommentToArticle(c echo.Context, userId uuid.UUID,
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
+	content, err := auc.checkCommentContent(ctx, req.Content)
+	if err != nil {
+		return err
+	}
 
 	comment := entities.ArticleComments{
 		ID:              uuid.New(),
 		UserID:          userId,
 		ArticleID:       articleId,
-		Content:         req.Content,
+		Content:         content,
 	}
 
-	err := auc.articleRepository.AddCommentToArticle(ctx, &comment)
+	err = auc.articleRepository.AddCommentToArticle(ctx, &comment)
 	if err != nil {
 		fmt.Println("Error: ", err)
 		return err
@@ -224,26 +300,170 @@ func (auc *articleUseCase) AddCommentToArticle(c echo.Context, userId uuid.UUID,
 	return nil
 }
 
-func (auc *articleUseCase) ReplyToComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleCommentRequest) error {
+func (auc *articleUseCase) ReplyToComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleReplyRequest) error {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
+	content, err := auc.checkCommentContent(ctx, req.Content)
+	if err != nil {
+		return err
+	}
+
+	// Replies are only allowed on visible comments of the same article
+	comment, err := auc.getComment(ctx, articleId, commentId)
+	if err != nil {
+		return err
+	}
+
 	replies := entities.ArticleCommentReplies{
 		ID:              uuid.New(),
 		UserID:          userId,
 		ArticleID:       articleId,
 		CommentID:       commentId,
-		Content:         req.Content,
+		Content:         content,
+	}
+
+	// The author of the comment or of the reply being answered gets notified
+	recipientId := comment.UserID
+	if req.ReplyToID != nil {
+		parent, err := auc.getReply(ctx, articleId, commentId, *req.ReplyToID)
+		if err != nil {
+			return err
+		}
+
+		replies.ParentReplyID = &parent.ID
+		replies.MentionedUserID = &parent.UserID
+		recipientId = parent.UserID
 	}
 
-	err := auc.articleRepository.ReplyToComment(ctx, &replies)
+	err = auc.articleRepository.ReplyToComment(ctx, &replies)
 	if err != nil {
 		return err
 	}
 
+	if recipientId != userId {
+		go auc.notifyReply(context.Background(), recipientId, userId, &replies)
+	}
+
 	return nil
 }
 
+// GetReplies returns a page of the replies of a comment, oldest first. The
+// cursor of the next page is returned while more replies remain.
+func (auc *articleUseCase) GetReplies(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, cursor string, limit int) ([]dto.ArticleReplyResponse, *dto_base.CursorMetadata, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := auc.getComment(ctx, articleId, commentId); err != nil {
+		return nil, nil, err
+	}
+
+	var after *entities.CommentCursor
+	if cursor != "" {
+		decoded, err := decodeCommentCursor(cursor)
+		if err != nil {
+			return nil, nil, err_util.ErrInvalidCursor
+		}
+		after = decoded
+	}
+
+	// One extra reply tells whether another page follows
+	replies, err := auc.articleRepository.GetRepliesByCommentID(ctx, commentId, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := &dto_base.CursorMetadata{}
+	if len(replies) > limit {
+		replies = replies[:limit]
+		last := replies[len(replies)-1]
+		metadata.HasMore = true
+		metadata.NextCursor = encodeCommentCursor(&entities.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	replyIds := make([]uuid.UUID, len(replies))
+	for i, reply := range replies {
+		replyIds[i] = reply.ID
+	}
+
+	liked, err := auc.getLikedComments(ctx, userId, entities.CommentTargetReply, replyIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.ArticleReplyResponse, len(replies))
+	for i, reply := range replies {
+		response[i] = dto.ArticleReplyResponse{
+			ID:              reply.ID,
+			CommentID:       reply.CommentID,
+			ParentReplyID:   reply.ParentReplyID,
+			UserID:          reply.UserID,
+			MentionedUserID: reply.MentionedUserID,
+			Content:         reply.Content,
+			LikesCount:      reply.LikesCount,
+			Liked:           liked[reply.ID],
+			EditedAt:        reply.EditedAt,
+			CreatedAt:       reply.CreatedAt,
+		}
+	}
+
+	return response, metadata, nil
+}
+
+func (auc *articleUseCase) LikeComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := auc.getComment(ctx, articleId, commentId); err != nil {
+		return err
+	}
+
+	return auc.articleRepository.LikeComment(ctx, &entities.ArticleCommentLikes{
+		ID:         uuid.New(),
+		TargetType: entities.CommentTargetComment,
+		TargetID:   commentId,
+		UserID:     userId,
+	})
+}
+
+func (auc *articleUseCase) UnlikeComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := auc.getComment(ctx, articleId, commentId); err != nil {
+		return err
+	}
+
+	return auc.articleRepository.UnlikeComment(ctx, entities.CommentTargetComment, commentId, userId)
+}
+
+func (auc *articleUseCase) LikeReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := auc.getReply(ctx, articleId, commentId, replyId); err != nil {
+		return err
+	}
+
+	return auc.articleRepository.LikeComment(ctx, &entities.ArticleCommentLikes{
+		ID:         uuid.New(),
+		TargetType: entities.CommentTargetReply,
+		TargetID:   replyId,
+		UserID:     userId,
+	})
+}
+
+func (auc *articleUseCase) UnlikeReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := auc.getReply(ctx, articleId, commentId, replyId); err != nil {
+		return err
+	}
+
+	return auc.articleRepository.UnlikeComment(ctx, entities.CommentTargetReply, replyId, userId)
+}
+
 func (auc *articleUseCase) LikeArticle(c echo.Context, userId uuid.UUID, articleId uuid.UUID) error {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
@@ -267,3 +487,164 @@ func (auc *articleUseCase) UnlikeArticle(c echo.Context, userId uuid.UUID, artic
 
 	return nil
 }
+
+func (auc *articleUseCase) EditComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.ArticleCommentRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	comment, err := auc.getComment(ctx, articleId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userId {
+		return err_util.ErrForbiddenResource
+	}
+
+	content, err := auc.checkCommentContent(ctx, req.Content)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	comment.Content = content
+	comment.EditedAt = &now
+
+	return auc.articleRepository.UpdateComment(ctx, comment)
+}
+
+func (auc *articleUseCase) DeleteComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	comment, err := auc.getComment(ctx, articleId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userId {
+		return err_util.ErrForbiddenResource
+	}
+
+	return auc.articleRepository.DeleteComment(ctx, comment)
+}
+
+func (auc *articleUseCase) EditReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID, req *dto.ArticleCommentRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	reply, err := auc.getReply(ctx, articleId, commentId, replyId)
+	if err != nil {
+		return err
+	}
+
+	if reply.UserID != userId {
+		return err_util.ErrForbiddenResource
+	}
+
+	content, err := auc.checkCommentContent(ctx, req.Content)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reply.Content = content
+	reply.EditedAt = &now
+
+	return auc.articleRepository.UpdateReply(ctx, reply)
+}
+
+func (auc *articleUseCase) DeleteReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	reply, err := auc.getReply(ctx, articleId, commentId, replyId)
+	if err != nil {
+		return err
+	}
+
+	if reply.UserID != userId {
+		return err_util.ErrForbiddenResource
+	}
+
+	return auc.articleRepository.DeleteReply(ctx, reply)
+}
+
+func (auc *articleUseCase) ReportComment(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, req *dto.CommentReportRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	comment, err := auc.getComment(ctx, articleId, commentId)
+	if err != nil {
+		return err
+	}
+
+	return auc.moderationUseCase.Report(ctx, &entities.ArticleCommentReports{
+		ID:         uuid.New(),
+		TargetType: entities.CommentTargetComment,
+		TargetID:   comment.ID,
+		ReporterID: userId,
+		ArticleID:  articleId,
+		Reason:     strings.TrimSpace(req.Reason),
+		Status:     entities.CommentReportOpen,
+	})
+}
+
+func (auc *articleUseCase) ReportReply(c echo.Context, userId uuid.UUID, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID, req *dto.CommentReportRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	reply, err := auc.getReply(ctx, articleId, commentId, replyId)
+	if err != nil {
+		return err
+	}
+
+	return auc.moderationUseCase.Report(ctx, &entities.ArticleCommentReports{
+		ID:         uuid.New(),
+		TargetType: entities.CommentTargetReply,
+		TargetID:   reply.ID,
+		ReporterID: userId,
+		ArticleID:  articleId,
+		Reason:     strings.TrimSpace(req.Reason),
+		Status:     entities.CommentReportOpen,
+	})
+}
+
+func (auc *articleUseCase) getComment(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID) (*entities.ArticleComments, error) {
+	comment, err := auc.articleRepository.GetCommentByID(ctx, articleId, commentId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (auc *articleUseCase) getReply(ctx context.Context, articleId uuid.UUID, commentId uuid.UUID, replyId uuid.UUID) (*entities.ArticleCommentReplies, error) {
+	reply, err := auc.articleRepository.GetReplyByID(ctx, articleId, commentId, replyId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// checkCommentContent trims the content and rejects it when it is empty or
+// contains a banned word
+func (auc *articleUseCase) checkCommentContent(ctx context.Context, content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", err_util.ErrInvalidComment
+	}
+
+	if err := auc.moderationUseCase.CheckContent(ctx, content); err != nil {
+		return "", err
+	}
+
+	return content, nil
+}
@@ -8,7 +8,9 @@ import (
 	dto_base "kreasi-nusantara-api/dto/base"
 	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/diff"
 	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/markdown"
 	"kreasi-nusantara-api/utils/token"
 	"math"
 	"net/http"
@@ -18,15 +20,25 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type ArticleUseCaseAdmin interface {
-	GetArticles(c echo.Context, req *dto_base.PaginationRequest) (*[]dto.ArticleAdminResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
+	GetArticles(c echo.Context, req *dto_base.PaginationRequest, status string) (*[]dto.ArticleAdminResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
 	SearchArticles(c echo.Context, req *dto_base.SearchRequest) ([]dto.ArticleAdminResponse, *dto_base.MetadataResponse, error)
 	CreateArticles(c echo.Context, req *dto.ArticleRequest, adminId uuid.UUID) error
 	UpdateArticles(c echo.Context, articleId uuid.UUID, req *dto.ArticleRequest) error
 	DeleteArticles(c echo.Context, articleId uuid.UUID) error
 	GetArticleByID(c echo.Context, articleId uuid.UUID) (*dto.ArticleAdminResponse, error)
+	UpdateArticleStatus(c echo.Context, articleId uuid.UUID, req *dto.ArticleStatusRequest) error
+	RunScheduledPublishing(ctx context.Context, interval time.Duration)
+	GetArticleRevisions(c echo.Context, articleId uuid.UUID) ([]dto.ArticleRevisionResponse, error)
+	DiffArticleRevisions(c echo.Context, articleId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) (*dto.ArticleRevisionDiffResponse, error)
+	RestoreArticleRevision(c echo.Context, articleId uuid.UUID, revisionId uuid.UUID) (*dto.ArticleRevisionResponse, error)
+	BackfillArticleSummaries(ctx context.Context) error
+	GetArticleProducts(c echo.Context, articleId uuid.UUID) ([]dto.ArticleProductResponse, error)
+	SetArticleProducts(c echo.Context, articleId uuid.UUID, req *dto.ArticleProductsRequest) ([]dto.ArticleProductResponse, error)
 	convertQueryParams(page, limit string) (int, int, error)
 }
 
@@ -34,17 +46,21 @@ type articleUseCaseAdmin struct {
 	articleAdminRepository repositories.ArticleAdminRepository
 	tokenUtil              token.TokenUtil
 	adminRepo              repositories.AdminRepository
+	tagUseCase             TagUseCase
+	slugUseCase            SlugUseCase
 }
 
-func NewArticleUseCaseAdmin(articleAdminRepository repositories.ArticleAdminRepository, tokenUtil token.TokenUtil, adminRepo repositories.AdminRepository) *articleUseCaseAdmin {
+func NewArticleUseCaseAdmin(articleAdminRepository repositories.ArticleAdminRepository, tokenUtil token.TokenUtil, adminRepo repositories.AdminRepository, tagUseCase TagUseCase, slugUseCase SlugUseCase) *articleUseCaseAdmin {
 	return &articleUseCaseAdmin{
 		articleAdminRepository: articleAdminRepository,
 		tokenUtil:              tokenUtil,
 		adminRepo:              adminRepo,
+		tagUseCase:             tagUseCase,
+		slugUseCase:            slugUseCase,
 	}
 }
 
-func (auc *articleUseCaseAdmin) GetArticles(c echo.Context, req *dto_base.PaginationRequest) (*[]dto.ArticleAdminResponse, *dto_base.PaginationMetadata, *dto_base.Link, error) {
+func (auc *articleUseCaseAdmin) GetArticles(c echo.Context, req *dto_base.PaginationRequest, status string) (*[]dto.ArticleAdminResponse, *dto_base.PaginationMetadata, *dto_base.Link, error) {
 
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
@@ -54,17 +70,29 @@ func (auc *articleUseCaseAdmin) GetArticles(c echo.Context, req *dto_base.Pagina
 		c.Request().URL.Path,
 		req.Limit,
 	)
+	if status != "" {
+		baseURL = fmt.Sprintf(
+			"%s?status=%s&limit=%d&page=",
+			c.Request().URL.Path,
+			status,
+			req.Limit,
+		)
+	}
 
 	var (
 		next = baseURL + strconv.Itoa(req.Page+1)
 		prev = baseURL + strconv.Itoa(req.Page-1)
 	)
 
+	if status != "" && !isArticleStatus(status) {
+		return nil, nil, nil, err_util.ErrInvalidArticleStatus
+	}
+
 	if auc.adminRepo == nil {
 		return nil, nil, nil, errors.New("adminRepo is nil")
 	}
 
-	articles, totalData, err := auc.articleAdminRepository.GetArticlesAdmin(ctx, req)
+	articles, totalData, err := auc.articleAdminRepository.GetArticlesAdmin(ctx, req, status)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -92,13 +120,17 @@ func (auc *articleUseCaseAdmin) GetArticles(c echo.Context, req *dto_base.Pagina
 		createdAtStr := authors.CreatedAt.Format("Jan 2, 2006")
 
 		article = append(article, dto.ArticleAdminResponse{
-			ID:        authors.ID,
-			Tags:      authors.Tags,
-			Title:     authors.Title,
-			Author:    authorName,
-			Image:     authors.Image,
-			Content:   authors.Content,
-			CreatedAt: createdAtStr,
+			ID:          authors.ID,
+			Tags:        authors.Tags,
+			Title:       authors.Title,
+			Author:      authorName,
+			Image:       authors.Image,
+			Content:     authors.Content,
+			Excerpt:     authors.Excerpt,
+			Status:      authors.Status,
+			PublishAt:   authors.PublishAt,
+			PublishedAt: authors.PublishedAt,
+			CreatedAt:   createdAtStr,
 		})
 	}
 
@@ -172,13 +204,17 @@ func (auc *articleUseCaseAdmin) SearchArticles(c echo.Context, req *dto_base.Sea
 		createdAtStr := authors.CreatedAt.Format("Jan 2, 2006")
 
 		article = append(article, dto.ArticleAdminResponse{
-			ID:        authors.ID,
-			Tags:      authors.Tags,
-			Title:     authors.Title,
-			Author:    authorName,
-			Image:     authors.Image,
-			Content:   authors.Content,
-			CreatedAt: createdAtStr,
+			ID:          authors.ID,
+			Tags:        authors.Tags,
+			Title:       authors.Title,
+			Author:      authorName,
+			Image:       authors.Image,
+			Content:     authors.Content,
+			Excerpt:     authors.Excerpt,
+			Status:      authors.Status,
+			PublishAt:   authors.PublishAt,
+			PublishedAt: authors.PublishedAt,
+			CreatedAt:   createdAtStr,
 		})
 	}
 
@@ -198,24 +234,39 @@ func (auc *articleUseCaseAdmin) CreateArticles(c echo.Context, req *dto.ArticleR
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
+	tags, err := auc.tagUseCase.ResolveTags(ctx, req.Tags)
+	if err != nil {
+		return err
+	}
+
 	article := &entities.Articles{
 		ID:            uuid.New(),
 		Title:         req.Title,
 		Content:       req.Content,
-		Tags:          req.Tags,
+		Tags:          joinTagNames(tags),
 		Image:         req.Image,
 		CreatedAt:     time.Now(),
 		AuthorID:      adminId,
 		LikesCount:    0,
 		CommentsCount: 0,
+		Status:        entities.ArticleStatusDraft,
 	}
+	summarizeArticle(article, req.Excerpt)
 
-	err := auc.articleAdminRepository.CreateArticleAdmin(ctx, article)
+	// Artikel baru berstatus draft kecuali status lain diminta
+	if req.Status != "" {
+		if err := applyArticleStatus(article, req.Status, req.PublishAt, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	slug, _, err := auc.slugUseCase.NextSlug(ctx, entities.SlugTypeArticle, article.ID, article.Title)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Artikel, tag, slug dan versi pertamanya disimpan dalam satu transaksi
+	return auc.articleAdminRepository.CreateArticleWithRevision(ctx, article, tagIDs(tags), slug, newArticleRevision(article, adminId, nil))
 }
 
 func (auc *articleUseCaseAdmin) UpdateArticles(c echo.Context, articleId uuid.UUID, req *dto.ArticleRequest) error {
@@ -232,12 +283,31 @@ func (auc *articleUseCaseAdmin) UpdateArticles(c echo.Context, articleId uuid.UU
 		return echo.NewHTTPError(http.StatusNotFound, "Article not found")
 	}
 
+	// Artikel lama belum memiliki riwayat, simpan kondisi sebelum diubah sebagai versi awal
+	if err := auc.ensureBaselineRevision(ctx, existingArticle); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update article")
+	}
+
+	tags, err := auc.tagUseCase.ResolveTags(ctx, req.Tags)
+	if err != nil {
+		return err
+	}
+
 	existingArticle.UpdatedAt = time.Now()
 	existingArticle.Content = req.Content
 	existingArticle.Title = req.Title
 	existingArticle.Image = req.Image
+	existingArticle.Tags = joinTagNames(tags)
+	summarizeArticle(existingArticle, req.Excerpt)
 
-	err = auc.articleAdminRepository.UpdateArticleAdmin(ctx, articleId, existingArticle)
+	// Judul yang berubah menghasilkan slug baru, slug lama tetap dialihkan ke slug baru
+	slug, previousSlug, err := auc.slugUseCase.NextSlug(ctx, entities.SlugTypeArticle, articleId, existingArticle.Title)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update article slug")
+	}
+
+	// Perubahan artikel, tag, slug dan versinya disimpan dalam satu transaksi
+	err = auc.articleAdminRepository.UpdateArticleWithRevision(ctx, existingArticle, tagIDs(tags), slug, previousSlug, newArticleRevision(existingArticle, claims.ID, nil))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update article")
 	}
@@ -308,18 +378,383 @@ func (auc *articleUseCaseAdmin) GetArticleByID(c echo.Context, articleId uuid.UU
 	CreatedAtStr := article.CreatedAt.Format("Jan 2, 2006")
 	// Membuat respons artikel
 	articleResponse := dto.ArticleAdminResponse{
-		ID:        article.ID,
-		Tags:      article.Tags,
-		Title:     article.Title,
-		Author:    authorName,
-		Content:   article.Content,
-		Image:     article.Image,
-		CreatedAt: CreatedAtStr,
+		ID:          article.ID,
+		Tags:        article.Tags,
+		Title:       article.Title,
+		Author:      authorName,
+		Content:     article.Content,
+		Excerpt:     article.Excerpt,
+		Image:       article.Image,
+		Status:      article.Status,
+		PublishAt:   article.PublishAt,
+		PublishedAt: article.PublishedAt,
+		CreatedAt:   CreatedAtStr,
 	}
 
 	return &articleResponse, nil
 }
 
+func (auc *articleUseCaseAdmin) UpdateArticleStatus(c echo.Context, articleId uuid.UUID, req *dto.ArticleStatusRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	article, err := auc.articleAdminRepository.GetArticleByIDAdmin(ctx, articleId)
+	if err != nil {
+		return err
+	}
+
+	if article.ID == uuid.Nil {
+		return err_util.ErrNotFound
+	}
+
+	if !canTransitionArticle(article.Status, req.Status) {
+		return err_util.ErrInvalidArticleStatus
+	}
+
+	if err := applyArticleStatus(article, req.Status, req.PublishAt, time.Now()); err != nil {
+		return err
+	}
+
+	return auc.articleAdminRepository.UpdateArticleStatus(ctx, article)
+}
+
+// RunScheduledPublishing periodically publishes scheduled articles whose
+// publish time has passed. It blocks until ctx is done.
+func (auc *articleUseCaseAdmin) RunScheduledPublishing(ctx context.Context, interval time.Duration) {
+	log := logrus.New()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			published, err := auc.articleAdminRepository.PublishScheduledArticles(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("Failed to publish scheduled articles")
+				continue
+			}
+
+			if published > 0 {
+				log.Infof("Published %d scheduled article(s)", published)
+			}
+		}
+	}
+}
+
+func (auc *articleUseCaseAdmin) GetArticleProducts(c echo.Context, articleId uuid.UUID) ([]dto.ArticleProductResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	article, err := auc.articleAdminRepository.GetArticleByIDAdmin(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.ID == uuid.Nil {
+		return nil, err_util.ErrNotFound
+	}
+
+	products, err := auc.articleAdminRepository.GetArticleProducts(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toArticleProductResponses(products), nil
+}
+
+// SetArticleProducts replaces the products featured in an article
+func (auc *articleUseCaseAdmin) SetArticleProducts(c echo.Context, articleId uuid.UUID, req *dto.ArticleProductsRequest) ([]dto.ArticleProductResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	article, err := auc.articleAdminRepository.GetArticleByIDAdmin(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.ID == uuid.Nil {
+		return nil, err_util.ErrNotFound
+	}
+
+	count, err := auc.articleAdminRepository.CountProductsByIDs(ctx, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(count) != len(req.ProductIDs) {
+		return nil, err_util.ErrInvalidArticleProducts
+	}
+
+	if err := auc.articleAdminRepository.ReplaceArticleProducts(ctx, articleId, req.ProductIDs); err != nil {
+		return nil, err
+	}
+
+	products, err := auc.articleAdminRepository.GetArticleProducts(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return toArticleProductResponses(products), nil
+}
+
+func (auc *articleUseCaseAdmin) GetArticleRevisions(c echo.Context, articleId uuid.UUID) ([]dto.ArticleRevisionResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	article, err := auc.articleAdminRepository.GetArticleByIDAdmin(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.ID == uuid.Nil {
+		return nil, err_util.ErrNotFound
+	}
+
+	revisions, err := auc.articleAdminRepository.GetArticleRevisions(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ArticleRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		response[i] = toArticleRevisionResponse(&revision)
+	}
+
+	return response, nil
+}
+
+func (auc *articleUseCaseAdmin) DiffArticleRevisions(c echo.Context, articleId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) (*dto.ArticleRevisionDiffResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	from, err := auc.getArticleRevision(ctx, articleId, fromId)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := auc.getArticleRevision(ctx, articleId, toId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Diff per baris untuk judul, tag dan isi artikel
+	titleDiff := diff.Lines(from.Title, to.Title)
+	tagsDiff := diff.Lines(from.Tags, to.Tags)
+	contentDiff := diff.Lines(from.Content, to.Content)
+
+	unified := fmt.Sprintf(
+		"--- version %d\n+++ version %d\n@@ title @@\n%s@@ tags @@\n%s@@ content @@\n%s",
+		from.Version,
+		to.Version,
+		diff.Unified(titleDiff),
+		diff.Unified(tagsDiff),
+		diff.Unified(contentDiff),
+	)
+
+	return &dto.ArticleRevisionDiffResponse{
+		From:         toArticleRevisionResponse(from),
+		To:           toArticleRevisionResponse(to),
+		TitleDiff:    titleDiff,
+		ContentDiff:  contentDiff,
+		TagsDiff:     tagsDiff,
+		ImageChanged: from.Image != to.Image,
+		Unified:      unified,
+	}, nil
+}
+
+func (auc *articleUseCaseAdmin) RestoreArticleRevision(c echo.Context, articleId uuid.UUID, revisionId uuid.UUID) (*dto.ArticleRevisionResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	claims := auc.tokenUtil.GetClaims(c)
+	if claims == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	article, err := auc.articleAdminRepository.GetArticleByIDAdmin(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.ID == uuid.Nil {
+		return nil, err_util.ErrNotFound
+	}
+
+	revision, err := auc.getArticleRevision(ctx, articleId, revisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := auc.tagUseCase.ResolveTags(ctx, revision.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pemulihan tidak menghapus riwayat, melainkan membuat versi baru dari versi lama
+	article.Title = revision.Title
+	article.Image = revision.Image
+	article.Content = revision.Content
+	article.Tags = joinTagNames(tags)
+	article.UpdatedAt = time.Now()
+	summarizeArticle(article, "")
+
+	slug, previousSlug, err := auc.slugUseCase.NextSlug(ctx, entities.SlugTypeArticle, article.ID, article.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := newArticleRevision(article, claims.ID, &revision.ID)
+	if err := auc.articleAdminRepository.RestoreArticleRevision(ctx, article, tagIDs(tags), slug, previousSlug, restored); err != nil {
+		return nil, err
+	}
+
+	response := toArticleRevisionResponse(restored)
+	return &response, nil
+}
+
+func (auc *articleUseCaseAdmin) getArticleRevision(ctx context.Context, articleId uuid.UUID, revisionId uuid.UUID) (*entities.ArticleRevisions, error) {
+	revision, err := auc.articleAdminRepository.GetArticleRevisionByID(ctx, articleId, revisionId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrArticleRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// ensureBaselineRevision menyimpan kondisi artikel saat ini sebagai versi pertama
+// untuk artikel yang dibuat sebelum riwayat revisi tersedia
+func (auc *articleUseCaseAdmin) ensureBaselineRevision(ctx context.Context, article *entities.Articles) error {
+	total, err := auc.articleAdminRepository.CountArticleRevisions(ctx, article.ID)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return nil
+	}
+
+	return auc.articleAdminRepository.CreateArticleRevision(ctx, newArticleRevision(article, article.AuthorID, nil))
+}
+
+func newArticleRevision(article *entities.Articles, editorId uuid.UUID, restoredFromId *uuid.UUID) *entities.ArticleRevisions {
+	return &entities.ArticleRevisions{
+		ID:             uuid.New(),
+		ArticleID:      article.ID,
+		EditorID:       editorId,
+		Title:          article.Title,
+		Image:          article.Image,
+		Content:        article.Content,
+		Tags:           article.Tags,
+		RestoredFromID: restoredFromId,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func toArticleRevisionResponse(revision *entities.ArticleRevisions) dto.ArticleRevisionResponse {
+	var editor string
+	if revision.Editor != nil {
+		editor = revision.Editor.FirstName + " " + revision.Editor.LastName
+	}
+
+	return dto.ArticleRevisionResponse{
+		ID:             revision.ID,
+		ArticleID:      revision.ArticleID,
+		Version:        revision.Version,
+		EditorID:       revision.EditorID,
+		Editor:         editor,
+		Title:          revision.Title,
+		Image:          revision.Image,
+		Content:        revision.Content,
+		Tags:           revision.Tags,
+		RestoredFromID: revision.RestoredFromID,
+		CreatedAt:      revision.CreatedAt,
+	}
+}
+
+// maxArticleExcerptLength is the length of generated article excerpts
+const maxArticleExcerptLength = 200
+
+// articleTransitions lists the states an article may move to from each state
+var articleTransitions = map[string][]string{
+	entities.ArticleStatusDraft:     {entities.ArticleStatusInReview, entities.ArticleStatusScheduled, entities.ArticleStatusPublished, entities.ArticleStatusArchived},
+	entities.ArticleStatusInReview:  {entities.ArticleStatusDraft, entities.ArticleStatusScheduled, entities.ArticleStatusPublished, entities.ArticleStatusArchived},
+	entities.ArticleStatusScheduled: {entities.ArticleStatusDraft, entities.ArticleStatusInReview, entities.ArticleStatusScheduled, entities.ArticleStatusPublished, entities.ArticleStatusArchived},
+	entities.ArticleStatusPublished: {entities.ArticleStatusDraft, entities.ArticleStatusArchived},
+	entities.ArticleStatusArchived:  {entities.ArticleStatusDraft, entities.ArticleStatusPublished},
+}
+
+// BackfillArticleSummaries menghitung ringkasan dan waktu baca artikel lama
+func (auc *articleUseCaseAdmin) BackfillArticleSummaries(ctx context.Context) error {
+	articles, err := auc.articleAdminRepository.GetArticlesWithoutSummary(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range articles {
+		summarizeArticle(&articles[i], articles[i].Excerpt)
+		if err := auc.articleAdminRepository.UpdateArticleSummary(ctx, &articles[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// summarizeArticle mengisi ringkasan dan perkiraan waktu baca dari konten Markdown.
+// Ringkasan dibuat otomatis bila admin tidak mengisinya.
+func summarizeArticle(article *entities.Articles, excerpt string) {
+	document := markdown.Render(article.Content)
+
+	excerpt = strings.TrimSpace(excerpt)
+	if excerpt == "" {
+		excerpt = markdown.Excerpt(document.Summary, maxArticleExcerptLength)
+	}
+
+	article.Excerpt = excerpt
+	article.ReadingTime = markdown.ReadingTime(document.WordCount)
+}
+
+func isArticleStatus(status string) bool {
+	_, ok := articleTransitions[status]
+	return ok
+}
+
+func canTransitionArticle(from, to string) bool {
+	for _, status := range articleTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// applyArticleStatus sets the status of the article along with its publish times.
+// Scheduled articles need a publish time in the future.
+func applyArticleStatus(article *entities.Articles, status string, publishAt *time.Time, now time.Time) error {
+	switch status {
+	case entities.ArticleStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return err_util.ErrInvalidPublishAt
+		}
+		article.PublishAt = publishAt
+	case entities.ArticleStatusPublished:
+		article.PublishAt = nil
+		if article.PublishedAt == nil {
+			article.PublishedAt = &now
+		}
+	default:
+		article.PublishAt = nil
+	}
+
+	article.Status = status
+	return nil
+}
+
 func (auc *articleUseCaseAdmin) convertQueryParams(page, limit string) (int, int, error) {
 	if page == "" {
 		page = "1"
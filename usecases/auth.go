@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/email"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/token"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AuthUseCase interface {
+	IssueTokens(c echo.Context, userID uuid.UUID, role string, email string) (*dto.TokenResponse, error)
+	RefreshToken(c echo.Context, req *dto.RefreshTokenRequest) (*dto.TokenResponse, error)
+	Logout(c echo.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	LogoutAll(c echo.Context, userID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+	RunTokenCleanup(ctx context.Context, interval time.Duration)
+
+	// Sessions
+	GetSessions(c echo.Context, userID uuid.UUID, currentSessionID uuid.UUID) ([]dto.SessionResponse, error)
+	RevokeSession(c echo.Context, userID uuid.UUID, sessionID uuid.UUID) error
+
+	// Brute-force protection
+	CheckAttempts(c echo.Context, scope string, account string) error
+	CountAttempt(c echo.Context, scope string, account string)
+	ClearAttempts(scope string, account string)
+	RecordSecurityEvent(c echo.Context, eventType string, scope string, account string, details string)
+}
+
+type authUseCase struct {
+	authRepository repositories.AuthRepository
+	tokenUtil      token.TokenUtil
+	redisClient    redis.RedisClient
+	emailUtil      email.EmailUtil
+}
+
+func NewAuthUseCase(authRepository repositories.AuthRepository, tokenUtil token.TokenUtil, redisClient redis.RedisClient, emailUtil email.EmailUtil) *authUseCase {
+	return &authUseCase{
+		authRepository: authRepository,
+		tokenUtil:      tokenUtil,
+		redisClient:    redisClient,
+		emailUtil:      emailUtil,
+	}
+}
+
+// IssueTokens starts a new session on the device making the request and
+// returns its access and refresh tokens. The email address is alerted when
+// the device was never used to log in to the account before.
+func (au *authUseCase) IssueTokens(c echo.Context, userID uuid.UUID, role string, email string) (*dto.TokenResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	userAgent := truncate(c.Request().UserAgent(), 255)
+	session := &entities.UserSessions{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Role:       role,
+		DeviceName: describeDevice(userAgent),
+		UserAgent:  userAgent,
+		IPAddress:  c.RealIP(),
+		LastSeenAt: time.Now(),
+	}
+
+	sessions, fromDevice, err := au.authRepository.CountSessions(ctx, userID, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, stored, err := au.newRefreshToken(session.ID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := au.authRepository.CreateSession(ctx, session, stored); err != nil {
+		return nil, err
+	}
+
+	// The first login of an account is not a new device worth an alert
+	if sessions > 0 && fromDevice == 0 {
+		go au.sendNewDeviceAlert(email, session)
+	}
+
+	return au.tokenResponse(stored, refreshToken)
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. Presenting a refresh token that was already exchanged
+// revokes its session, since either the client or an attacker holds a copy.
+func (au *authUseCase) RefreshToken(c echo.Context, req *dto.RefreshTokenRequest) (*dto.TokenResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	current, err := au.authRepository.GetRefreshTokenByHash(ctx, au.tokenUtil.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+		return nil, err_util.ErrInvalidRefreshToken
+	}
+
+	if current.UsedAt != nil {
+		return nil, au.revokeReusedSession(ctx, current)
+	}
+
+	// The account may have been deleted, deactivated or given another role
+	// since the session started
+	role, err := au.currentRole(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, next, err := au.newRefreshToken(current.SessionID, current.UserID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := au.authRepository.RotateRefreshToken(ctx, current.ID, next, c.RealIP())
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Another request exchanged the same token first
+		return nil, au.revokeReusedSession(ctx, current)
+	}
+
+	return au.tokenResponse(next, refreshToken)
+}
+
+// Logout ends the session the access token belongs to
+func (au *authUseCase) Logout(c echo.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	// The session may already be revoked, e.g. from another device
+	if err := au.revokeSession(ctx, userID, sessionID); err != nil && !errors.Is(err, err_util.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// LogoutAll ends every session of the user on all devices
+func (au *authUseCase) LogoutAll(c echo.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return au.RevokeAllSessions(ctx, userID)
+}
+
+func (au *authUseCase) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	sessionIDs, err := au.authRepository.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		au.markSessionRevoked(sessionID)
+	}
+
+	return nil
+}
+
+// RunTokenCleanup deletes expired refresh tokens every interval until ctx is done
+func (au *authUseCase) RunTokenCleanup(ctx context.Context, interval time.Duration) {
+	log := logrus.New()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := au.authRepository.DeleteExpiredRefreshTokens(ctx); err != nil {
+				log.WithError(err).Error("Failed to delete expired refresh tokens")
+			}
+		}
+	}
+}
+
+func (au *authUseCase) revokeReusedSession(ctx context.Context, reused *entities.RefreshTokens) error {
+	logrus.WithFields(logrus.Fields{
+		"user_id":    reused.UserID,
+		"session_id": reused.SessionID,
+	}).Warn("Refresh token reuse detected, revoking session")
+
+	if err := au.revokeSession(ctx, reused.UserID, reused.SessionID); err != nil && !errors.Is(err, err_util.ErrNotFound) {
+		return err
+	}
+
+	return err_util.ErrRefreshTokenReused
+}
+
+// currentRole returns the role the account behind a refresh token has now. The
+// session is revoked when the account was deleted or deactivated.
+func (au *authUseCase) currentRole(ctx context.Context, current *entities.RefreshTokens) (string, error) {
+	role, active := "user", false
+	if current.Role == "user" {
+		exists, err := au.authRepository.UserExists(ctx, current.UserID)
+		if err != nil {
+			return "", err
+		}
+		active = exists
+	} else {
+		admin, err := au.authRepository.GetAdminByID(ctx, current.UserID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+		if err == nil {
+			active = admin.IsActive
+			role = "admin"
+			if admin.IsSuperAdmin {
+				role = "super_admin"
+			}
+		}
+	}
+
+	if !active {
+		if err := au.revokeSession(ctx, current.UserID, current.SessionID); err != nil && !errors.Is(err, err_util.ErrNotFound) {
+			return "", err
+		}
+		return "", err_util.ErrInvalidRefreshToken
+	}
+
+	return role, nil
+}
+
+func (au *authUseCase) newRefreshToken(sessionID uuid.UUID, userID uuid.UUID, role string) (string, *entities.RefreshTokens, error) {
+	refreshToken, err := au.tokenUtil.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, err_util.ErrFailedGenerateToken
+	}
+
+	return refreshToken, &entities.RefreshTokens{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		UserID:    userID,
+		Role:      role,
+		TokenHash: au.tokenUtil.HashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(token.RefreshTokenTTL),
+	}, nil
+}
+
+func (au *authUseCase) tokenResponse(stored *entities.RefreshTokens, refreshToken string) (*dto.TokenResponse, error) {
+	accessToken, err := au.tokenUtil.GenerateToken(stored.UserID, stored.Role, stored.SessionID)
+	if err != nil {
+		return nil, err_util.ErrFailedGenerateToken
+	}
+
+	return &dto.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(token.AccessTokenTTL.Seconds()),
+	}, nil
+}
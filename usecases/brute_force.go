@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+	err_util "kreasi-nusantara-api/utils/error"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Scopes of counted attempts, each with its own limits
+const (
+	AttemptLogin      = "login"
+	AttemptAdminLogin = "admin_login"
+	AttemptOTP        = "otp"
+	AttemptOTPSend    = "otp_send"
+	AttemptTwoFactor  = "two_factor"
+)
+
+const (
+	// attemptWindow is how long failed attempts are remembered
+	attemptWindow = 15 * time.Minute
+	// lockoutMemory is how long past lockouts make the next one longer
+	lockoutMemory = 24 * time.Hour
+	baseLockout   = time.Minute
+	maxLockout    = time.Hour
+)
+
+// attemptLimit is how many attempts an account and an IP address may make
+// within attemptWindow before they are locked out
+type attemptLimit struct {
+	account int
+	ip      int
+}
+
+var attemptLimits = map[string]attemptLimit{
+	AttemptLogin:      {account: 5, ip: 20},
+	AttemptAdminLogin: {account: 5, ip: 10},
+	AttemptOTP:        {account: 5, ip: 20},
+	AttemptOTPSend:    {account: 3, ip: 10},
+	AttemptTwoFactor:  {account: 5, ip: 20},
+}
+
+// CheckAttempts returns ErrTooManyAttempts while the account or the IP
+// address of the request is locked out of the scope
+func (au *authUseCase) CheckAttempts(c echo.Context, scope string, account string) error {
+	for _, subject := range attemptSubjects(c, account) {
+		locked, err := au.redisClient.Exists(attemptKey("lock", scope, subject))
+		if err != nil {
+			logrus.WithError(err).Error("Failed to check lockout")
+			continue
+		}
+		if locked {
+			return err_util.ErrTooManyAttempts
+		}
+	}
+
+	return nil
+}
+
+// CountAttempt records a failed attempt, or a use of a rate-limited action,
+// and locks the account or IP address out once it reaches its limit. Every
+// lockout within lockoutMemory lasts twice as long as the one before.
+func (au *authUseCase) CountAttempt(c echo.Context, scope string, account string) {
+	limit := attemptLimits[scope]
+	subjects := attemptSubjects(c, account)
+	limits := []int{limit.account, limit.ip}
+
+	for i, subject := range subjects {
+		attempts, err := au.redisClient.IncrWithExpiry(attemptKey("count", scope, subject), attemptWindow)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to count attempt")
+			continue
+		}
+		if attempts < int64(limits[i]) {
+			continue
+		}
+
+		lockouts, err := au.redisClient.IncrWithExpiry(attemptKey("lockouts", scope, subject), lockoutMemory)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to count lockout")
+			lockouts = 1
+		}
+
+		duration := lockoutDuration(lockouts)
+		if err := au.redisClient.Set(attemptKey("lock", scope, subject), "1", duration); err != nil {
+			logrus.WithError(err).Error("Failed to lock out")
+			continue
+		}
+		if err := au.redisClient.Del(attemptKey("count", scope, subject)); err != nil {
+			logrus.WithError(err).Error("Failed to reset attempts")
+		}
+
+		eventType := entities.SecurityEventAccountLocked
+		if strings.HasPrefix(subject, "ip:") {
+			eventType = entities.SecurityEventIPLocked
+		}
+		au.RecordSecurityEvent(c, eventType, scope, account,
+			fmt.Sprintf("locked out for %s after %d attempts", duration, attempts))
+	}
+}
+
+// ClearAttempts forgets the failed attempts of an account after it succeeds.
+// The attempts of the IP address are kept, they may target other accounts.
+func (au *authUseCase) ClearAttempts(scope string, account string) {
+	if err := au.redisClient.Del(attemptKey("count", scope, "account:"+normalizeAccount(account))); err != nil {
+		logrus.WithError(err).Error("Failed to clear attempts")
+	}
+}
+
+// RecordSecurityEvent adds an entry to the audit trail, failures are only logged
+func (au *authUseCase) RecordSecurityEvent(c echo.Context, eventType string, scope string, account string, details string) {
+	event := &entities.SecurityEvents{
+		ID:        uuid.New(),
+		Type:      eventType,
+		Scope:     scope,
+		Account:   truncate(normalizeAccount(account), 100),
+		IPAddress: c.RealIP(),
+		Details:   details,
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"type":    event.Type,
+		"scope":   event.Scope,
+		"account": event.Account,
+		"ip":      event.IPAddress,
+	}).Warn(details)
+
+	// Lockouts are recorded even when the client gave up on the request
+	if err := au.authRepository.CreateSecurityEvent(context.Background(), event); err != nil {
+		logrus.WithError(err).Error("Failed to record security event")
+	}
+}
+
+func attemptSubjects(c echo.Context, account string) []string {
+	return []string{"account:" + normalizeAccount(account), "ip:" + c.RealIP()}
+}
+
+func attemptKey(kind string, scope string, subject string) string {
+	return fmt.Sprintf("attempts:%s:%s:%s", kind, scope, subject)
+}
+
+func lockoutDuration(lockouts int64) time.Duration {
+	duration := baseLockout
+	for i := int64(1); i < lockouts && duration < maxLockout; i++ {
+		duration *= 2
+	}
+	if duration > maxLockout {
+		return maxLockout
+	}
+	return duration
+}
+
+func normalizeAccount(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
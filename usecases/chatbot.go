@@ -29,8 +29,7 @@ func NewChatBotUseCase(openAIService openai.OpenAIClient, redisClient redis.Redi
 }
 
 func (cb *chatBotUseCase) AnswerChat(c echo.Context, userId uuid.UUID, question string) (string, error) {
-	userIDstr := userId.String()
-    key := "chat_history:" + userIDstr
+    key := chatHistoryKey(userId)
 
     history, err := cb.redisClient.Get(key)
     if err != nil && err.Error() != "redis: nil" {
@@ -55,6 +54,11 @@ func (cb *chatBotUseCase) AnswerChat(c echo.Context, userId uuid.UUID, question
     return answer, nil
 }
 
+// chatHistoryKey is where the conversation of a user with the chatbot is kept
+func chatHistoryKey(userID uuid.UUID) string {
+	return "chat_history:" + userID.String()
+}
+
 func limitHistory(history string, limit int) string {
 	lines := strings.Split(history, "\n")
 	var limitedLines []string
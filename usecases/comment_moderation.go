@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type CommentModerationUseCase interface {
+	GetModerationQueue(c echo.Context, hidden bool) ([]dto.CommentModerationResponse, error)
+	HideComment(c echo.Context, targetType string, targetID uuid.UUID) error
+	RestoreComment(c echo.Context, targetType string, targetID uuid.UUID) error
+
+	GetBannedWords(c echo.Context) ([]dto.BannedWordResponse, error)
+	CreateBannedWord(c echo.Context, req *dto.BannedWordRequest) (*dto.BannedWordResponse, error)
+	DeleteBannedWord(c echo.Context, id uuid.UUID) error
+
+	CheckContent(ctx context.Context, content string) error
+	Report(ctx context.Context, report *entities.ArticleCommentReports) error
+}
+
+type commentModerationUseCase struct {
+	moderationRepository repositories.CommentModerationRepository
+}
+
+func NewCommentModerationUseCase(moderationRepository repositories.CommentModerationRepository) *commentModerationUseCase {
+	return &commentModerationUseCase{
+		moderationRepository: moderationRepository,
+	}
+}
+
+func (mu *commentModerationUseCase) GetModerationQueue(c echo.Context, hidden bool) ([]dto.CommentModerationResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	items, err := mu.moderationRepository.GetModerationQueue(ctx, hidden)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CommentModerationResponse, len(items))
+	for i, item := range items {
+		reasons := []string{}
+		if item.Reasons != "" {
+			reasons = strings.Split(item.Reasons, "\x1f")
+		}
+
+		response[i] = dto.CommentModerationResponse{
+			TargetType:     item.TargetType,
+			TargetID:       item.TargetID,
+			ArticleID:      item.ArticleID,
+			UserID:         item.UserID,
+			Content:        item.Content,
+			Hidden:         item.Hidden,
+			ReportCount:    item.ReportCount,
+			Reasons:        reasons,
+			LastReportedAt: item.LastReportedAt,
+			CreatedAt:      item.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// HideComment hides a comment or reply from readers and resolves its reports
+func (mu *commentModerationUseCase) HideComment(c echo.Context, targetType string, targetID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return mu.setHidden(ctx, targetType, targetID, true)
+}
+
+// RestoreComment shows a hidden comment or reply again and dismisses its reports
+func (mu *commentModerationUseCase) RestoreComment(c echo.Context, targetType string, targetID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return mu.setHidden(ctx, targetType, targetID, false)
+}
+
+func (mu *commentModerationUseCase) GetBannedWords(c echo.Context) ([]dto.BannedWordResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	words, err := mu.moderationRepository.GetBannedWords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.BannedWordResponse, len(words))
+	for i, word := range words {
+		response[i] = toBannedWordResponse(&word)
+	}
+
+	return response, nil
+}
+
+func (mu *commentModerationUseCase) CreateBannedWord(c echo.Context, req *dto.BannedWordRequest) (*dto.BannedWordResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	normalized := normalizeCommentText(req.Word)
+	if normalized == "" {
+		return nil, err_util.ErrInvalidBannedWord
+	}
+
+	word := &entities.BannedWords{
+		ID:   uuid.New(),
+		Word: normalized,
+	}
+
+	created, err := mu.moderationRepository.CreateBannedWord(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, err_util.ErrBannedWordExists
+	}
+
+	response := toBannedWordResponse(word)
+	return &response, nil
+}
+
+func (mu *commentModerationUseCase) DeleteBannedWord(c echo.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := mu.moderationRepository.DeleteBannedWord(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CheckContent rejects content containing a banned word or phrase. Words are
+// compared case-insensitively and only as whole words.
+func (mu *commentModerationUseCase) CheckContent(ctx context.Context, content string) error {
+	words, err := mu.moderationRepository.GetBannedWords(ctx)
+	if err != nil {
+		return err
+	}
+
+	normalized := " " + normalizeCommentText(content) + " "
+	for _, word := range words {
+		if strings.Contains(normalized, " "+word.Word+" ") {
+			return err_util.ErrCommentBlocked
+		}
+	}
+
+	return nil
+}
+
+func (mu *commentModerationUseCase) Report(ctx context.Context, report *entities.ArticleCommentReports) error {
+	created, err := mu.moderationRepository.CreateReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return err_util.ErrAlreadyReported
+	}
+
+	return nil
+}
+
+func (mu *commentModerationUseCase) setHidden(ctx context.Context, targetType string, targetID uuid.UUID, hidden bool) error {
+	if err := mu.moderationRepository.SetHidden(ctx, targetType, targetID, hidden); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// normalizeCommentText lowercases text and keeps only its words separated by single spaces
+func normalizeCommentText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, " ")
+}
+
+func toBannedWordResponse(word *entities.BannedWords) dto.BannedWordResponse {
+	return dto.BannedWordResponse{
+		ID:        word.ID,
+		Word:      word.Word,
+		CreatedAt: word.CreatedAt,
+	}
+}
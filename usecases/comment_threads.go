@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// encodeCommentCursor turns the position of a reply into an opaque cursor
+func encodeCommentCursor(cursor *entities.CommentCursor) string {
+	raw := fmt.Sprintf("%d_%s", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCommentCursor(cursor string) (*entities.CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	nanos, id, found := strings.Cut(string(raw), "_")
+	if !found {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	replyId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CommentCursor{CreatedAt: time.Unix(0, unixNano), ID: replyId}, nil
+}
+
+// getLikedComments returns which of the given comments or replies the user liked
+func (auc *articleUseCase) getLikedComments(ctx context.Context, userId uuid.UUID, targetType string, targetIds []uuid.UUID) (map[uuid.UUID]bool, error) {
+	likedIds, err := auc.articleRepository.GetLikedCommentIDs(ctx, userId, targetType, targetIds)
+	if err != nil {
+		return nil, err
+	}
+
+	liked := make(map[uuid.UUID]bool, len(likedIds))
+	for _, id := range likedIds {
+		liked[id] = true
+	}
+
+	return liked, nil
+}
+
+// notifyReply emails the author of a comment or reply that someone answered it
+func (auc *articleUseCase) notifyReply(ctx context.Context, recipientId uuid.UUID, replierId uuid.UUID, reply *entities.ArticleCommentReplies) {
+	log := logrus.WithField("reply_id", reply.ID)
+
+	recipient, err := auc.userRepository.GetUserByID(ctx, recipientId)
+	if err != nil {
+		log.WithError(err).Error("Failed to get the recipient of a reply notification")
+		return
+	}
+
+	replier, err := auc.userRepository.GetUserByID(ctx, replierId)
+	if err != nil {
+		log.WithError(err).Error("Failed to get the author of a reply")
+		return
+	}
+
+	article, err := auc.articleRepository.GetArticleByID(ctx, reply.ArticleID)
+	if err != nil {
+		log.WithError(err).Error("Failed to get the article of a reply")
+		return
+	}
+
+	link := CanonicalURL(entities.SlugTypeArticle, article.Slug)
+	if link != "" {
+		link += "#comment-" + reply.CommentID.String()
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\n%s replied to you on \"%s\":\n\n%s\n\n%s",
+		recipient.Username,
+		replier.Username,
+		article.Title,
+		reply.Content,
+		link,
+	)
+	if err := auc.emailUtil.SendEmail(recipient.Email, "Kreasi Nusantara New Reply", body); err != nil {
+		log.WithError(err).Error("Failed to send reply notification")
+	}
+}
@@ -251,11 +251,16 @@ func (pduc *productDashboardUseCase) GetHeaderProduct(c echo.Context, req *dto_b
 		return nil, err
 	}
 
+	engagement, err := pduc.productRepository.GetEngagementTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Menghitung total like, comment, visitor, dan share
 	totalLikes := 0
 	totalComments := 0
-	totalVisitors := 14
-	totalShares := 16
+	totalVisitors := engagement.Views
+	totalShares := engagement.Shares
 	for _, article := range article {
 		totalLikes += article.LikesCount
 		totalComments += article.CommentsCount
@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// pendingViewsKey is the Redis hash buffering view counts until they are
+	// flushed, keyed by "<object type>:<object id>"
+	pendingViewsKey = "views:pending"
+	viewedKeyPrefix = "views:seen:"
+	// viewDedupWindow is how long repeated views by the same visitor count once
+	viewDedupWindow = 24 * time.Hour
+)
+
+type EngagementUseCase interface {
+	RecordView(c echo.Context, objectType string, objectID uuid.UUID, visitorID uuid.UUID) error
+	ShareContent(c echo.Context, objectType string, objectID uuid.UUID, userID uuid.UUID, req *dto.ShareRequest) error
+	GetArticleStats(c echo.Context, articleID uuid.UUID) (*dto.ArticleStatsResponse, error)
+	FlushViews(ctx context.Context) error
+	RunViewFlushing(ctx context.Context, interval time.Duration)
+}
+
+type engagementUseCase struct {
+	engagementRepository repositories.EngagementRepository
+	redisClient          redis.RedisClient
+}
+
+func NewEngagementUseCase(engagementRepository repositories.EngagementRepository, redisClient redis.RedisClient) *engagementUseCase {
+	return &engagementUseCase{
+		engagementRepository: engagementRepository,
+		redisClient:          redisClient,
+	}
+}
+
+// RecordView counts a view of the visitor unless they already viewed the same
+// content within the dedup window. Views are buffered in Redis and written to
+// the database by FlushViews.
+func (eu *engagementUseCase) RecordView(c echo.Context, objectType string, objectID uuid.UUID, visitorID uuid.UUID) error {
+	field := fmt.Sprintf("%s:%s", objectType, objectID)
+
+	first, err := eu.redisClient.SetNX(viewedKeyPrefix+field+":"+visitorID.String(), "1", viewDedupWindow)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return nil
+	}
+
+	return eu.redisClient.HIncrBy(pendingViewsKey, field, 1)
+}
+
+func (eu *engagementUseCase) ShareContent(c echo.Context, objectType string, objectID uuid.UUID, userID uuid.UUID, req *dto.ShareRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	exists, err := eu.engagementRepository.ContentExists(ctx, objectType, objectID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return err_util.ErrNotFound
+	}
+
+	share := &entities.ContentShares{
+		ID:         uuid.New(),
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		UserID:     userID,
+		Channel:    req.Channel,
+	}
+
+	return eu.engagementRepository.CreateShare(ctx, share)
+}
+
+func (eu *engagementUseCase) GetArticleStats(c echo.Context, articleID uuid.UUID) (*dto.ArticleStatsResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	article, err := eu.engagementRepository.GetArticleCounters(ctx, articleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	channels, err := eu.engagementRepository.GetShareChannels(ctx, entities.SlugTypeArticle, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	sharesByChannel := make([]dto.ShareChannelResponse, len(channels))
+	for i, channel := range channels {
+		sharesByChannel[i] = dto.ShareChannelResponse{
+			Channel: channel.Channel,
+			Count:   channel.Count,
+		}
+	}
+
+	return &dto.ArticleStatsResponse{
+		ArticleID:       article.ID,
+		Views:           article.ViewsCount + eu.pendingViews(entities.SlugTypeArticle, articleID),
+		Shares:          article.SharesCount,
+		Likes:           article.LikesCount,
+		Comments:        article.CommentsCount,
+		SharesByChannel: sharesByChannel,
+	}, nil
+}
+
+// FlushViews moves the buffered view counts from Redis to the database. Counts
+// that fail to be written are put back so the next flush retries them.
+func (eu *engagementUseCase) FlushViews(ctx context.Context) error {
+	pending, err := eu.redisClient.PopHash(pendingViewsKey)
+	if err != nil {
+		return err
+	}
+
+	views := map[string]map[uuid.UUID]int{}
+	for field, value := range pending {
+		objectType, rawID, found := strings.Cut(field, ":")
+		objectID, idErr := uuid.Parse(rawID)
+		count, countErr := strconv.Atoi(value)
+		if !found || idErr != nil || countErr != nil || count <= 0 {
+			continue
+		}
+
+		if views[objectType] == nil {
+			views[objectType] = map[uuid.UUID]int{}
+		}
+		views[objectType][objectID] += count
+	}
+
+	var flushErr error
+	for objectType, counts := range views {
+		if err := eu.engagementRepository.AddViews(ctx, objectType, counts); err != nil {
+			flushErr = err
+			eu.restorePendingViews(objectType, counts)
+		}
+	}
+
+	return flushErr
+}
+
+// RunViewFlushing periodically flushes buffered view counts. It blocks until
+// ctx is done.
+func (eu *engagementUseCase) RunViewFlushing(ctx context.Context, interval time.Duration) {
+	log := logrus.New()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := eu.FlushViews(ctx); err != nil {
+				log.WithError(err).Error("Failed to flush view counts")
+			}
+		}
+	}
+}
+
+// pendingViews returns the views of an object that have not been flushed yet
+func (eu *engagementUseCase) pendingViews(objectType string, objectID uuid.UUID) int {
+	value, err := eu.redisClient.HGet(pendingViewsKey, fmt.Sprintf("%s:%s", objectType, objectID))
+	if err != nil {
+		return 0
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+func (eu *engagementUseCase) restorePendingViews(objectType string, counts map[uuid.UUID]int) {
+	for objectID, count := range counts {
+		field := fmt.Sprintf("%s:%s", objectType, objectID)
+		if err := eu.redisClient.HIncrBy(pendingViewsKey, field, int64(count)); err != nil {
+			logrus.WithError(err).WithField("object", field).Error("Failed to restore pending views")
+		}
+	}
+}
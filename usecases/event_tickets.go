@@ -0,0 +1,365 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/email"
+	err_util "kreasi-nusantara-api/utils/error"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type EventTicketUseCase interface {
+	GetMyTickets(c echo.Context, userID uuid.UUID) ([]dto.EventTicketResponse, error)
+	TransferTicket(c echo.Context, userID uuid.UUID, ticketID uuid.UUID, req *dto.EventTicketTransferRequest) (*dto.EventTicketResponse, error)
+
+	// Admin
+	VerifyTicket(c echo.Context, code string) (*dto.EventTicketResponse, error)
+	GetTicketTransfers(c echo.Context, eventID uuid.UUID) ([]dto.EventTicketTransferResponse, error)
+	UpdateTicketTransferSetting(c echo.Context, eventID uuid.UUID, req *dto.EventTicketTransferSettingRequest) error
+
+	IssueTickets(ctx context.Context, transactionID string) error
+	RevokeTickets(ctx context.Context, transactionID uuid.UUID) error
+	InvalidateTickets(ctx context.Context, transactionID string) error
+}
+
+type eventTicketUseCase struct {
+	ticketRepository           repositories.EventTicketRepository
+	eventTransactionRepository repositories.EventTransactionRepository
+	eventAdminRepository       repositories.EventAdminRepository
+	userRepository             repositories.UserRepository
+	emailUtil                  email.EmailUtil
+}
+
+func NewEventTicketUseCase(ticketRepository repositories.EventTicketRepository, eventTransactionRepository repositories.EventTransactionRepository, eventAdminRepository repositories.EventAdminRepository, userRepository repositories.UserRepository, emailUtil email.EmailUtil) *eventTicketUseCase {
+	return &eventTicketUseCase{
+		ticketRepository:           ticketRepository,
+		eventTransactionRepository: eventTransactionRepository,
+		eventAdminRepository:       eventAdminRepository,
+		userRepository:             userRepository,
+		emailUtil:                  emailUtil,
+	}
+}
+
+func (tu *eventTicketUseCase) GetMyTickets(c echo.Context, userID uuid.UUID) ([]dto.EventTicketResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	user, err := tu.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := tu.ticketRepository.GetTicketsByHolder(ctx, user.ID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.EventTicketResponse, len(tickets))
+	for i, ticket := range tickets {
+		response[i] = toEventTicketResponse(&ticket, true)
+	}
+
+	return response, nil
+}
+
+func (tu *eventTicketUseCase) TransferTicket(c echo.Context, userID uuid.UUID, ticketID uuid.UUID, req *dto.EventTicketTransferRequest) (*dto.EventTicketResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	ticket, err := tu.ticketRepository.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	user, err := tu.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isTicketHolder(ticket, user) {
+		return nil, err_util.ErrForbiddenResource
+	}
+
+	if !isTicketTransferable(ticket) {
+		return nil, err_util.ErrTicketTransferDisabled
+	}
+
+	// Resolve the recipient, linking the ticket to their account when they have one
+	var recipientID *uuid.UUID
+	recipientEmail := strings.ToLower(strings.TrimSpace(req.Email))
+	if req.UserID != nil {
+		recipient, err := tu.userRepository.GetUserByID(ctx, *req.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err_util.ErrInvalidTransferRecipient
+			}
+			return nil, err
+		}
+		recipientID = &recipient.ID
+		recipientEmail = recipient.Email
+	} else {
+		recipient, err := tu.userRepository.GetUserByEmail(ctx, recipientEmail)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			recipientID = &recipient.ID
+		}
+	}
+
+	if (recipientID != nil && *recipientID == user.ID) || strings.EqualFold(recipientEmail, ticket.Email) {
+		return nil, err_util.ErrInvalidTransferRecipient
+	}
+
+	code, err := generateTicketCode()
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &entities.EventTicketTransfer{
+		ID:            uuid.New(),
+		EventTicketID: ticket.ID,
+		EventID:       ticket.EventID,
+		FromUserID:    &user.ID,
+		FromFullName:  ticket.FullName,
+		FromEmail:     ticket.Email,
+		ToUserID:      recipientID,
+		ToFullName:    req.FullName,
+		ToEmail:       recipientEmail,
+		RevokedCode:   ticket.Code,
+	}
+
+	// A fresh code invalidates the QR code the previous holder received
+	previousHolderID := ticket.HolderUserID
+	ticket.HolderUserID = recipientID
+	ticket.Code = code
+	ticket.IdentityNumber = req.IdentityNumber
+	ticket.FullName = req.FullName
+	ticket.Email = recipientEmail
+	ticket.Phone = req.Phone
+
+	if err := tu.ticketRepository.TransferTicket(ctx, ticket, previousHolderID, transfer); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTicketChanged
+		}
+		return nil, err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\n%s has transferred a ticket for %s (%s) to you.\n\nYour ticket code: %s",
+		ticket.FullName,
+		transfer.FromFullName,
+		ticket.Event.Name,
+		ticket.Event.Date.Format("02-01-2006"),
+		ticket.Code,
+	)
+	if err := tu.emailUtil.SendEmail(ticket.Email, "Kreasi Nusantara Ticket Transfer", body); err != nil {
+		logrus.WithError(err).Error("Failed to send ticket transfer email")
+	}
+
+	// The new code belongs to the recipient only, and so does the email of an
+	// account the sender picked by id
+	response := toEventTicketResponse(ticket, false)
+	response.Code = ""
+	if req.UserID != nil {
+		response.Holder.Email = ""
+	}
+	return &response, nil
+}
+
+func (tu *eventTicketUseCase) VerifyTicket(c echo.Context, code string) (*dto.EventTicketResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	ticket, err := tu.ticketRepository.GetTicketByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Tickets only admit while their booking stays paid
+	transaction, err := tu.eventTransactionRepository.GetTransactionByID(ctx, ticket.EventTransactionID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+	if transaction.TransactionStatus != "paid" {
+		return nil, err_util.ErrNotFound
+	}
+
+	response := toEventTicketResponse(ticket, false)
+	return &response, nil
+}
+
+func (tu *eventTicketUseCase) GetTicketTransfers(c echo.Context, eventID uuid.UUID) ([]dto.EventTicketTransferResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	transfers, err := tu.ticketRepository.GetTransfersByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.EventTicketTransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		response[i] = dto.EventTicketTransferResponse{
+			ID:            transfer.ID,
+			EventTicketID: transfer.EventTicketID,
+			FromUserID:    transfer.FromUserID,
+			FromFullName:  transfer.FromFullName,
+			FromEmail:     transfer.FromEmail,
+			ToUserID:      transfer.ToUserID,
+			ToFullName:    transfer.ToFullName,
+			ToEmail:       transfer.ToEmail,
+			RevokedCode:   transfer.RevokedCode,
+			CreatedAt:     transfer.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+func (tu *eventTicketUseCase) UpdateTicketTransferSetting(c echo.Context, eventID uuid.UUID, req *dto.EventTicketTransferSettingRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return tu.ticketRepository.UpdateTicketTransferSetting(ctx, eventID, *req.AllowTicketTransfer)
+}
+
+// IssueTickets creates one ticket per seat of a paid transaction. It is safe to call
+// again for the same transaction since tickets are only issued once.
+func (tu *eventTicketUseCase) IssueTickets(ctx context.Context, transactionID string) error {
+	transaction, err := tu.eventTransactionRepository.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if transaction.TransactionStatus != "paid" {
+		return nil
+	}
+
+	issued, err := tu.ticketRepository.CountTicketsByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	if issued > 0 {
+		return nil
+	}
+
+	price, err := tu.eventAdminRepository.GetPriceByID(ctx, transaction.EventPriceID)
+	if err != nil {
+		return err
+	}
+
+	tickets := make([]entities.EventTicket, transaction.Quantity)
+	for i := range tickets {
+		code, err := generateTicketCode()
+		if err != nil {
+			return err
+		}
+
+		tickets[i] = entities.EventTicket{
+			ID:                 uuid.New(),
+			EventTransactionID: transaction.ID,
+			EventID:            price.EventID,
+			HolderUserID:       &transaction.UserId,
+			Code:               code,
+			IdentityNumber:     transaction.Buyer.IdentityNumber,
+			FullName:           transaction.Buyer.FullName,
+			Email:              transaction.Buyer.Email,
+			Phone:              transaction.Buyer.Phone,
+		}
+	}
+
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	return tu.ticketRepository.CreateTickets(ctx, tickets)
+}
+
+// RevokeTickets removes the tickets of a cancelled booking. Bookings whose tickets
+// were handed to someone else are left untouched.
+func (tu *eventTicketUseCase) RevokeTickets(ctx context.Context, transactionID uuid.UUID) error {
+	transfers, err := tu.ticketRepository.CountTransfersByTransactionID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if transfers > 0 {
+		return err_util.ErrTicketTransferred
+	}
+
+	return tu.ticketRepository.DeleteTicketsByTransactionID(ctx, transactionID)
+}
+
+// InvalidateTickets removes the tickets of a booking whose payment was
+// cancelled, rejected or refunded, including tickets already transferred to
+// someone else. The transfer history is kept.
+func (tu *eventTicketUseCase) InvalidateTickets(ctx context.Context, transactionID string) error {
+	id, err := uuid.Parse(transactionID)
+	if err != nil {
+		return err
+	}
+
+	return tu.ticketRepository.DeleteTicketsByTransactionID(ctx, id)
+}
+
+func isTicketHolder(ticket *entities.EventTicket, user *entities.User) bool {
+	if ticket.HolderUserID != nil {
+		return *ticket.HolderUserID == user.ID
+	}
+	return strings.EqualFold(ticket.Email, user.Email)
+}
+
+// isTicketTransferable reports whether the event still accepts transfers: the
+// organiser allows it and the event day has not passed yet.
+func isTicketTransferable(ticket *entities.EventTicket) bool {
+	return ticket.Event.AllowTicketTransfer && time.Now().Before(ticket.Event.Date.AddDate(0, 0, 1))
+}
+
+func toEventTicketResponse(ticket *entities.EventTicket, holder bool) dto.EventTicketResponse {
+	return dto.EventTicketResponse{
+		ID:                 ticket.ID,
+		EventTransactionID: ticket.EventTransactionID,
+		EventID:            ticket.EventID,
+		EventName:          ticket.Event.Name,
+		EventDate:          ticket.Event.Date.Format("02-01-2006"),
+		Code:               ticket.Code,
+		Holder: dto.BuyerInformation{
+			IdentityNumber: ticket.IdentityNumber,
+			FullName:       ticket.FullName,
+			Email:          ticket.Email,
+			Phone:          ticket.Phone,
+		},
+		Transferable: holder && isTicketTransferable(ticket),
+	}
+}
+
+func generateTicketCode() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(bytes)), nil
+}
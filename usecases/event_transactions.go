@@ -2,12 +2,14 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"kreasi-nusantara-api/config"
 	"kreasi-nusantara-api/drivers/redis"
 	"kreasi-nusantara-api/dto"
 	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,24 +17,30 @@ import (
 	"github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/snap"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type EventTransactionUseCase interface {
 	CreateEventTransaction(c echo.Context, userID uuid.UUID, request dto.EventTransactionRequest) (dto.EventTransactionResponse, error)
 	GetEventTransactionById(c echo.Context, transactionId uuid.UUID) (dto.EventTransactionResponse, error)
+	CancelFreeTransaction(c echo.Context, userID uuid.UUID, transactionId uuid.UUID) error
 }
 
 type eventTransactionUseCase struct {
 	eventTransactionRepository repositories.EventTransactionRepository
 	eventPriceRepository       repositories.EventAdminRepository
+	waitlistUseCase            EventWaitlistUseCase
+	ticketUseCase              EventTicketUseCase
 	redisClient                redis.RedisClient
 	config                     config.MidtransConfig
 }
 
-func NewEventTransactionUseCase(eventTransactionRepository repositories.EventTransactionRepository, eventPriceRepository repositories.EventAdminRepository, redisClient redis.RedisClient, config config.MidtransConfig) *eventTransactionUseCase {
+func NewEventTransactionUseCase(eventTransactionRepository repositories.EventTransactionRepository, eventPriceRepository repositories.EventAdminRepository, waitlistUseCase EventWaitlistUseCase, ticketUseCase EventTicketUseCase, redisClient redis.RedisClient, config config.MidtransConfig) *eventTransactionUseCase {
 	return &eventTransactionUseCase{
 		eventTransactionRepository: eventTransactionRepository,
 		eventPriceRepository:       eventPriceRepository,
+		waitlistUseCase:            waitlistUseCase,
+		ticketUseCase:              ticketUseCase,
 		redisClient:                redisClient,
 		config:                     config,
 	}
@@ -51,6 +59,25 @@ func (eu *eventTransactionUseCase) CreateEventTransaction(c echo.Context, userID
 		return dto.EventTransactionResponse{}, err
 	}
 
+	// RSVP-only events admit each user once, which is checked while booking
+	event, err := eu.eventPriceRepository.GetEventsByID(ctx, price.EventID)
+	if err != nil {
+		return dto.EventTransactionResponse{}, err
+	}
+
+	var rsvpEventID *uuid.UUID
+	if event.RSVPOnly {
+		rsvpEventID = &event.ID
+
+		// An RSVP is a single seat for the user themselves
+		request.Quantity = 1
+	}
+
+	reservation, err := eu.waitlistUseCase.ClaimTickets(ctx, userID, price, request.Quantity, request.ReservationToken)
+	if err != nil {
+		return dto.EventTransactionResponse{}, err
+	}
+
 	fmt.Println("transaction buyer in", request.IdentityNumber)
 
 	transactionData.ID = uuid.New()
@@ -66,33 +93,55 @@ func (eu *eventTransactionUseCase) CreateEventTransaction(c echo.Context, userID
 	transactionData.Buyer.Email = request.Email
 	transactionData.Buyer.Phone = request.Phone
 
-	req := &snap.Request{
-		TransactionDetails: midtrans.TransactionDetails{
-			OrderID:  transactionData.ID.String(),
-			GrossAmt: int64(transactionData.TotalAmount),
-		},
+	// Free tickets are confirmed right away, without a payment gateway charge
+	isFree := price.Price == 0
+	if isFree {
+		transactionData.TransactionStatus = "paid"
+		transactionData.TransactionMethod = "free"
 	}
 
-	var client snap.Client
-	client.New(eu.config.ServerKey, midtrans.Sandbox)
-
-	snapResp, err := client.CreateTransaction(req)
-	if snapResp == nil {
-		return dto.EventTransactionResponse{}, err
+	// Seats are checked again while the transaction is stored, other checkouts
+	// may have taken them since ClaimTickets
+	var reservationID *uuid.UUID
+	if reservation != nil {
+		reservationID = &reservation.ID
 	}
-
-	transactionData.SnapURL = snapResp.RedirectURL
-
-	err = eu.eventTransactionRepository.CreateTransaction(ctx, &transactionData)
+	result, err := eu.eventTransactionRepository.BookTransaction(ctx, &transactionData, reservationID, rsvpEventID)
 	if err != nil {
 		log.WithError(err).Error("Failed to create event transaction")
 		return dto.EventTransactionResponse{}, err
 	}
+	switch result {
+	case entities.BookingSoldOut:
+		return dto.EventTransactionResponse{}, err_util.ErrTicketSoldOut
+	case entities.BookingReservationInvalid:
+		return dto.EventTransactionResponse{}, err_util.ErrInvalidReservation
+	case entities.BookingAlreadyRSVPed:
+		return dto.EventTransactionResponse{}, err_util.ErrAlreadyRSVPed
+	}
 
-	key := "transaction-" + transactionData.ID.String()
-	err = eu.redisClient.Set(key, "event", time.Hour*1)
-	if err != nil {
-		return dto.EventTransactionResponse{}, err
+	if isFree {
+		if err := eu.ticketUseCase.IssueTickets(ctx, transactionData.ID.String()); err != nil {
+			log.WithError(err).Error("Failed to issue free event tickets")
+		}
+	} else {
+		// The seats are held by the pending transaction, so it is only charged now
+		snapURL, err := eu.createCharge(&transactionData)
+		if err != nil {
+			eu.cancelUncharged(ctx, transactionData.ID)
+			return dto.EventTransactionResponse{}, err
+		}
+		transactionData.SnapURL = snapURL
+
+		if err := eu.eventTransactionRepository.UpdateSnapURL(ctx, transactionData.ID, snapURL); err != nil {
+			return dto.EventTransactionResponse{}, err
+		}
+
+		key := "transaction-" + transactionData.ID.String()
+		err = eu.redisClient.Set(key, "event", time.Hour*1)
+		if err != nil {
+			return dto.EventTransactionResponse{}, err
+		}
 	}
 
 	return dto.EventTransactionResponse{
@@ -111,6 +160,41 @@ func (eu *eventTransactionUseCase) CreateEventTransaction(c echo.Context, userID
 	}, nil
 }
 
+// createCharge opens the Midtrans payment page of a transaction
+func (eu *eventTransactionUseCase) createCharge(transaction *entities.EventTransaction) (string, error) {
+	req := &snap.Request{
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  transaction.ID.String(),
+			GrossAmt: int64(transaction.TotalAmount),
+		},
+	}
+
+	var client snap.Client
+	client.New(eu.config.ServerKey, midtrans.Sandbox)
+
+	snapResp, err := client.CreateTransaction(req)
+	if snapResp == nil {
+		return "", err
+	}
+
+	return snapResp.RedirectURL, nil
+}
+
+// cancelUncharged cancels a transaction whose charge could not be created and
+// hands its seats to the waitlist
+func (eu *eventTransactionUseCase) cancelUncharged(ctx context.Context, transactionId uuid.UUID) {
+	log := logrus.New()
+
+	if err := eu.eventTransactionRepository.UpdateTransactionStatus(ctx, transactionId, "canceled"); err != nil {
+		log.WithError(err).Error("Failed to cancel uncharged event transaction")
+		return
+	}
+
+	if err := eu.waitlistUseCase.ReleaseTicketsForTransaction(ctx, transactionId.String()); err != nil {
+		log.WithError(err).Error("Failed to release tickets of uncharged event transaction")
+	}
+}
+
 func (eu *eventTransactionUseCase) GetEventTransactionById(c echo.Context, transactionId uuid.UUID) (dto.EventTransactionResponse, error) {
 	transactionData, err := eu.eventTransactionRepository.GetTransactionByID(c.Request().Context(), transactionId.String())
 	if err != nil {
@@ -131,3 +215,50 @@ func (eu *eventTransactionUseCase) GetEventTransactionById(c echo.Context, trans
 		SnapURL:           transactionData.SnapURL,
 	}, nil
 }
+
+// CancelFreeTransaction cancels a confirmed free booking or RSVP, revoking its
+// tickets and handing the seats to the waitlist.
+func (eu *eventTransactionUseCase) CancelFreeTransaction(c echo.Context, userID uuid.UUID, transactionId uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	transaction, err := eu.eventTransactionRepository.GetTransactionByID(ctx, transactionId.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	if transaction.UserId != userID {
+		return err_util.ErrForbiddenResource
+	}
+
+	if transaction.TotalAmount > 0 || transaction.TransactionStatus != "paid" {
+		return err_util.ErrBookingNotCancellable
+	}
+
+	price, err := eu.eventPriceRepository.GetPriceByID(ctx, transaction.EventPriceID)
+	if err != nil {
+		return err
+	}
+
+	event, err := eu.eventPriceRepository.GetEventsByID(ctx, price.EventID)
+	if err != nil {
+		return err
+	}
+
+	if !time.Now().Before(event.Date.AddDate(0, 0, 1)) {
+		return err_util.ErrBookingNotCancellable
+	}
+
+	if err := eu.ticketUseCase.RevokeTickets(ctx, transaction.ID); err != nil {
+		return err
+	}
+
+	if err := eu.eventTransactionRepository.UpdateTransactionStatus(ctx, transaction.ID, "canceled"); err != nil {
+		return err
+	}
+
+	return eu.waitlistUseCase.ReleaseTicketsForTransaction(ctx, transaction.ID.String())
+}
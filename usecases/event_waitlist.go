@@ -0,0 +1,349 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	"kreasi-nusantara-api/utils/email"
+	err_util "kreasi-nusantara-api/utils/error"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// reservationWindow is how long a notified waitlist user may hold released tickets.
+const reservationWindow = 30 * time.Minute
+
+type EventWaitlistUseCase interface {
+	JoinWaitlist(c echo.Context, userID uuid.UUID, priceID uuid.UUID, req *dto.EventWaitlistRequest) (*dto.EventWaitlistResponse, error)
+	LeaveWaitlist(c echo.Context, userID uuid.UUID, priceID uuid.UUID) error
+	GetMyWaitlist(c echo.Context, userID uuid.UUID) ([]dto.EventWaitlistResponse, error)
+
+	// Ticket availability
+	ClaimTickets(ctx context.Context, userID uuid.UUID, price *entities.EventPrices, quantity int, reservationToken string) (*entities.EventWaitlist, error)
+	ReleaseTickets(ctx context.Context, priceID uuid.UUID) error
+	ReleaseTicketsForTransaction(ctx context.Context, transactionID string) error
+	RunReservationExpiry(ctx context.Context, interval time.Duration)
+}
+
+type eventWaitlistUseCase struct {
+	waitlistRepository         repositories.EventWaitlistRepository
+	eventTransactionRepository repositories.EventTransactionRepository
+	eventAdminRepository       repositories.EventAdminRepository
+	emailUtil                  email.EmailUtil
+}
+
+func NewEventWaitlistUseCase(waitlistRepository repositories.EventWaitlistRepository, eventTransactionRepository repositories.EventTransactionRepository, eventAdminRepository repositories.EventAdminRepository, emailUtil email.EmailUtil) *eventWaitlistUseCase {
+	return &eventWaitlistUseCase{
+		waitlistRepository:         waitlistRepository,
+		eventTransactionRepository: eventTransactionRepository,
+		eventAdminRepository:       eventAdminRepository,
+		emailUtil:                  emailUtil,
+	}
+}
+
+func (wu *eventWaitlistUseCase) JoinWaitlist(c echo.Context, userID uuid.UUID, priceID uuid.UUID, req *dto.EventWaitlistRequest) (*dto.EventWaitlistResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	price, err := wu.eventAdminRepository.GetPriceByID(ctx, priceID)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := wu.availableTickets(ctx, price, nil)
+	if err != nil {
+		return nil, err
+	}
+	if available >= req.Quantity {
+		return nil, err_util.ErrTicketsStillAvailable
+	}
+
+	_, err = wu.waitlistRepository.GetActiveWaitlist(ctx, priceID, userID)
+	if err == nil {
+		return nil, err_util.ErrAlreadyOnWaitlist
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	waitlist := &entities.EventWaitlist{
+		ID:           uuid.New(),
+		EventPriceID: priceID,
+		UserID:       userID,
+		Quantity:     req.Quantity,
+		Status:       entities.WaitlistStatusWaiting,
+	}
+
+	if err := wu.waitlistRepository.CreateWaitlist(ctx, waitlist); err != nil {
+		return nil, err
+	}
+
+	if ticketType, err := wu.eventAdminRepository.GetTicketTypeByID(ctx, price.TicketTypeID); err == nil {
+		price.TicketType = *ticketType
+	}
+
+	waitlist.EventPrice = *price
+	return wu.toWaitlistResponse(ctx, waitlist)
+}
+
+func (wu *eventWaitlistUseCase) LeaveWaitlist(c echo.Context, userID uuid.UUID, priceID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	waitlist, err := wu.waitlistRepository.GetActiveWaitlist(ctx, priceID, userID)
+	if err != nil {
+		return err
+	}
+
+	wasNotified := waitlist.Status == entities.WaitlistStatusNotified
+
+	waitlist.Status = entities.WaitlistStatusCancelled
+	waitlist.ReservationToken = nil
+	waitlist.ReservedUntil = nil
+	if err := wu.waitlistRepository.UpdateWaitlist(ctx, waitlist); err != nil {
+		return err
+	}
+
+	// Hand the reserved seats over to the next user in line
+	if wasNotified {
+		return wu.ReleaseTickets(ctx, priceID)
+	}
+
+	return nil
+}
+
+func (wu *eventWaitlistUseCase) GetMyWaitlist(c echo.Context, userID uuid.UUID) ([]dto.EventWaitlistResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	waitlists, err := wu.waitlistRepository.GetWaitlistsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.EventWaitlistResponse, 0, len(waitlists))
+	for i := range waitlists {
+		item, err := wu.toWaitlistResponse(ctx, &waitlists[i])
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *item)
+	}
+
+	return response, nil
+}
+
+// ClaimTickets checks that the requested quantity can be sold. Seats held by
+// notified waitlist users are only available through their reservation token.
+func (wu *eventWaitlistUseCase) ClaimTickets(ctx context.Context, userID uuid.UUID, price *entities.EventPrices, quantity int, reservationToken string) (*entities.EventWaitlist, error) {
+	if quantity <= 0 {
+		return nil, err_util.ErrInvalidTicketQuantity
+	}
+
+	var reservation *entities.EventWaitlist
+
+	if reservationToken != "" {
+		var err error
+		reservation, err = wu.waitlistRepository.GetReservationByToken(ctx, reservationToken)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err_util.ErrInvalidReservation
+			}
+			return nil, err
+		}
+
+		if reservation.UserID != userID ||
+			reservation.EventPriceID != price.ID ||
+			reservation.Status != entities.WaitlistStatusNotified ||
+			reservation.ReservedUntil == nil ||
+			reservation.ReservedUntil.Before(time.Now()) ||
+			quantity > reservation.Quantity {
+			return nil, err_util.ErrInvalidReservation
+		}
+	}
+
+	var excludeID *uuid.UUID
+	if reservation != nil {
+		excludeID = &reservation.ID
+	}
+
+	available, err := wu.availableTickets(ctx, price, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	if available < quantity {
+		return nil, err_util.ErrTicketSoldOut
+	}
+
+	return reservation, nil
+}
+
+// ReleaseTickets offers the currently free seats of a ticket tier to the
+// waitlist in joining order. Users asking for more seats than are free keep
+// their place while smaller requests behind them are served.
+func (wu *eventWaitlistUseCase) ReleaseTickets(ctx context.Context, priceID uuid.UUID) error {
+	log := logrus.New()
+
+	price, err := wu.eventAdminRepository.GetPriceByID(ctx, priceID)
+	if err != nil {
+		return err
+	}
+
+	available, err := wu.availableTickets(ctx, price, nil)
+	if err != nil {
+		return err
+	}
+	if available <= 0 {
+		return nil
+	}
+
+	waitlists, err := wu.waitlistRepository.GetWaitingList(ctx, priceID)
+	if err != nil {
+		return err
+	}
+
+	var eventName string
+	if event, err := wu.eventAdminRepository.GetEventsByID(ctx, price.EventID); err == nil {
+		eventName = event.Name
+	}
+
+	for i := range waitlists {
+		if available <= 0 {
+			break
+		}
+
+		waitlist := &waitlists[i]
+		if waitlist.Quantity > available {
+			continue
+		}
+
+		token, err := generateReservationToken()
+		if err != nil {
+			return err
+		}
+		reservedUntil := time.Now().Add(reservationWindow)
+
+		waitlist.Status = entities.WaitlistStatusNotified
+		waitlist.ReservationToken = &token
+		waitlist.ReservedUntil = &reservedUntil
+		if err := wu.waitlistRepository.UpdateWaitlist(ctx, waitlist); err != nil {
+			return err
+		}
+		available -= waitlist.Quantity
+
+		link := fmt.Sprintf("%s/events/%s/checkout?price_id=%s&reservation=%s", os.Getenv("FRONTEND_URL"), price.EventID, price.ID, token)
+		body := fmt.Sprintf(
+			"Good news! %d ticket(s) for %s are now reserved for you.\n\nComplete your checkout before %s:\n%s",
+			waitlist.Quantity,
+			eventName,
+			reservedUntil.Format("02-01-2006 15:04"),
+			link,
+		)
+		if err := wu.emailUtil.SendEmail(waitlist.User.Email, "Kreasi Nusantara Ticket Waitlist", body); err != nil {
+			log.WithError(err).Error("Failed to send waitlist notification")
+		}
+	}
+
+	return nil
+}
+
+func (wu *eventWaitlistUseCase) ReleaseTicketsForTransaction(ctx context.Context, transactionID string) error {
+	transaction, err := wu.eventTransactionRepository.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	return wu.ReleaseTickets(ctx, transaction.EventPriceID)
+}
+
+// RunReservationExpiry periodically expires unused reservations and offers
+// their seats to the next users in line. It blocks until ctx is done.
+func (wu *eventWaitlistUseCase) RunReservationExpiry(ctx context.Context, interval time.Duration) {
+	log := logrus.New()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			priceIDs, err := wu.waitlistRepository.ExpireReservations(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("Failed to expire waitlist reservations")
+				continue
+			}
+
+			for _, priceID := range priceIDs {
+				if err := wu.ReleaseTickets(ctx, priceID); err != nil {
+					log.WithError(err).Error("Failed to release waitlist tickets")
+				}
+			}
+		}
+	}
+}
+
+func (wu *eventWaitlistUseCase) availableTickets(ctx context.Context, price *entities.EventPrices, excludeReservation *uuid.UUID) (int, error) {
+	sold, err := wu.eventTransactionRepository.CountSoldTickets(ctx, price.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	reserved, err := wu.waitlistRepository.CountReservedTickets(ctx, price.ID, excludeReservation)
+	if err != nil {
+		return 0, err
+	}
+
+	return price.NoOfTicket - int(sold) - int(reserved), nil
+}
+
+func (wu *eventWaitlistUseCase) toWaitlistResponse(ctx context.Context, waitlist *entities.EventWaitlist) (*dto.EventWaitlistResponse, error) {
+	response := &dto.EventWaitlistResponse{
+		ID:           waitlist.ID,
+		EventID:      waitlist.EventPrice.EventID,
+		EventPriceID: waitlist.EventPriceID,
+		TicketType: dto.EventTicketTypeResponse{
+			ID:   waitlist.EventPrice.TicketType.ID,
+			Name: waitlist.EventPrice.TicketType.Name,
+		},
+		Quantity:  waitlist.Quantity,
+		Status:    waitlist.Status,
+		CreatedAt: waitlist.CreatedAt.Format("02-01-2006 15:04"),
+	}
+
+	switch waitlist.Status {
+	case entities.WaitlistStatusWaiting:
+		ahead, err := wu.waitlistRepository.CountWaitingAhead(ctx, waitlist)
+		if err != nil {
+			return nil, err
+		}
+		response.Position = int(ahead) + 1
+	case entities.WaitlistStatusNotified:
+		if waitlist.ReservationToken != nil {
+			response.ReservationToken = *waitlist.ReservationToken
+		}
+		if waitlist.ReservedUntil != nil {
+			response.ReservedUntil = waitlist.ReservedUntil.Format("02-01-2006 15:04")
+		}
+	}
+
+	return response, nil
+}
+
+func generateReservationToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"kreasi-nusantara-api/dto"
 	dto_base "kreasi-nusantara-api/dto/base"
+	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/repositories"
 	err_util "kreasi-nusantara-api/utils/error"
 	"math"
@@ -24,6 +25,13 @@ type EventUseCase interface {
 
 	GetEventsByMonthYear(c echo.Context, year int, month int) ([]dto.EventResponse, error)
 	GetEventsByDate(c echo.Context, date time.Time) ([]dto.EventResponse, error)
+
+	GetNearbyEvents(c echo.Context, req *dto.EventNearbyRequest) ([]dto.EventNearbyResponse, error)
+
+	// Event Review
+	CreateEventReview(c echo.Context, userId uuid.UUID, eventId uuid.UUID, req *dto.EventReviewRequest) error
+	GetEventReviews(c echo.Context, eventId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.EventReviewResponse, *dto_base.PaginationMetadata, *dto_base.Link, error)
+	GetCategoryRatings(c echo.Context) ([]dto.EventCategoryRatingResponse, error)
 }
 
 type eventUseCase struct {
@@ -67,6 +75,7 @@ func (euc *eventUseCase) GetEvents(c echo.Context, req *dto_base.PaginationReque
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    *event.Photos[0].Image,
 			Category: event.Category.Name,
@@ -74,12 +83,18 @@ func (euc *eventUseCase) GetEvents(c echo.Context, req *dto_base.PaginationReque
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, nil, nil, err
+	}
+
 	totalPage := int(math.Ceil(float64(totalData) / float64(req.Limit)))
 	paginationMetadata := &dto_base.PaginationMetadata{
 		TotalData:   totalData,
@@ -117,17 +132,22 @@ func (euc *eventUseCase) GetEventByID(c echo.Context, eventId uuid.UUID) (*dto.E
 	}
 
 	eventDetailResponse := &dto.EventDetailResponse{
-		ID:     event.ID,
-		Name:   event.Name,
-		Images: make([]string, len(event.Photos)),
-		Date:   event.Date.Format("02-01-2006"),
+		ID:           event.ID,
+		Slug:         event.Slug,
+		CanonicalURL: CanonicalURL(entities.SlugTypeEvent, event.Slug),
+		Name:         event.Name,
+		Images:       make([]string, len(event.Photos)),
+		Date:         event.Date.Format("02-01-2006"),
 		Location: dto.EventLocationDetail{
 			Subdistrict: event.Location.Subdistrict,
 			City:        event.Location.City,
 			Building:    event.Location.Building,
+			Latitude:    event.Location.Latitude,
+			Longitude:   event.Location.Longitude,
 		},
 		Description: event.Description,
 		Ticket:      make([]dto.EventPricesResponse, len(event.Prices)),
+		RSVPOnly:    event.RSVPOnly,
 	}
 
 	for i, img := range event.Photos {
@@ -148,6 +168,24 @@ func (euc *eventUseCase) GetEventByID(c echo.Context, eventId uuid.UUID) (*dto.E
 		}
 	}
 
+	ratings, err := euc.ratingSummaries(ctx, []uuid.UUID{event.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	eventDetailResponse.AverageRating = ratings[event.ID].AverageRating
+	eventDetailResponse.TotalReview = ratings[event.ID].TotalReview
+
+	latestReviews, err := euc.eventRepository.GetLatestEventReviews(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventDetailResponse.LatestReview = make([]*dto.EventReviewResponse, len(latestReviews))
+	for i, review := range latestReviews {
+		eventDetailResponse.LatestReview[i] = toEventReviewResponse(review)
+	}
+
 	return eventDetailResponse, nil
 }
 
@@ -182,6 +220,7 @@ func (euc *eventUseCase) GetEventsByCategory(c echo.Context, categoryId int, req
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    *event.Photos[0].Image,
 			Category: event.Category.Name,
@@ -189,12 +228,18 @@ func (euc *eventUseCase) GetEventsByCategory(c echo.Context, categoryId int, req
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, nil, nil, err
+	}
+
 	totalPage := int(math.Ceil(float64(totalData) / float64(req.Limit)))
 	paginationMetadata := &dto_base.PaginationMetadata{
 		TotalData:   totalData,
@@ -242,6 +287,7 @@ func (euc *eventUseCase) SearchEvents(c echo.Context, req *dto_base.SearchReques
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    *event.Photos[0].Image,
 			Category: event.Category.Name,
@@ -249,12 +295,18 @@ func (euc *eventUseCase) SearchEvents(c echo.Context, req *dto_base.SearchReques
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, nil, err
+	}
+
 	metadataResponse := &dto_base.MetadataResponse{
 		TotalData:   int(totalData),
 		TotalCount:  int(totalData),
@@ -285,6 +337,7 @@ func (euc *eventUseCase) GetUpcomingEvents(c echo.Context) ([]dto.EventResponse,
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    *event.Photos[0].Image,
 			Category: event.Category.Name,
@@ -292,12 +345,18 @@ func (euc *eventUseCase) GetUpcomingEvents(c echo.Context) ([]dto.EventResponse,
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, err
+	}
+
 	return eventResponse, nil
 }
 
@@ -329,6 +388,7 @@ func (euc *eventUseCase) GetEventsByMonthYear(c echo.Context, year int, month in
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    imageUrl,
 			Category: event.Category.Name,
@@ -336,12 +396,18 @@ func (euc *eventUseCase) GetEventsByMonthYear(c echo.Context, year int, month in
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, err
+	}
+
 	return eventResponse, nil
 }
 
@@ -372,6 +438,7 @@ func (euc *eventUseCase) GetEventsByDate(c echo.Context, date time.Time) ([]dto.
 
 		eventResponse[i] = dto.EventResponse{
 			ID:       event.ID,
+			Slug:     event.Slug,
 			Name:     event.Name,
 			Image:    imageUrl,
 			Category: event.Category.Name,
@@ -379,11 +446,264 @@ func (euc *eventUseCase) GetEventsByDate(c echo.Context, date time.Time) ([]dto.
 				Building:    event.Location.Building,
 				Subdistrict: event.Location.Subdistrict,
 				City:        event.Location.City,
+				Latitude:    event.Location.Latitude,
+				Longitude:   event.Location.Longitude,
 			},
 			Date:     event.Date.Format("02-01-2006"),
 			MinPrice: minPrice,
 		}
 	}
 
+	if err := euc.applyRatings(ctx, eventResponse); err != nil {
+		return nil, err
+	}
+
+	return eventResponse, nil
+}
+
+func (euc *eventUseCase) GetNearbyEvents(c echo.Context, req *dto.EventNearbyRequest) ([]dto.EventNearbyResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	distances, err := euc.eventRepository.GetNearbyEvents(ctx, req.Latitude, req.Longitude, req.Radius, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(distances) == 0 {
+		return []dto.EventNearbyResponse{}, nil
+	}
+
+	eventIds := make([]uuid.UUID, len(distances))
+	for i, distance := range distances {
+		eventIds[i] = distance.EventID
+	}
+
+	events, err := euc.eventRepository.GetEventsByIDs(ctx, eventIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := euc.ratingSummaries(ctx, eventIds)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsById := make(map[uuid.UUID]entities.Events, len(events))
+	for _, event := range events {
+		eventsById[event.ID] = event
+	}
+
+	// Keep the distance ordering returned by the repository
+	eventResponse := make([]dto.EventNearbyResponse, 0, len(distances))
+	for _, distance := range distances {
+		event, ok := eventsById[distance.EventID]
+		if !ok {
+			continue
+		}
+
+		minPrice := math.MaxInt64
+		for _, price := range event.Prices {
+			if price.Price < minPrice {
+				minPrice = price.Price
+			}
+		}
+
+		var imageUrl string
+		if len(event.Photos) > 0 && event.Photos[0].Image != nil {
+			imageUrl = *event.Photos[0].Image
+		}
+
+		eventResponse = append(eventResponse, dto.EventNearbyResponse{
+			EventResponse: dto.EventResponse{
+				ID:       event.ID,
+				Slug:     event.Slug,
+				Name:     event.Name,
+				Image:    imageUrl,
+				Category: event.Category.Name,
+				Location: dto.EventLocationDetail{
+					Building:    event.Location.Building,
+					Subdistrict: event.Location.Subdistrict,
+					City:        event.Location.City,
+					Latitude:    event.Location.Latitude,
+					Longitude:   event.Location.Longitude,
+				},
+				Date:          event.Date.Format("02-01-2006"),
+				MinPrice:      minPrice,
+				AverageRating: ratings[event.ID].AverageRating,
+				TotalReview:   ratings[event.ID].TotalReview,
+			},
+			Distance: math.Round(distance.Distance*100) / 100,
+		})
+	}
+
 	return eventResponse, nil
-}
\ No newline at end of file
+}
+
+func (euc *eventUseCase) CreateEventReview(c echo.Context, userId uuid.UUID, eventId uuid.UUID, req *dto.EventReviewRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	event, err := euc.eventRepository.GetEventByID(ctx, eventId)
+	if err != nil {
+		return err
+	}
+
+	if event.ID == uuid.Nil {
+		return err_util.ErrNotFound
+	}
+
+	// An event only counts as ended once its whole day has passed
+	if time.Now().Before(event.Date.AddDate(0, 0, 1)) {
+		return err_util.ErrEventNotEnded
+	}
+
+	attended, err := euc.eventRepository.HasAttendedEvent(ctx, userId, eventId)
+	if err != nil {
+		return err
+	}
+
+	if !attended {
+		return err_util.ErrNotEventAttendee
+	}
+
+	reviewed, err := euc.eventRepository.HasReviewedEvent(ctx, userId, eventId)
+	if err != nil {
+		return err
+	}
+
+	if reviewed {
+		return err_util.ErrAlreadyReviewedEvent
+	}
+
+	eventReview := &entities.EventReviews{
+		ID:      uuid.New(),
+		UserID:  userId,
+		EventID: eventId,
+		Rating:  req.Rating,
+		Review:  req.Review,
+	}
+
+	return euc.eventRepository.CreateEventReview(ctx, eventReview)
+}
+
+func (euc *eventUseCase) GetEventReviews(c echo.Context, eventId uuid.UUID, req *dto_base.PaginationRequest) ([]dto.EventReviewResponse, *dto_base.PaginationMetadata, *dto_base.Link, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	baseURL := fmt.Sprintf(
+		"%s?limit=%d&page=",
+		c.Request().URL.Path,
+		req.Limit,
+	)
+
+	var (
+		next = baseURL + strconv.Itoa(req.Page+1)
+		prev = baseURL + strconv.Itoa(req.Page-1)
+	)
+
+	reviews, totalData, err := euc.eventRepository.GetEventReviews(ctx, eventId, req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	eventReviewResponse := make([]dto.EventReviewResponse, len(reviews))
+	for i, review := range reviews {
+		eventReviewResponse[i] = *toEventReviewResponse(review)
+	}
+
+	totalPage := int(math.Ceil(float64(totalData) / float64(req.Limit)))
+	meta := &dto_base.PaginationMetadata{
+		TotalData:   totalData,
+		TotalPage:   totalPage,
+		CurrentPage: req.Page,
+	}
+
+	if req.Page > totalPage {
+		return nil, nil, nil, err_util.ErrPageNotFound
+	}
+
+	if req.Page == 1 {
+		prev = ""
+	}
+
+	if req.Page == totalPage {
+		next = ""
+	}
+
+	link := &dto_base.Link{
+		Next: next,
+		Prev: prev,
+	}
+
+	return eventReviewResponse, meta, link, nil
+}
+
+func (euc *eventUseCase) GetCategoryRatings(c echo.Context) ([]dto.EventCategoryRatingResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	summaries, err := euc.eventRepository.GetEventCategoryRatingSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryRatingResponse := make([]dto.EventCategoryRatingResponse, len(summaries))
+	for i, summary := range summaries {
+		categoryRatingResponse[i] = dto.EventCategoryRatingResponse{
+			ID:            summary.CategoryID,
+			Name:          summary.Name,
+			AverageRating: summary.AverageRating,
+			TotalReview:   summary.TotalReview,
+		}
+	}
+
+	return categoryRatingResponse, nil
+}
+
+// ratingSummaries returns the rating summary of each given event keyed by event ID.
+// Events without reviews are absent from the map, so lookups yield a zero summary.
+func (euc *eventUseCase) ratingSummaries(ctx context.Context, eventIds []uuid.UUID) (map[uuid.UUID]entities.EventRatingSummary, error) {
+	summaries, err := euc.eventRepository.GetEventRatingSummaries(ctx, eventIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make(map[uuid.UUID]entities.EventRatingSummary, len(summaries))
+	for _, summary := range summaries {
+		ratings[summary.EventID] = summary
+	}
+
+	return ratings, nil
+}
+
+func (euc *eventUseCase) applyRatings(ctx context.Context, eventResponse []dto.EventResponse) error {
+	eventIds := make([]uuid.UUID, len(eventResponse))
+	for i, event := range eventResponse {
+		eventIds[i] = event.ID
+	}
+
+	ratings, err := euc.ratingSummaries(ctx, eventIds)
+	if err != nil {
+		return err
+	}
+
+	for i := range eventResponse {
+		eventResponse[i].AverageRating = ratings[eventResponse[i].ID].AverageRating
+		eventResponse[i].TotalReview = ratings[eventResponse[i].ID].TotalReview
+	}
+
+	return nil
+}
+
+func toEventReviewResponse(review entities.EventReviews) *dto.EventReviewResponse {
+	return &dto.EventReviewResponse{
+		User: dto.UserReview{
+			ImageURL: review.User.Photo,
+			Username: review.User.Username,
+		},
+		Rating:    review.Rating,
+		Review:    review.Review,
+		CreatedAt: review.CreatedAt,
+	}
+}
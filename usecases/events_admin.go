@@ -44,15 +44,26 @@ type EventAdminUseCase interface {
 	GetPricesByEventID(c echo.Context, eventID uuid.UUID) ([]dto.EventPricesResponse, error)
 	GetDetailPrices(c echo.Context, priceID uuid.UUID) (*dto.EventPricesResponse, error)
 	DeletePrices(c echo.Context, priceID uuid.UUID) error
+
+	// Venues
+	GetVenues(c echo.Context) ([]dto.EventLocationResponse, error)
+	CreateVenue(c echo.Context, req *dto.EventLocationRequest) (*dto.EventLocationResponse, error)
+	UpdateVenue(c echo.Context, venueID uuid.UUID, req *dto.EventLocationRequest) error
+	DeleteVenue(c echo.Context, venueID uuid.UUID) error
+	UpsertPostalCodeCentroids(c echo.Context, req *dto.PostalCodeCentroidsRequest) error
 }
 
 type eventAdminUseCase struct {
 	eventAdminRepository repositories.EventAdminRepository
+	waitlistUseCase      EventWaitlistUseCase
+	slugUseCase          SlugUseCase
 }
 
-func NewEventAdminUseCase(eventAdminRepository repositories.EventAdminRepository) *eventAdminUseCase {
+func NewEventAdminUseCase(eventAdminRepository repositories.EventAdminRepository, waitlistUseCase EventWaitlistUseCase, slugUseCase SlugUseCase) *eventAdminUseCase {
 	return &eventAdminUseCase{
 		eventAdminRepository: eventAdminRepository,
+		waitlistUseCase:      waitlistUseCase,
+		slugUseCase:          slugUseCase,
 	}
 }
 
@@ -198,26 +209,32 @@ func (pu *eventAdminUseCase) CreateEventsAdmin(c echo.Context, req *dto.EventReq
 		}
 	}
 
+	// Event RSVP hanya boleh memiliki tiket gratis
+	rsvpOnly := req.RSVPOnly != nil && *req.RSVPOnly
+	if rsvpOnly && hasPaidTicket(price) {
+		return err_util.ErrPaidTicketOnRSVPEvent
+	}
+
 	// Parse date
 	date, err := time.Parse("02-01-2006", req.Date)
 	if err != nil {
 		return err
 	}
 
-	// Process and save location
-	locationID := uuid.New()
-	location := entities.EventLocations{
-		ID:          locationID,
-		Building:    req.Location.Building,
-		Address:     req.Location.Address,
-		Province:    req.Location.Province,
-		City:        req.Location.City,
-		Subdistrict: req.Location.Subdistrict,
-		PostalCode:  req.Location.PostalCode,
-	}
-
-	if err := pu.eventAdminRepository.CreateLocation(ctx, &location); err != nil {
-		return err
+	// Reuse an existing venue or save a new one
+	var locationID uuid.UUID
+	if req.LocationID != nil {
+		location, err := pu.eventAdminRepository.GetLocationByID(ctx, *req.LocationID)
+		if err != nil {
+			return err
+		}
+		locationID = location.ID
+	} else {
+		location := pu.newVenue(ctx, uuid.New(), req.Location)
+		if err := pu.eventAdminRepository.CreateLocation(ctx, location); err != nil {
+			return err
+		}
+		locationID = location.ID
 	}
 
 	event := entities.Events{
@@ -229,6 +246,7 @@ func (pu *eventAdminUseCase) CreateEventsAdmin(c echo.Context, req *dto.EventReq
 		Photos:      photos,
 		Prices:      price,
 		CategoryID:  req.CategoryID,
+		RSVPOnly:    rsvpOnly,
 	}
 
 	// Save event
@@ -236,6 +254,10 @@ func (pu *eventAdminUseCase) CreateEventsAdmin(c echo.Context, req *dto.EventReq
 		return err
 	}
 
+	if _, err := pu.slugUseCase.AssignSlug(ctx, entities.SlugTypeEvent, event.ID, event.Name); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -300,11 +322,13 @@ func (pu *eventAdminUseCase) GetEventByID(c echo.Context, eventID uuid.UUID) (*d
 	}
 
 	eventResponse := dto.EventAdminDetailResponse{
-		ID:          event.ID,
-		Name:        event.Name,
-		Status:      status,
-		Date:        event.Date.Format("2006-01-02"),
-		Description: event.Description,
+		ID:                  event.ID,
+		Name:                event.Name,
+		Status:              status,
+		AllowTicketTransfer: event.AllowTicketTransfer,
+		RSVPOnly:            event.RSVPOnly,
+		Date:                event.Date.Format("2006-01-02"),
+		Description:         event.Description,
 		Category: dto.EventCategoriesResponse{
 			ID:   category.ID,
 			Name: category.Name,
@@ -318,6 +342,8 @@ func (pu *eventAdminUseCase) GetEventByID(c echo.Context, eventID uuid.UUID) (*d
 			City:        location.City,
 			Subdistrict: location.Subdistrict,
 			PostalCode:  location.PostalCode,
+			Latitude:    location.Latitude,
+			Longitude:   location.Longitude,
 		},
 		Photos: photos,
 	}
@@ -418,21 +444,39 @@ func (pu *eventAdminUseCase) UpdateEventsAdmin(c echo.Context, eventID uuid.UUID
 		existingEvent.Date = date
 	}
 
-	// Update location details (if required)
-	if req.Location.Building != "" || req.Location.Address != "" || req.Location.City != "" || req.Location.Subdistrict != "" || req.Location.PostalCode != "" {
-		location := entities.EventLocations{
-			ID:          existingEvent.LocationID,
-			Building:    req.Location.Building,
-			Address:     req.Location.Address,
-			Province:    req.Location.Province,
-			City:        req.Location.City,
-			Subdistrict: req.Location.Subdistrict,
-			PostalCode:  req.Location.PostalCode,
+	// Switch to another venue or update the current one (if required). Only the
+	// given location fields change, and a venue shared with other events is left
+	// as it is, this event gets an updated copy.
+	if req.LocationID != nil {
+		location, err := pu.eventAdminRepository.GetLocationByID(ctx, *req.LocationID)
+		if err != nil {
+			return err
+		}
+		existingEvent.LocationID = location.ID
+	} else if req.Location != nil {
+		events, err := pu.eventAdminRepository.CountEventsByLocationID(ctx, existingEvent.LocationID)
+		if err != nil {
+			return err
 		}
 
-		if err := pu.eventAdminRepository.UpdateLocation(ctx, &location); err != nil {
+		current, err := pu.eventAdminRepository.GetLocationByID(ctx, existingEvent.LocationID)
+		if err != nil {
 			return err
 		}
+		venue := overlayVenue(current, req.Location)
+
+		if events > 1 {
+			location := pu.newVenue(ctx, uuid.New(), venue)
+			if err := pu.eventAdminRepository.CreateLocation(ctx, location); err != nil {
+				return err
+			}
+			existingEvent.LocationID = location.ID
+		} else {
+			location := pu.newVenue(ctx, existingEvent.LocationID, venue)
+			if err := pu.eventAdminRepository.UpdateLocation(ctx, location); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Update photos
@@ -475,8 +519,22 @@ func (pu *eventAdminUseCase) UpdateEventsAdmin(c echo.Context, eventID uuid.UUID
 		existingEvent.Prices = prices
 	}
 
+	// Event RSVP hanya boleh memiliki tiket gratis
+	if req.RSVPOnly != nil {
+		existingEvent.RSVPOnly = *req.RSVPOnly
+	}
+	if existingEvent.RSVPOnly && hasPaidTicket(existingEvent.Prices) {
+		return err_util.ErrPaidTicketOnRSVPEvent
+	}
+
 	// Save updated event in database
-	return pu.eventAdminRepository.UpdateEventsAdmin(ctx, eventID, existingEvent)
+	if err := pu.eventAdminRepository.UpdateEventsAdmin(ctx, eventID, existingEvent); err != nil {
+		return err
+	}
+
+	// Nama yang berubah menghasilkan slug baru, slug lama tetap dialihkan ke slug baru
+	_, err = pu.slugUseCase.AssignSlug(ctx, entities.SlugTypeEvent, eventID, existingEvent.Name)
+	return err
 }
 
 func (pu *eventAdminUseCase) DeleteEventsAdmin(c echo.Context, eventID uuid.UUID) error {
@@ -726,6 +784,19 @@ func (pu *eventAdminUseCase) UpdatePrices(c echo.Context, priceID uuid.UUID, req
 		return err
 	}
 
+	previousQuota := price.NoOfTicket
+
+	// Event RSVP hanya boleh memiliki tiket gratis
+	if req.Price > 0 {
+		event, err := pu.eventAdminRepository.GetEventsByID(ctx, price.EventID)
+		if err != nil {
+			return err
+		}
+		if event.RSVPOnly {
+			return err_util.ErrPaidTicketOnRSVPEvent
+		}
+	}
+
 	// Update data harga berdasarkan request
 	price.Price = req.Price
 	price.NoOfTicket = req.NoOfTicket
@@ -737,5 +808,179 @@ func (pu *eventAdminUseCase) UpdatePrices(c echo.Context, priceID uuid.UUID, req
 		return err
 	}
 
+	// Tawarkan kuota tambahan ke waitlist
+	if price.NoOfTicket > previousQuota {
+		return pu.waitlistUseCase.ReleaseTickets(ctx, priceID)
+	}
+
 	return nil
 }
+
+// Venues
+
+func (pu *eventAdminUseCase) GetVenues(c echo.Context) ([]dto.EventLocationResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	locations, err := pu.eventAdminRepository.GetLocation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.EventLocationResponse, len(locations))
+	for i, location := range locations {
+		response[i] = toEventLocationResponse(&location)
+	}
+
+	return response, nil
+}
+
+func (pu *eventAdminUseCase) CreateVenue(c echo.Context, req *dto.EventLocationRequest) (*dto.EventLocationResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	location := pu.newVenue(ctx, uuid.New(), req)
+	if err := pu.eventAdminRepository.CreateLocation(ctx, location); err != nil {
+		return nil, err
+	}
+
+	response := toEventLocationResponse(location)
+	return &response, nil
+}
+
+func (pu *eventAdminUseCase) UpdateVenue(c echo.Context, venueID uuid.UUID, req *dto.EventLocationRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := pu.eventAdminRepository.GetLocationByID(ctx, venueID); err != nil {
+		return err
+	}
+
+	location := pu.newVenue(ctx, venueID, req)
+	return pu.eventAdminRepository.UpdateLocation(ctx, location)
+}
+
+func (pu *eventAdminUseCase) DeleteVenue(c echo.Context, venueID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if _, err := pu.eventAdminRepository.GetLocationByID(ctx, venueID); err != nil {
+		return err
+	}
+
+	count, err := pu.eventAdminRepository.CountEventsByLocationID(ctx, venueID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return err_util.ErrVenueInUse
+	}
+
+	return pu.eventAdminRepository.DeleteLocation(ctx, venueID)
+}
+
+func (pu *eventAdminUseCase) UpsertPostalCodeCentroids(c echo.Context, req *dto.PostalCodeCentroidsRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	centroids := make([]entities.PostalCodeCentroids, len(req.Centroids))
+	for i, centroid := range req.Centroids {
+		centroids[i] = entities.PostalCodeCentroids{
+			PostalCode: strings.TrimSpace(centroid.PostalCode),
+			Latitude:   centroid.Latitude,
+			Longitude:  centroid.Longitude,
+		}
+	}
+
+	return pu.eventAdminRepository.UpsertPostalCodeCentroids(ctx, centroids)
+}
+
+// newVenue builds a venue from the request. When the admin does not supply
+// coordinates they are derived from the postal code centroid, if one is known.
+func (pu *eventAdminUseCase) newVenue(ctx context.Context, venueID uuid.UUID, req *dto.EventLocationRequest) *entities.EventLocations {
+	location := &entities.EventLocations{
+		ID:          venueID,
+		Building:    req.Building,
+		Address:     req.Address,
+		Province:    req.Province,
+		City:        req.City,
+		Subdistrict: req.Subdistrict,
+		PostalCode:  req.PostalCode,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}
+
+	if location.Latitude == nil || location.Longitude == nil {
+		centroid, err := pu.eventAdminRepository.GetPostalCodeCentroid(ctx, strings.TrimSpace(req.PostalCode))
+		if err == nil {
+			location.Latitude = &centroid.Latitude
+			location.Longitude = &centroid.Longitude
+		}
+	}
+
+	return location
+}
+
+// overlayVenue returns the venue as a request with the non-empty fields of req
+// applied. The current coordinates are kept unless new ones are given or the
+// postal code changes.
+func overlayVenue(location *entities.EventLocations, req *dto.EventLocationRequest) *dto.EventLocationRequest {
+	venue := &dto.EventLocationRequest{
+		Building:    location.Building,
+		Address:     location.Address,
+		Province:    location.Province,
+		City:        location.City,
+		Subdistrict: location.Subdistrict,
+		PostalCode:  location.PostalCode,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+	}
+
+	if req.Building != "" {
+		venue.Building = req.Building
+	}
+	if req.Address != "" {
+		venue.Address = req.Address
+	}
+	if req.Province != "" {
+		venue.Province = req.Province
+	}
+	if req.City != "" {
+		venue.City = req.City
+	}
+	if req.Subdistrict != "" {
+		venue.Subdistrict = req.Subdistrict
+	}
+	if req.PostalCode != "" && req.PostalCode != location.PostalCode {
+		venue.PostalCode = req.PostalCode
+		venue.Latitude, venue.Longitude = nil, nil
+	}
+	if req.Latitude != nil && req.Longitude != nil {
+		venue.Latitude, venue.Longitude = req.Latitude, req.Longitude
+	}
+
+	return venue
+}
+
+func toEventLocationResponse(location *entities.EventLocations) dto.EventLocationResponse {
+	return dto.EventLocationResponse{
+		ID:          location.ID,
+		Building:    location.Building,
+		Address:     location.Address,
+		Province:    location.Province,
+		City:        location.City,
+		Subdistrict: location.Subdistrict,
+		PostalCode:  location.PostalCode,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+	}
+}
+
+func hasPaidTicket(prices []entities.EventPrices) bool {
+	for _, price := range prices {
+		if price.Price > 0 {
+			return true
+		}
+	}
+	return false
+}
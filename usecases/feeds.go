@@ -0,0 +1,357 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"mime"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// Supported feed formats
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+)
+
+const (
+	maxFeedItems    = 50
+	feedTitle       = "Kreasi Nusantara"
+	feedDescription = "The latest culture articles from Kreasi Nusantara"
+)
+
+type FeedUseCase interface {
+	GetFeedVersion(c echo.Context, format string, tagSlug string) (*dto.FeedVersion, error)
+	RenderFeed(c echo.Context, format string, tagSlug string) ([]byte, error)
+}
+
+type feedUseCase struct {
+	feedRepository repositories.FeedRepository
+	tagRepository  repositories.TagRepository
+}
+
+func NewFeedUseCase(feedRepository repositories.FeedRepository, tagRepository repositories.TagRepository) *feedUseCase {
+	return &feedUseCase{
+		feedRepository: feedRepository,
+		tagRepository:  tagRepository,
+	}
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary,omitempty"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// feedItem is an article prepared for either feed format
+type feedItem struct {
+	article   entities.Articles
+	link      string
+	author    string
+	published time.Time
+	tags      []entities.ArticleTagName
+}
+
+// GetFeedVersion returns the ETag and modification time of a feed. It only
+// reads a summary of the feed, so unchanged feeds can be answered cheaply.
+func (fu *feedUseCase) GetFeedVersion(c echo.Context, format string, tagSlug string) (*dto.FeedVersion, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tagID, err := fu.resolveTag(ctx, tagSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	stamp, err := fu.feedRepository.GetFeedStamp(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d|%d", format, tagSlug, stamp.Count, stamp.LastModified.UnixNano())))
+
+	return &dto.FeedVersion{
+		ETag:         `"` + hex.EncodeToString(hash[:12]) + `"`,
+		LastModified: stamp.LastModified,
+	}, nil
+}
+
+// RenderFeed renders the latest published articles, optionally limited to a
+// tag, as an RSS 2.0 or Atom 1.0 document
+func (fu *feedUseCase) RenderFeed(c echo.Context, format string, tagSlug string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tagID, err := fu.resolveTag(ctx, tagSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := fu.feedRepository.GetFeedArticles(ctx, tagID, maxFeedItems)
+	if err != nil {
+		return nil, err
+	}
+
+	articleIDs := make([]uuid.UUID, len(articles))
+	for i, article := range articles {
+		articleIDs[i] = article.ID
+	}
+
+	tagNames, err := fu.feedRepository.GetArticleTagNames(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsByArticle := map[uuid.UUID][]entities.ArticleTagName{}
+	for _, tag := range tagNames {
+		tagsByArticle[tag.ArticleID] = append(tagsByArticle[tag.ArticleID], tag)
+	}
+
+	items := make([]feedItem, len(articles))
+	var updated time.Time
+	for i, article := range articles {
+		published := article.CreatedAt
+		if article.PublishedAt != nil {
+			published = *article.PublishedAt
+		}
+
+		items[i] = feedItem{
+			article:   article,
+			link:      CanonicalURL(entities.SlugTypeArticle, article.Slug),
+			author:    feedAuthorName(article.Author),
+			published: published.UTC(),
+			tags:      tagsByArticle[article.ID],
+		}
+
+		if article.UpdatedAt.After(updated) {
+			updated = article.UpdatedAt
+		}
+		if published.After(updated) {
+			updated = published
+		}
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	title := feedTitle
+	siteLink := os.Getenv("FRONTEND_URL") + "/articles"
+	if tagSlug != "" {
+		title = fmt.Sprintf("%s - #%s", feedTitle, tagSlug)
+		siteLink = fmt.Sprintf("%s/tags/%s", os.Getenv("FRONTEND_URL"), tagSlug)
+	}
+	selfLink := c.Scheme() + "://" + c.Request().Host + c.Request().URL.Path
+
+	var document interface{}
+	switch format {
+	case FeedFormatAtom:
+		document = buildAtomFeed(title, siteLink, selfLink, updated.UTC(), items)
+	default:
+		document = buildRSSFeed(title, siteLink, selfLink, updated.UTC(), items)
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// resolveTag returns the ID of the tag with the given slug, or nil for the feed of all articles
+func (fu *feedUseCase) resolveTag(ctx context.Context, tagSlug string) (*uuid.UUID, error) {
+	if tagSlug == "" {
+		return nil, nil
+	}
+
+	tag, err := fu.tagRepository.GetTagBySlug(ctx, tagSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &tag.ID, nil
+}
+
+func buildRSSFeed(title string, siteLink string, selfLink string, updated time.Time, items []feedItem) rssFeed {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         title,
+			Link:          siteLink,
+			Description:   feedDescription,
+			SelfLink:      atomLink{Href: selfLink, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: updated.Format(time.RFC1123Z),
+			Items:         make([]rssItem, len(items)),
+		},
+	}
+
+	for i, item := range items {
+		rss := rssItem{
+			Title:       item.article.Title,
+			Link:        item.link,
+			GUID:        rssGUID{IsPermaLink: "false", Value: "urn:uuid:" + item.article.ID.String()},
+			Description: item.article.Excerpt,
+			PubDate:     item.published.Format(time.RFC1123Z),
+			Creator:     item.author,
+		}
+
+		for _, tag := range item.tags {
+			rss.Categories = append(rss.Categories, tag.Name)
+		}
+
+		if item.article.Image != "" {
+			rss.Enclosure = &rssEnclosure{URL: item.article.Image, Type: imageMIMEType(item.article.Image), Length: "0"}
+		}
+
+		feed.Channel.Items[i] = rss
+	}
+
+	return feed
+}
+
+func buildAtomFeed(title string, siteLink string, selfLink string, updated time.Time, items []feedItem) atomFeed {
+	feed := atomFeed{
+		Title:   title,
+		ID:      selfLink,
+		Updated: updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfLink, Rel: "self", Type: "application/atom+xml"},
+			{Href: siteLink, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, len(items)),
+	}
+
+	for i, item := range items {
+		entry := atomEntry{
+			Title:     item.article.Title,
+			ID:        "urn:uuid:" + item.article.ID.String(),
+			Updated:   item.article.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: item.published.Format(time.RFC3339),
+			Summary:   item.article.Excerpt,
+			Author:    atomAuthor{Name: item.author},
+		}
+
+		if item.link != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.link, Rel: "alternate", Type: "text/html"})
+		}
+		if item.article.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.article.Image, Rel: "enclosure", Type: imageMIMEType(item.article.Image)})
+		}
+
+		for _, tag := range item.tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag.Slug, Label: tag.Name})
+		}
+
+		feed.Entries[i] = entry
+	}
+
+	return feed
+}
+
+func feedAuthorName(author *entities.Admin) string {
+	if author == nil {
+		return feedTitle
+	}
+
+	name := strings.TrimSpace(author.FirstName + " " + author.LastName)
+	if name == "" {
+		return author.Username
+	}
+	return name
+}
+
+// imageMIMEType guesses the MIME type of an image from its URL, defaulting to JPEG
+func imageMIMEType(imageURL string) string {
+	extension := path.Ext(strings.SplitN(imageURL, "?", 2)[0])
+	if mimeType := mime.TypeByExtension(strings.ToLower(extension)); strings.HasPrefix(mimeType, "image/") {
+		return mimeType
+	}
+	return "image/jpeg"
+}
@@ -28,11 +28,13 @@ type ProductUseCase interface {
 
 type productUseCase struct {
 	productRepository repositories.ProductRepository
+	tagUseCase        TagUseCase
 }
 
-func NewProductUseCase(productRepository repositories.ProductRepository) *productUseCase {
+func NewProductUseCase(productRepository repositories.ProductRepository, tagUseCase TagUseCase) *productUseCase {
 	return &productUseCase{
 		productRepository: productRepository,
+		tagUseCase:        tagUseCase,
 	}
 }
 
@@ -83,6 +85,7 @@ func (puc *productUseCase) GetProducts(c echo.Context, req *dto_base.PaginationR
 
         productResponse[i] = dto.ProductResponse{
             ID:              product.ID,
+            Slug:            product.Slug,
             Image:           imageUrl,
             Name:            product.Name,
             OriginalPrice:   product.ProductPricing.OriginalPrice,
@@ -140,6 +143,11 @@ func (puc *productUseCase) GetProductByID(c echo.Context, productId uuid.UUID) (
         return nil, err
     }
 
+    tags, err := puc.tagUseCase.GetProductTags(ctx, productId)
+    if err != nil {
+        return nil, err
+    }
+
     var latestReviewResponses []*dto.ProductReviewResponse
     for _, review := range latestReviews {
         if review != nil {
@@ -157,6 +165,8 @@ func (puc *productUseCase) GetProductByID(c echo.Context, productId uuid.UUID) (
 
     productDetailResponse := &dto.ProductDetailResponse{
         ID:              product.ID,
+        Slug:            product.Slug,
+        CanonicalURL:    CanonicalURL(entities.SlugTypeProduct, product.Slug),
         Name:            product.Name,
         Description:     product.Description,
         Images:          make([]string, len(product.ProductImages)),
@@ -168,6 +178,7 @@ func (puc *productUseCase) GetProductByID(c echo.Context, productId uuid.UUID) (
         TotalReview:     totalReview,
         LatestReview:   latestReviewResponses,
         Variants:        make([]dto.ProductVariantResponse, len(*product.ProductVariants)),
+        Tags:            tags,
     }
 
     for i, img := range product.ProductImages {
@@ -229,6 +240,7 @@ func (puc *productUseCase) GetProductsByCategory(c echo.Context, categoryId int,
         summary := ratingReviewMap[product.ID]
         productResponse[i] = dto.ProductResponse{
             ID:              product.ID,
+            Slug:            product.Slug,
             Image:           imageUrl,
             Name:            product.Name,
             OriginalPrice:   product.ProductPricing.OriginalPrice,
@@ -295,6 +307,7 @@ func (puc *productUseCase) SearchProducts(c echo.Context, req *dto_base.SearchRe
         summary := ratingReviewMap[product.ID]
         productResponse[i] = dto.ProductResponse{
             ID:              product.ID,
+            Slug:            product.Slug,
             Image:           imageUrl,
             Name:            product.Name,
             OriginalPrice:   product.ProductPricing.OriginalPrice,
@@ -36,13 +36,17 @@ type productAdminUseCase struct {
 	productAdminRepository repositories.ProductAdminRepository
 	productRepository      repositories.ProductRepository
 	tokenUtil              token.TokenUtil
+	tagUseCase             TagUseCase
+	slugUseCase            SlugUseCase
 }
 
-func NewProductAdminUseCase(productAdminRepository repositories.ProductAdminRepository, tokenUtil token.TokenUtil, productRepository repositories.ProductRepository) *productAdminUseCase {
+func NewProductAdminUseCase(productAdminRepository repositories.ProductAdminRepository, tokenUtil token.TokenUtil, productRepository repositories.ProductRepository, tagUseCase TagUseCase, slugUseCase SlugUseCase) *productAdminUseCase {
 	return &productAdminUseCase{
 		productAdminRepository: productAdminRepository,
 		tokenUtil:              tokenUtil,
 		productRepository:      productRepository,
+		tagUseCase:             tagUseCase,
+		slugUseCase:            slugUseCase,
 	}
 }
 
@@ -146,6 +150,15 @@ func (pu *productAdminUseCase) CreateProduct(c echo.Context, req *dto.ProductReq
 
 	productID := uuid.New()
 
+	var tags []entities.Tags
+	if req.Tags != nil {
+		resolved, err := pu.tagUseCase.ResolveTags(ctx, *req.Tags)
+		if err != nil {
+			return err
+		}
+		tags = resolved
+	}
+
 	// Upload images and get URLs
 	productImages := make([]entities.ProductImages, len(req.ProductImages))
 	for i, images := range req.ProductImages {
@@ -204,7 +217,15 @@ func (pu *productAdminUseCase) CreateProduct(c echo.Context, req *dto.ProductReq
 		},
 	}
 
-	return pu.productAdminRepository.CreateProduct(ctx, product)
+	if err := pu.productAdminRepository.CreateProduct(ctx, product); err != nil {
+		return err
+	}
+
+	if _, err := pu.slugUseCase.AssignSlug(ctx, entities.SlugTypeProduct, productID, product.Name); err != nil {
+		return err
+	}
+
+	return pu.tagUseCase.SetProductTags(ctx, productID, tags)
 }
 
 func (pu *productAdminUseCase) GetAllProduct(c echo.Context, req *dto_base.PaginationRequest) (*[]dto.ProductResponseAdmin, *dto_base.PaginationMetadata, *dto_base.Link, error) {
@@ -339,6 +360,14 @@ func (pu *productAdminUseCase) UpdateProduct(c echo.Context, productID uuid.UUID
 		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
 	}
 
+	var tags []entities.Tags
+	if req.Tags != nil {
+		tags, err = pu.tagUseCase.ResolveTags(ctx, *req.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
 	discountPrice := float64(req.ProductPricing.OriginalPrice) * (1 - float64(*req.ProductPricing.DiscountPercent)/100)
 	// Update the product details
 	existingProduct.Name = req.Name
@@ -388,7 +417,20 @@ func (pu *productAdminUseCase) UpdateProduct(c echo.Context, productID uuid.UUID
 	existingProduct.ProductVideos = videos
 
 	// Save the updated product
-	return pu.productAdminRepository.UpdateProduct(ctx, productID, existingProduct)
+	if err := pu.productAdminRepository.UpdateProduct(ctx, productID, existingProduct); err != nil {
+		return err
+	}
+
+	// A renamed product gets a new slug, the old one keeps redirecting
+	if _, err := pu.slugUseCase.AssignSlug(ctx, entities.SlugTypeProduct, productID, existingProduct.Name); err != nil {
+		return err
+	}
+
+	if req.Tags == nil {
+		return nil
+	}
+
+	return pu.tagUseCase.SetProductTags(ctx, productID, tags)
 }
 
 func (pu *productAdminUseCase) DeleteProduct(c echo.Context, productID uuid.UUID) error {
@@ -542,6 +584,12 @@ func (pu *productAdminUseCase) GetProductByID(c echo.Context, productID uuid.UUI
 		})
 	}
 
+	// Mengambil tag produk
+	tags, err := pu.tagUseCase.GetProductTags(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Membuat respons produk akhir dengan menggabungkan semua informasi yang diperlukan
 	productResponse := dto.ProductResponse{
 		ID:           product.ID,
@@ -559,6 +607,7 @@ func (pu *productAdminUseCase) GetProductByID(c echo.Context, productID uuid.UUI
 		ProductImages:   photos,
 		ProductVideos:   videos,
 		Rating:          ratingMap[product.ID].AverageRating,
+		Tags:            tags,
 	}
 
 	return &productResponse, nil
@@ -87,6 +87,7 @@ func (rc *recommendationUseCase) GetProductRecommendation(c echo.Context, userId
 			}
 			recommendationProductResponse = append(recommendationProductResponse, dto.ProductResponse{
 				ID:              product.ID,
+				Slug:            product.Slug,
 				Image:           productImage,
 				Name:            product.Name,
 				OriginalPrice:   product.ProductPricing.OriginalPrice,
@@ -169,6 +170,7 @@ func (rc *recommendationUseCase) GetProductRecommendation(c echo.Context, userId
 
 		recommendationProductResponse = append(recommendationProductResponse, dto.ProductResponse{
 			ID:              product.ID,
+			Slug:            product.Slug,
 			Image:           productImage,
 			Name:            product.Name,
 			OriginalPrice:   product.ProductPricing.OriginalPrice,
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxRelatedArticles = 4
+	// relatedCandidateLimit is how many articles are scored when looking for related ones
+	relatedCandidateLimit = 100
+	// textSimilarityWeight is what identical titles and excerpts count for,
+	// relative to a single shared tag
+	textSimilarityWeight = 3.0
+)
+
+// similarityStopWords are frequent Indonesian and English words that say
+// nothing about the topic of an article
+var similarityStopWords = map[string]bool{
+	"yang": true, "dan": true, "dari": true, "untuk": true, "dengan": true, "dalam": true,
+	"pada": true, "ini": true, "itu": true, "atau": true, "juga": true, "akan": true,
+	"adalah": true, "sebagai": true, "oleh": true, "karena": true, "tidak": true, "lebih": true,
+	"the": true, "and": true, "for": true, "with": true, "from": true, "this": true,
+	"that": true, "are": true, "was": true, "how": true, "what": true, "your": true,
+}
+
+// rankRelatedArticles scores candidates by the tags they share with the
+// article and the overlap of their titles and excerpts, returning the best
+// matches. Candidates with nothing in common are left out.
+func rankRelatedArticles(title string, excerpt string, candidates []entities.RelatedArticleCandidate, limit int) []dto.ArticleResponse {
+	words := significantWords(title + " " + excerpt)
+
+	type scoredArticle struct {
+		candidate entities.RelatedArticleCandidate
+		score     float64
+	}
+
+	var scored []scoredArticle
+	for _, candidate := range candidates {
+		similarity := textSimilarity(words, significantWords(candidate.Title+" "+candidate.Excerpt))
+		score := float64(candidate.SharedTags) + textSimilarityWeight*similarity
+		if score > 0 {
+			scored = append(scored, scoredArticle{candidate: candidate, score: score})
+		}
+	}
+
+	// Candidates arrive newest first, so a stable sort breaks ties by recency
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	related := make([]dto.ArticleResponse, len(scored))
+	for i, article := range scored {
+		related[i] = dto.ArticleResponse{
+			ID:          article.candidate.ID,
+			Slug:        article.candidate.Slug,
+			Title:       article.candidate.Title,
+			Image:       article.candidate.Image,
+			Excerpt:     article.candidate.Excerpt,
+			ReadingTime: article.candidate.ReadingTime,
+			CreatedAt:   article.candidate.CreatedAt,
+		}
+	}
+
+	return related
+}
+
+// significantWords returns the distinct words of text, leaving out stop words
+// and words shorter than three letters
+func significantWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.Fields(normalizeCommentText(text)) {
+		if utf8.RuneCountInString(word) >= 3 && !similarityStopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// textSimilarity is the Jaccard index of two word sets
+func textSimilarity(a map[string]bool, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func toArticleProductResponses(products []entities.Products) []dto.ArticleProductResponse {
+	response := make([]dto.ArticleProductResponse, len(products))
+	for i, product := range products {
+		var imageUrl string
+		if len(product.ProductImages) > 0 && product.ProductImages[0].ImageUrl != nil {
+			imageUrl = *product.ProductImages[0].ImageUrl
+		}
+
+		response[i] = dto.ArticleProductResponse{
+			ID:              product.ID,
+			Slug:            product.Slug,
+			Name:            product.Name,
+			Image:           imageUrl,
+			OriginalPrice:   product.ProductPricing.OriginalPrice,
+			DiscountPercent: product.ProductPricing.DiscountPercent,
+			DiscountPrice:   product.ProductPricing.DiscountPrice,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/token"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Browsers and operating systems recognised in user agents, checked in order
+// since e.g. Edge and Opera also claim to be Chrome
+var (
+	userAgentBrowsers = []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	userAgentSystems = []struct{ token, name string }{
+		{"Android", "Android"},
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// GetSessions lists the signed in devices of a user, marking the one making the request
+func (au *authUseCase) GetSessions(c echo.Context, userID uuid.UUID, currentSessionID uuid.UUID) ([]dto.SessionResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	sessions, err := au.authRepository.GetActiveSessions(ctx, userID, time.Now().Add(-token.RefreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = dto.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+			LastSeenAt: session.LastSeenAt,
+			CreatedAt:  session.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// RevokeSession signs a user out of one of their devices
+func (au *authUseCase) RevokeSession(c echo.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	return au.revokeSession(ctx, userID, sessionID)
+}
+
+func (au *authUseCase) revokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	if err := au.authRepository.RevokeSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	au.markSessionRevoked(sessionID)
+	return nil
+}
+
+// markSessionRevoked makes the JWT middleware reject the access tokens the
+// session still holds. Its refresh tokens are already revoked, so a failure
+// only lets the access tokens live until they expire.
+func (au *authUseCase) markSessionRevoked(sessionID uuid.UUID) {
+	if err := au.redisClient.Set(token.RevokedSessionKey(sessionID), "1", token.AccessTokenTTL); err != nil {
+		logrus.WithError(err).WithField("session_id", sessionID).Error("Failed to mark session as revoked")
+	}
+}
+
+func (au *authUseCase) sendNewDeviceAlert(email string, session *entities.UserSessions) {
+	body := fmt.Sprintf(
+		"Your Kreasi Nusantara account was just signed in from a new device.\n\nDevice: %s\nIP address: %s\nTime: %s\n\nIf this was not you, change your password right away to sign out every device.",
+		session.DeviceName,
+		session.IPAddress,
+		session.CreatedAt.Format(time.RFC1123),
+	)
+
+	if err := au.emailUtil.SendEmail(email, "Kreasi Nusantara New Login", body); err != nil {
+		logrus.WithError(err).WithField("session_id", session.ID).Error("Failed to send new device alert")
+	}
+}
+
+// describeDevice turns a user agent into a readable name such as "Chrome on Windows"
+func describeDevice(userAgent string) string {
+	browser := ""
+	for _, candidate := range userAgentBrowsers {
+		if strings.Contains(userAgent, candidate.token) {
+			browser = candidate.name
+			break
+		}
+	}
+
+	system := ""
+	for _, candidate := range userAgentSystems {
+		if strings.Contains(userAgent, candidate.token) {
+			system = candidate.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+
+	return "Unknown device"
+}
+
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max])
+}
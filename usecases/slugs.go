@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/slug"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maxSlugAttempts bounds the numbered suffixes tried when a slug is already taken
+const maxSlugAttempts = 1000
+
+// slugPaths maps each kind of content to its path on the frontend
+var slugPaths = map[string]string{
+	entities.SlugTypeProduct: "products",
+	entities.SlugTypeArticle: "articles",
+	entities.SlugTypeEvent:   "events",
+}
+
+type SlugUseCase interface {
+	ResolveSlug(c echo.Context, objectType string, slug string) (uuid.UUID, string, error)
+	GetSitemap(c echo.Context) ([]byte, error)
+
+	AssignSlug(ctx context.Context, objectType string, objectID uuid.UUID, name string) (string, error)
+	NextSlug(ctx context.Context, objectType string, objectID uuid.UUID, name string) (string, string, error)
+	BackfillSlugs(ctx context.Context) error
+}
+
+type slugUseCase struct {
+	slugRepository repositories.SlugRepository
+}
+
+func NewSlugUseCase(slugRepository repositories.SlugRepository) *slugUseCase {
+	return &slugUseCase{
+		slugRepository: slugRepository,
+	}
+}
+
+// ResolveSlug returns the ID of the content behind a slug together with its
+// current slug, which differs from the given one when an old slug was used.
+func (su *slugUseCase) ResolveSlug(c echo.Context, objectType string, objectSlug string) (uuid.UUID, string, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	objectID, err := su.slugRepository.GetIDBySlug(ctx, objectType, objectSlug)
+	if err == nil {
+		return objectID, objectSlug, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, "", err
+	}
+
+	redirect, err := su.slugRepository.GetRedirect(ctx, objectType, objectSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, "", err_util.ErrNotFound
+		}
+		return uuid.Nil, "", err
+	}
+
+	canonical, err := su.slugRepository.GetSlugByID(ctx, objectType, redirect.ObjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, "", err_util.ErrNotFound
+		}
+		return uuid.Nil, "", err
+	}
+
+	// The content may have been unpublished or deleted since it was renamed
+	if _, err := su.slugRepository.GetIDBySlug(ctx, objectType, canonical); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, "", err_util.ErrNotFound
+		}
+		return uuid.Nil, "", err
+	}
+
+	return redirect.ObjectID, canonical, nil
+}
+
+// AssignSlug gives content a unique slug generated from its name. The current
+// slug is kept as long as it still matches the name, otherwise a numbered
+// suffix is added until the slug is free and the previous one becomes a redirect.
+func (su *slugUseCase) AssignSlug(ctx context.Context, objectType string, objectID uuid.UUID, name string) (string, error) {
+	next, current, err := su.NextSlug(ctx, objectType, objectID, name)
+	if err != nil {
+		return "", err
+	}
+
+	if next == current {
+		return current, nil
+	}
+
+	if err := su.slugRepository.SaveSlug(ctx, objectType, objectID, next, current); err != nil {
+		return "", err
+	}
+
+	return next, nil
+}
+
+// NextSlug picks the slug AssignSlug would give the content without saving it,
+// together with the current slug, for callers that save it in their own transaction.
+// Content that is not stored yet has no current slug.
+func (su *slugUseCase) NextSlug(ctx context.Context, objectType string, objectID uuid.UUID, name string) (string, string, error) {
+	current, err := su.slugRepository.GetSlugByID(ctx, objectType, objectID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", err
+	}
+
+	base := slug.Make(name)
+	if base == "" {
+		base = objectType
+	}
+
+	if current != "" && matchesSlugBase(current, base) {
+		return current, current, nil
+	}
+
+	for i := 1; i <= maxSlugAttempts; i++ {
+		candidate := numberedSlug(base, i)
+
+		taken, err := su.slugRepository.IsSlugTaken(ctx, objectType, candidate, objectID)
+		if err != nil {
+			return "", "", err
+		}
+		if taken {
+			continue
+		}
+
+		return candidate, current, nil
+	}
+
+	return "", "", fmt.Errorf("no free slug for %q", base)
+}
+
+// BackfillSlugs assigns slugs to content created before slugs existed
+func (su *slugUseCase) BackfillSlugs(ctx context.Context) error {
+	for _, objectType := range []string{entities.SlugTypeProduct, entities.SlugTypeArticle, entities.SlugTypeEvent} {
+		sources, err := su.slugRepository.GetMissingSlugs(ctx, objectType)
+		if err != nil {
+			return err
+		}
+
+		for _, source := range sources {
+			if _, err := su.AssignSlug(ctx, objectType, source.ID, source.Name); err != nil {
+				logrus.WithError(err).WithField("id", source.ID).Warnf("Skipping slug of %s", objectType)
+			}
+		}
+	}
+
+	return nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// GetSitemap renders the sitemap.xml of every public product, article, event and tag page
+func (su *slugUseCase) GetSitemap(c echo.Context) ([]byte, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	urlSet := sitemapURLSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+
+	for _, objectType := range []string{entities.SlugTypeProduct, entities.SlugTypeArticle, entities.SlugTypeEvent} {
+		entries, err := su.slugRepository.GetSitemapEntries(ctx, objectType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			urlSet.URLs = append(urlSet.URLs, toSitemapURL(CanonicalURL(objectType, entry.Slug), entry))
+		}
+	}
+
+	tags, err := su.slugRepository.GetTagSitemapEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range tags {
+		urlSet.URLs = append(urlSet.URLs, toSitemapURL(fmt.Sprintf("%s/tags/%s", os.Getenv("FRONTEND_URL"), entry.Slug), entry))
+	}
+
+	body, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// CanonicalURL returns the frontend URL of content with the given slug
+func CanonicalURL(objectType string, objectSlug string) string {
+	if objectSlug == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/%s", os.Getenv("FRONTEND_URL"), slugPaths[objectType], objectSlug)
+}
+
+func toSitemapURL(loc string, entry entities.SitemapEntry) sitemapURL {
+	url := sitemapURL{Loc: loc}
+	if !entry.UpdatedAt.IsZero() {
+		url.LastMod = entry.UpdatedAt.Format("2006-01-02")
+	}
+	return url
+}
+
+func numberedSlug(base string, n int) string {
+	if n == 1 {
+		return base
+	}
+
+	suffix := "-" + strconv.Itoa(n)
+	if len(base)+len(suffix) > slug.MaxLength {
+		base = strings.TrimRight(base[:slug.MaxLength-len(suffix)], "-")
+	}
+
+	return base + suffix
+}
+
+// matchesSlugBase reports whether current is base itself or base with a numbered suffix
+func matchesSlugBase(current string, base string) bool {
+	if current == base {
+		return true
+	}
+
+	i := strings.LastIndex(current, "-")
+	if i <= 0 {
+		return false
+	}
+
+	n, err := strconv.Atoi(current[i+1:])
+	if err != nil || n < 2 {
+		return false
+	}
+
+	return current == numberedSlug(base, n)
+}
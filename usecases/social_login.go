@@ -0,0 +1,414 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/drivers/redis"
+	dto "kreasi-nusantara-api/dto/user"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/oidc"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// oidcStateTTL is how long a user has to finish logging in at the provider
+const oidcStateTTL = 10 * time.Minute
+
+// What an authorization request was started for
+const (
+	oidcPurposeLogin = "login"
+	oidcPurposeLink  = "link"
+)
+
+type SocialLoginUseCase interface {
+	GetProviders(c echo.Context) []string
+	GetAuthorizationURL(c echo.Context, provider string) (*dto.AuthorizationURLResponse, error)
+	Login(c echo.Context, provider string, req *dto.OIDCCallbackRequest) (*dto.LoginResponse, error)
+
+	// Linked identities
+	GetLinkURL(c echo.Context, userID uuid.UUID, provider string) (*dto.AuthorizationURLResponse, error)
+	LinkIdentity(c echo.Context, userID uuid.UUID, provider string, req *dto.OIDCCallbackRequest) error
+	GetIdentities(c echo.Context, userID uuid.UUID) ([]dto.IdentityResponse, error)
+	UnlinkIdentity(c echo.Context, userID uuid.UUID, provider string) error
+}
+
+type socialLoginUseCase struct {
+	providers          map[string]oidc.Provider
+	identityRepository repositories.IdentityRepository
+	userRepository     repositories.UserRepository
+	redisClient        redis.RedisClient
+	authUseCase        AuthUseCase
+	twoFactorUseCase   TwoFactorUseCase
+}
+
+// oidcState is what the server remembers about an authorization request
+// until the user comes back from the provider
+type oidcState struct {
+	Provider     string    `json:"provider"`
+	Purpose      string    `json:"purpose"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	UserID       uuid.UUID `json:"user_id"`
+}
+
+func NewSocialLoginUseCase(
+	providers []oidc.Provider,
+	identityRepository repositories.IdentityRepository,
+	userRepository repositories.UserRepository,
+	redisClient redis.RedisClient,
+	authUseCase AuthUseCase,
+	twoFactorUseCase TwoFactorUseCase,
+) *socialLoginUseCase {
+	providersByName := make(map[string]oidc.Provider, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+
+	return &socialLoginUseCase{
+		providers:          providersByName,
+		identityRepository: identityRepository,
+		userRepository:     userRepository,
+		redisClient:        redisClient,
+		authUseCase:        authUseCase,
+		twoFactorUseCase:   twoFactorUseCase,
+	}
+}
+
+func (su *socialLoginUseCase) GetProviders(c echo.Context) []string {
+	names := make([]string, 0, len(su.providers))
+	for name := range su.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (su *socialLoginUseCase) GetAuthorizationURL(c echo.Context, provider string) (*dto.AuthorizationURLResponse, error) {
+	return su.startAuthorization(c, provider, oidcPurposeLogin, uuid.Nil)
+}
+
+// Login logs a user in with a provider account. An unknown account is linked
+// to the user with the same email, or signs up a new user, but only when the
+// provider verified the email.
+func (su *socialLoginUseCase) Login(c echo.Context, provider string, req *dto.OIDCCallbackRequest) (*dto.LoginResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	claims, err := su.finishAuthorization(c, provider, oidcPurposeLogin, uuid.Nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *entities.User
+	identity, err := su.identityRepository.GetIdentity(ctx, provider, claims.Subject)
+	switch {
+	case err == nil:
+		user, err = su.userRepository.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err_util.ErrNotFound
+			}
+			return nil, err
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		user, err = su.linkOrRegister(ctx, provider, claims)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	challenge, err := su.twoFactorUseCase.Challenge(c, user.ID, entities.AccountTypeUser, "user", user.Email)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &dto.LoginResponse{
+			Username:          user.Username,
+			Email:             user.Email,
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge.ChallengeToken,
+		}, nil
+	}
+
+	tokens, err := su.authUseCase.IssueTokens(c, user.ID, "user", user.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LoginResponse{
+		Username:     user.Username,
+		Email:        user.Email,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+func (su *socialLoginUseCase) GetLinkURL(c echo.Context, userID uuid.UUID, provider string) (*dto.AuthorizationURLResponse, error) {
+	return su.startAuthorization(c, provider, oidcPurposeLink, userID)
+}
+
+// LinkIdentity links a provider account to the logged in user. The
+// authorization request must have been started by the same user.
+func (su *socialLoginUseCase) LinkIdentity(c echo.Context, userID uuid.UUID, provider string, req *dto.OIDCCallbackRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	claims, err := su.finishAuthorization(c, provider, oidcPurposeLink, userID, req)
+	if err != nil {
+		return err
+	}
+
+	if _, err := su.identityRepository.GetIdentity(ctx, provider, claims.Subject); err == nil {
+		return err_util.ErrIdentityAlreadyLinked
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	identities, err := su.identityRepository.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			return err_util.ErrIdentityAlreadyLinked
+		}
+	}
+
+	return su.identityRepository.CreateIdentity(ctx, newIdentity(userID, provider, claims))
+}
+
+func (su *socialLoginUseCase) GetIdentities(c echo.Context, userID uuid.UUID) ([]dto.IdentityResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	identities, err := su.identityRepository.GetIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.IdentityResponse, len(identities))
+	for i, identity := range identities {
+		response[i] = dto.IdentityResponse{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// UnlinkIdentity removes a linked provider account, unless the user has no
+// password and it is the only way left to log in
+func (su *socialLoginUseCase) UnlinkIdentity(c echo.Context, userID uuid.UUID, provider string) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	user, err := su.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	if user.Password == "" {
+		identities, err := su.identityRepository.GetIdentitiesByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(identities) == 1 && identities[0].Provider == provider {
+			return err_util.ErrLastLoginMethod
+		}
+	}
+
+	if err := su.identityRepository.DeleteIdentity(ctx, userID, provider); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// startAuthorization remembers a new authorization request and returns the
+// URL of the provider's login page
+func (su *socialLoginUseCase) startAuthorization(c echo.Context, providerName string, purpose string, userID uuid.UUID) (*dto.AuthorizationURLResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	provider, ok := su.providers[providerName]
+	if !ok {
+		return nil, err_util.ErrUnknownOIDCProvider
+	}
+
+	state := oidcState{Provider: providerName, Purpose: purpose, UserID: userID}
+	var err error
+	if state.Nonce, err = oidc.RandomString(); err != nil {
+		return nil, err
+	}
+	if state.CodeVerifier, err = oidc.RandomString(); err != nil {
+		return nil, err
+	}
+	stateKey, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, stateKey, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := su.redisClient.Set(oidcStateKey(stateKey), string(value), oidcStateTTL); err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthorizationURLResponse{URL: authURL}, nil
+}
+
+// finishAuthorization consumes the state of an authorization request and
+// redeems the code the provider returned for the user's verified claims
+func (su *socialLoginUseCase) finishAuthorization(c echo.Context, providerName string, purpose string, userID uuid.UUID, req *dto.OIDCCallbackRequest) (*oidc.Claims, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	provider, ok := su.providers[providerName]
+	if !ok {
+		return nil, err_util.ErrUnknownOIDCProvider
+	}
+
+	value, err := su.redisClient.GetDel(oidcStateKey(req.State))
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, err_util.ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+
+	var state oidcState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return nil, err_util.ErrInvalidOIDCState
+	}
+
+	if state.Provider != providerName || state.Purpose != purpose || state.UserID != userID {
+		return nil, err_util.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		logrus.New().WithError(err).WithField("provider", providerName).Warn("Failed to exchange authorization code")
+		return nil, err_util.ErrOIDCLoginFailed
+	}
+
+	return claims, nil
+}
+
+// linkOrRegister links a provider account seen for the first time to the user
+// with its email, or signs up a new user with it
+func (su *socialLoginUseCase) linkOrRegister(ctx context.Context, provider string, claims *oidc.Claims) (*entities.User, error) {
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, err_util.ErrOIDCEmailNotVerified
+	}
+
+	user, err := su.userRepository.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		identity := newIdentity(user.ID, provider, claims)
+		if user.IsVerified {
+			return user, su.identityRepository.CreateIdentity(ctx, identity)
+		}
+		if err := su.identityRepository.ClaimUnverifiedUser(ctx, user.ID, identity); err != nil {
+			return nil, err
+		}
+		user.IsVerified = true
+		user.Password = ""
+		return user, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	username, err := su.generateUsername(ctx, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users who sign up with a provider have no password until they reset one
+	user = &entities.User{
+		ID:         uuid.New(),
+		Username:   username,
+		FirstName:  claims.GivenName,
+		LastName:   claims.FamilyName,
+		Email:      claims.Email,
+		IsVerified: true,
+	}
+	if err := su.identityRepository.CreateUserWithIdentity(ctx, user, newIdentity(user.ID, provider, claims)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// generateUsername derives a free username from the local part of an email
+func (su *socialLoginUseCase) generateUsername(ctx context.Context, email string) (string, error) {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	base := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' {
+			return r
+		}
+		return -1
+	}, local)
+	if len(base) > 20 {
+		base = base[:20]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		exists, err := su.userRepository.UsernameExists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s%04d", base, rand.IntN(10000))
+	}
+
+	return base + "_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12], nil
+}
+
+func newIdentity(userID uuid.UUID, provider string, claims *oidc.Claims) *entities.UserIdentities {
+	return &entities.UserIdentities{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+}
+
+func oidcStateKey(state string) string {
+	return "oidc:state:" + state
+}
@@ -0,0 +1,439 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/dto"
+	userdto "kreasi-nusantara-api/dto/user"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/oidc"
+	"kreasi-nusantara-api/utils/oidc/oidctest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const testProvider = "test"
+
+// fakeUserRepository keeps users in memory. Methods the social login does not
+// use are left to the embedded nil interface.
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *fakeUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepository) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeIdentityRepository struct {
+	users      *fakeUserRepository
+	identities []entities.UserIdentities
+}
+
+func (r *fakeIdentityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*entities.UserIdentities, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeIdentityRepository) GetIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]entities.UserIdentities, error) {
+	var identities []entities.UserIdentities
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *fakeIdentityRepository) CreateIdentity(ctx context.Context, identity *entities.UserIdentities) error {
+	r.identities = append(r.identities, *identity)
+	return nil
+}
+
+func (r *fakeIdentityRepository) CreateUserWithIdentity(ctx context.Context, user *entities.User, identity *entities.UserIdentities) error {
+	r.users.users[user.ID] = user
+	return r.CreateIdentity(ctx, identity)
+}
+
+func (r *fakeIdentityRepository) ClaimUnverifiedUser(ctx context.Context, userID uuid.UUID, identity *entities.UserIdentities) error {
+	user := r.users.users[userID]
+	user.IsVerified = true
+	user.Password = ""
+	return r.CreateIdentity(ctx, identity)
+}
+
+func (r *fakeIdentityRepository) DeleteIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	for i, identity := range r.identities {
+		if identity.UserID == userID && identity.Provider == provider {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+type fakeAuthUseCase struct {
+	AuthUseCase
+}
+
+func (fakeAuthUseCase) IssueTokens(c echo.Context, userID uuid.UUID, role string, email string) (*dto.TokenResponse, error) {
+	return &dto.TokenResponse{Token: "token-" + userID.String(), RefreshToken: "refresh", ExpiresIn: 900}, nil
+}
+
+type fakeTwoFactorUseCase struct {
+	TwoFactorUseCase
+}
+
+func (fakeTwoFactorUseCase) Challenge(c echo.Context, accountID uuid.UUID, accountType string, role string, email string) (*dto.TwoFactorChallenge, error) {
+	return nil, nil
+}
+
+type socialLoginTest struct {
+	issuer     *oidctest.Issuer
+	users      *fakeUserRepository
+	identities *fakeIdentityRepository
+	useCase    *socialLoginUseCase
+}
+
+func newSocialLoginTest(t *testing.T) *socialLoginTest {
+	t.Helper()
+
+	issuer := oidctest.NewIssuer(t, "kreasi-nusantara")
+	provider := oidc.NewProvider(oidc.Config{
+		Name:        testProvider,
+		Issuer:      issuer.URL(),
+		ClientID:    issuer.ClientID,
+		RedirectURL: "https://kreasinusantara.example/auth/test/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+
+	server := miniredis.RunT(t)
+	redisClient := redis.RedisClient{Client: goredis.NewClient(&goredis.Options{Addr: server.Addr()})}
+	t.Cleanup(func() { redisClient.Client.Close() })
+
+	users := &fakeUserRepository{users: make(map[uuid.UUID]*entities.User)}
+	identities := &fakeIdentityRepository{users: users}
+
+	return &socialLoginTest{
+		issuer:     issuer,
+		users:      users,
+		identities: identities,
+		useCase: NewSocialLoginUseCase(
+			[]oidc.Provider{provider},
+			identities,
+			users,
+			redisClient,
+			fakeAuthUseCase{},
+			fakeTwoFactorUseCase{},
+		),
+	}
+}
+
+func newEchoContext() echo.Context {
+	return echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+}
+
+// authorize starts a login and lets the user log in at the fake issuer
+func (st *socialLoginTest) authorize(t *testing.T, account oidctest.Account) *userdto.OIDCCallbackRequest {
+	t.Helper()
+
+	response, err := st.useCase.GetAuthorizationURL(newEchoContext(), testProvider)
+	if err != nil {
+		t.Fatalf("GetAuthorizationURL() error = %v", err)
+	}
+
+	code, state := st.issuer.Authorize(t, response.URL, account)
+	return &userdto.OIDCCallbackRequest{Code: code, State: state}
+}
+
+func (st *socialLoginTest) addUser(email string, verified bool) *entities.User {
+	user := &entities.User{
+		ID:         uuid.New(),
+		Username:   strings.Split(email, "@")[0],
+		Email:      email,
+		Password:   "hashed-password",
+		IsVerified: verified,
+	}
+	st.users.users[user.ID] = user
+	return user
+}
+
+func verifiedAccount(email string) oidctest.Account {
+	return oidctest.Account{
+		Subject:       "subject-" + email,
+		Email:         email,
+		EmailVerified: true,
+		GivenName:     "Budi",
+		FamilyName:    "Santoso",
+	}
+}
+
+func TestSocialLoginRegistersNewUser(t *testing.T) {
+	st := newSocialLoginTest(t)
+
+	response, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, verifiedAccount("budi@example.com")))
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	user, err := st.users.GetUserByEmail(context.Background(), "budi@example.com")
+	if err != nil {
+		t.Fatalf("no user was registered: %v", err)
+	}
+	if !user.IsVerified || user.Password != "" || user.Username != "budi" || user.FirstName != "Budi" {
+		t.Errorf("registered user = %+v", user)
+	}
+	if response.Token != "token-"+user.ID.String() {
+		t.Errorf("Login() token = %q, want a token of the new user", response.Token)
+	}
+	if len(st.identities.identities) != 1 || st.identities.identities[0].UserID != user.ID {
+		t.Errorf("identities = %+v, want one identity of the new user", st.identities.identities)
+	}
+}
+
+func TestSocialLoginReturningUser(t *testing.T) {
+	st := newSocialLoginTest(t)
+	user := st.addUser("budi@example.com", true)
+	account := verifiedAccount("budi@example.com")
+	st.identities.identities = append(st.identities.identities, *newIdentity(user.ID, testProvider, &oidc.Claims{Subject: account.Subject}))
+
+	// The provider account stays linked even after its email changed
+	account.Email = "budi.santoso@example.com"
+	response, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, account))
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if response.Email != user.Email {
+		t.Errorf("Login() email = %q, want %q", response.Email, user.Email)
+	}
+	if len(st.users.users) != 1 || len(st.identities.identities) != 1 {
+		t.Errorf("returning login created users or identities")
+	}
+}
+
+func TestSocialLoginLinksVerifiedEmail(t *testing.T) {
+	st := newSocialLoginTest(t)
+	user := st.addUser("budi@example.com", true)
+
+	response, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, verifiedAccount("budi@example.com")))
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if response.Token != "token-"+user.ID.String() {
+		t.Errorf("Login() token = %q, want a token of the existing user", response.Token)
+	}
+	if user.Password != "hashed-password" {
+		t.Error("linking a verified account cleared its password")
+	}
+	if len(st.identities.identities) != 1 || st.identities.identities[0].UserID != user.ID {
+		t.Errorf("identities = %+v, want one identity of the existing user", st.identities.identities)
+	}
+}
+
+func TestSocialLoginClaimsUnverifiedAccount(t *testing.T) {
+	st := newSocialLoginTest(t)
+	user := st.addUser("budi@example.com", false)
+
+	if _, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, verifiedAccount("budi@example.com"))); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	// Whoever set the password never proved they own the email
+	if !user.IsVerified || user.Password != "" {
+		t.Errorf("claimed user = %+v, want verified without password", user)
+	}
+}
+
+func TestSocialLoginRefusesUnverifiedEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(claims jwt.MapClaims)
+	}{
+		{
+			name:   "email not verified",
+			tamper: func(claims jwt.MapClaims) { claims["email_verified"] = false },
+		},
+		{
+			name:   "email_verified missing",
+			tamper: func(claims jwt.MapClaims) { delete(claims, "email_verified") },
+		},
+		{
+			name:   "email missing",
+			tamper: func(claims jwt.MapClaims) { delete(claims, "email") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSocialLoginTest(t)
+			user := st.addUser("budi@example.com", true)
+			st.issuer.Tamper = tt.tamper
+
+			response, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, verifiedAccount("budi@example.com")))
+			if !errors.Is(err, err_util.ErrOIDCEmailNotVerified) {
+				t.Fatalf("Login() = %+v, %v, want %v", response, err, err_util.ErrOIDCEmailNotVerified)
+			}
+			if len(st.identities.identities) != 0 || len(st.users.users) != 1 {
+				t.Errorf("an unverified email linked or registered an account")
+			}
+			if user.Password != "hashed-password" {
+				t.Error("an unverified email changed the existing account")
+			}
+		})
+	}
+}
+
+func TestSocialLoginRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(claims jwt.MapClaims)
+	}{
+		{
+			name:   "wrong issuer",
+			tamper: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example" },
+		},
+		{
+			name:   "wrong audience",
+			tamper: func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+		},
+		{
+			name:   "expired",
+			tamper: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name:   "wrong nonce",
+			tamper: func(claims jwt.MapClaims) { claims["nonce"] = "replayed-nonce" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newSocialLoginTest(t)
+			st.issuer.Tamper = tt.tamper
+
+			response, err := st.useCase.Login(newEchoContext(), testProvider, st.authorize(t, verifiedAccount("budi@example.com")))
+			if !errors.Is(err, err_util.ErrOIDCLoginFailed) {
+				t.Fatalf("Login() = %+v, %v, want %v", response, err, err_util.ErrOIDCLoginFailed)
+			}
+			if len(st.users.users) != 0 {
+				t.Error("an invalid ID token registered a user")
+			}
+		})
+	}
+}
+
+func TestSocialLoginRejectsPKCEMismatch(t *testing.T) {
+	st := newSocialLoginTest(t)
+
+	// The code of one authorization request redeemed with the state, and so
+	// the code verifier, of another
+	first := st.authorize(t, verifiedAccount("budi@example.com"))
+	second := st.authorize(t, verifiedAccount("budi@example.com"))
+
+	response, err := st.useCase.Login(newEchoContext(), testProvider, &userdto.OIDCCallbackRequest{Code: first.Code, State: second.State})
+	if !errors.Is(err, err_util.ErrOIDCLoginFailed) {
+		t.Fatalf("Login() = %+v, %v, want %v", response, err, err_util.ErrOIDCLoginFailed)
+	}
+}
+
+func TestSocialLoginRejectsReusedState(t *testing.T) {
+	st := newSocialLoginTest(t)
+	req := st.authorize(t, verifiedAccount("budi@example.com"))
+
+	if _, err := st.useCase.Login(newEchoContext(), testProvider, req); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if _, err := st.useCase.Login(newEchoContext(), testProvider, req); !errors.Is(err, err_util.ErrInvalidOIDCState) {
+		t.Fatalf("second Login() error = %v, want %v", err, err_util.ErrInvalidOIDCState)
+	}
+}
+
+func TestSocialLoginRejectsStateOfLinkRequest(t *testing.T) {
+	st := newSocialLoginTest(t)
+	user := st.addUser("budi@example.com", true)
+
+	response, err := st.useCase.GetLinkURL(newEchoContext(), user.ID, testProvider)
+	if err != nil {
+		t.Fatalf("GetLinkURL() error = %v", err)
+	}
+	code, state := st.issuer.Authorize(t, response.URL, verifiedAccount("budi@example.com"))
+
+	_, err = st.useCase.Login(newEchoContext(), testProvider, &userdto.OIDCCallbackRequest{Code: code, State: state})
+	if !errors.Is(err, err_util.ErrInvalidOIDCState) {
+		t.Fatalf("Login() error = %v, want %v", err, err_util.ErrInvalidOIDCState)
+	}
+}
+
+func TestLinkIdentity(t *testing.T) {
+	st := newSocialLoginTest(t)
+	user := st.addUser("budi@example.com", true)
+
+	// Linking needs no verified email, the user is already logged in
+	account := verifiedAccount("other@example.com")
+	account.EmailVerified = false
+
+	response, err := st.useCase.GetLinkURL(newEchoContext(), user.ID, testProvider)
+	if err != nil {
+		t.Fatalf("GetLinkURL() error = %v", err)
+	}
+	code, state := st.issuer.Authorize(t, response.URL, account)
+
+	// The link request belongs to the user who started it
+	err = st.useCase.LinkIdentity(newEchoContext(), uuid.New(), testProvider, &userdto.OIDCCallbackRequest{Code: code, State: state})
+	if !errors.Is(err, err_util.ErrInvalidOIDCState) {
+		t.Fatalf("LinkIdentity() by another user error = %v, want %v", err, err_util.ErrInvalidOIDCState)
+	}
+
+	response, err = st.useCase.GetLinkURL(newEchoContext(), user.ID, testProvider)
+	if err != nil {
+		t.Fatalf("GetLinkURL() error = %v", err)
+	}
+	code, state = st.issuer.Authorize(t, response.URL, account)
+
+	if err := st.useCase.LinkIdentity(newEchoContext(), user.ID, testProvider, &userdto.OIDCCallbackRequest{Code: code, State: state}); err != nil {
+		t.Fatalf("LinkIdentity() error = %v", err)
+	}
+	if len(st.identities.identities) != 1 || st.identities.identities[0].UserID != user.ID {
+		t.Errorf("identities = %+v, want one identity of the user", st.identities.identities)
+	}
+}
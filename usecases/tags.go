@@ -0,0 +1,420 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/slug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTagNameLength = 50
+	maxTagsPerItem   = 10
+)
+
+type TagUseCase interface {
+	SearchTags(c echo.Context, query string, limit int) ([]dto.TagResponse, error)
+	GetTagBySlug(c echo.Context, slug string) (*dto.TagDetailResponse, error)
+
+	// Admin
+	GetTags(c echo.Context) ([]dto.TagAdminResponse, error)
+	CreateTag(c echo.Context, req *dto.TagRequest) (*dto.TagResponse, error)
+	RenameTag(c echo.Context, tagID uuid.UUID, req *dto.TagRequest) (*dto.TagResponse, error)
+	DeleteTag(c echo.Context, tagID uuid.UUID) error
+	MergeTags(c echo.Context, req *dto.TagMergeRequest) error
+
+	ResolveTags(ctx context.Context, raw string) ([]entities.Tags, error)
+	SetArticleTags(ctx context.Context, articleID uuid.UUID, tags []entities.Tags) error
+	SetProductTags(ctx context.Context, productID uuid.UUID, tags []entities.Tags) error
+	GetArticleTags(ctx context.Context, articleID uuid.UUID) ([]dto.TagResponse, error)
+	GetProductTags(ctx context.Context, productID uuid.UUID) ([]dto.TagResponse, error)
+	BackfillArticleTags(ctx context.Context) error
+}
+
+type tagUseCase struct {
+	tagRepository     repositories.TagRepository
+	productRepository repositories.ProductRepository
+}
+
+func NewTagUseCase(tagRepository repositories.TagRepository, productRepository repositories.ProductRepository) *tagUseCase {
+	return &tagUseCase{
+		tagRepository:     tagRepository,
+		productRepository: productRepository,
+	}
+}
+
+func (tu *tagUseCase) SearchTags(c echo.Context, query string, limit int) ([]dto.TagResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tags, err := tu.tagRepository.SearchTags(ctx, strings.ToLower(strings.TrimPrefix(query, "#")), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTagResponses(tags), nil
+}
+
+func (tu *tagUseCase) GetTagBySlug(c echo.Context, tagSlug string) (*dto.TagDetailResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tag, err := tu.tagRepository.GetTagBySlug(ctx, tagSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	articles, err := tu.tagRepository.GetArticlesByTagID(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := tu.tagRepository.GetProductsByTagID(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := tu.productRepository.GetAllAverageRatingsAndTotalReviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ratingMap := make(map[uuid.UUID]entities.RatingSummary)
+	for _, summary := range ratings {
+		ratingMap[summary.ProductID] = summary
+	}
+
+	response := &dto.TagDetailResponse{
+		Tag:      toTagResponse(tag),
+		Articles: make([]dto.ArticleResponse, len(articles)),
+		Products: make([]dto.ProductResponse, len(products)),
+	}
+
+	for i, article := range articles {
+		response.Articles[i] = dto.ArticleResponse{
+			ID:          article.ID,
+			Slug:        article.Slug,
+			Image:       article.Image,
+			Title:       article.Title,
+			Excerpt:     article.Excerpt,
+			ReadingTime: article.ReadingTime,
+			CreatedAt:   article.CreatedAt,
+		}
+	}
+
+	for i, product := range products {
+		var imageUrl string
+		if len(product.ProductImages) > 0 && product.ProductImages[0].ImageUrl != nil {
+			imageUrl = *product.ProductImages[0].ImageUrl
+		}
+
+		summary := ratingMap[product.ID]
+		response.Products[i] = dto.ProductResponse{
+			ID:              product.ID,
+			Slug:            product.Slug,
+			Image:           imageUrl,
+			Name:            product.Name,
+			OriginalPrice:   product.ProductPricing.OriginalPrice,
+			DiscountPercent: product.ProductPricing.DiscountPercent,
+			DiscountPrice:   product.ProductPricing.DiscountPrice,
+			AverageRating:   summary.AverageRating,
+			TotalReview:     summary.TotalReview,
+		}
+	}
+
+	return response, nil
+}
+
+func (tu *tagUseCase) GetTags(c echo.Context) ([]dto.TagAdminResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	summaries, err := tu.tagRepository.GetTagSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.TagAdminResponse, len(summaries))
+	for i, summary := range summaries {
+		response[i] = dto.TagAdminResponse{
+			ID:           summary.ID,
+			Name:         summary.Name,
+			Slug:         summary.Slug,
+			ArticleCount: summary.ArticleCount,
+			ProductCount: summary.ProductCount,
+		}
+	}
+
+	return response, nil
+}
+
+func (tu *tagUseCase) CreateTag(c echo.Context, req *dto.TagRequest) (*dto.TagResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tag, err := newTag(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tu.ensureSlugAvailable(ctx, tag.Slug, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	if err := tu.tagRepository.CreateTags(ctx, []entities.Tags{*tag}); err != nil {
+		return nil, err
+	}
+
+	response := toTagResponse(tag)
+	return &response, nil
+}
+
+func (tu *tagUseCase) RenameTag(c echo.Context, tagID uuid.UUID, req *dto.TagRequest) (*dto.TagResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	tag, err := tu.tagRepository.GetTagByID(ctx, tagID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrNotFound
+		}
+		return nil, err
+	}
+
+	renamed, err := newTag(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Renaming onto the slug of another tag should be done with a merge instead
+	if err := tu.ensureSlugAvailable(ctx, renamed.Slug, tag.ID); err != nil {
+		return nil, err
+	}
+
+	tag.Name = renamed.Name
+	tag.Slug = renamed.Slug
+	tag.UpdatedAt = time.Now()
+
+	if err := tu.tagRepository.RenameTag(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	response := toTagResponse(tag)
+	return &response, nil
+}
+
+func (tu *tagUseCase) DeleteTag(c echo.Context, tagID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := tu.tagRepository.DeleteTag(ctx, tagID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (tu *tagUseCase) MergeTags(c echo.Context, req *dto.TagMergeRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == req.TargetID {
+			return err_util.ErrInvalidTagMerge
+		}
+	}
+
+	if _, err := tu.tagRepository.GetTagByID(ctx, req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrNotFound
+		}
+		return err
+	}
+
+	return tu.tagRepository.MergeTags(ctx, req.SourceIDs, req.TargetID)
+}
+
+// ResolveTags parses a comma separated list of tag names and returns the matching
+// tags, creating the ones that do not exist yet.
+func (tu *tagUseCase) ResolveTags(ctx context.Context, raw string) ([]entities.Tags, error) {
+	tags, err := parseTags(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return []entities.Tags{}, nil
+	}
+
+	if err := tu.tagRepository.CreateTags(ctx, tags); err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	return tu.tagRepository.GetTagsBySlugs(ctx, slugs)
+}
+
+func (tu *tagUseCase) SetArticleTags(ctx context.Context, articleID uuid.UUID, tags []entities.Tags) error {
+	return tu.tagRepository.ReplaceArticleTags(ctx, articleID, tagIDs(tags))
+}
+
+func (tu *tagUseCase) SetProductTags(ctx context.Context, productID uuid.UUID, tags []entities.Tags) error {
+	return tu.tagRepository.ReplaceProductTags(ctx, productID, tagIDs(tags))
+}
+
+func (tu *tagUseCase) GetArticleTags(ctx context.Context, articleID uuid.UUID) ([]dto.TagResponse, error) {
+	tags, err := tu.tagRepository.GetTagsByArticleID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTagResponses(tags), nil
+}
+
+func (tu *tagUseCase) GetProductTags(ctx context.Context, productID uuid.UUID) ([]dto.TagResponse, error) {
+	tags, err := tu.tagRepository.GetTagsByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTagResponses(tags), nil
+}
+
+// BackfillArticleTags links articles written before the tag table existed to tags
+// parsed from their tags column.
+func (tu *tagUseCase) BackfillArticleTags(ctx context.Context) error {
+	articles, err := tu.tagRepository.GetUntaggedArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, article := range articles {
+		tags, err := tu.ResolveTags(ctx, article.Tags)
+		if err != nil {
+			logrus.WithError(err).WithField("article_id", article.ID).Warn("Skipping tags of article")
+			continue
+		}
+
+		if err := tu.SetArticleTags(ctx, article.ID, tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tu *tagUseCase) ensureSlugAvailable(ctx context.Context, tagSlug string, ownerID uuid.UUID) error {
+	existing, err := tu.tagRepository.GetTagBySlug(ctx, tagSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if existing.ID != ownerID {
+		return err_util.ErrTagAlreadyExists
+	}
+
+	return nil
+}
+
+// parseTags splits a comma separated list of tag names, dropping duplicates
+func parseTags(raw string) ([]entities.Tags, error) {
+	tags := []entities.Tags{}
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(raw, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		tag, err := newTag(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[tag.Slug] {
+			continue
+		}
+
+		seen[tag.Slug] = true
+		tags = append(tags, *tag)
+	}
+
+	if len(tags) > maxTagsPerItem {
+		return nil, err_util.ErrTooManyTags
+	}
+
+	return tags, nil
+}
+
+func newTag(name string) (*entities.Tags, error) {
+	name = strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(name), "#")), " ")
+	tagSlug := slug.Make(name)
+
+	if tagSlug == "" || len(name) > maxTagNameLength {
+		return nil, err_util.ErrInvalidTagName
+	}
+
+	return &entities.Tags{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      tagSlug,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// joinTagNames formats tags the same way as the tags column of an article
+func joinTagNames(tags []entities.Tags) string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func tagIDs(tags []entities.Tags) []uuid.UUID {
+	ids := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
+
+func toTagResponse(tag *entities.Tags) dto.TagResponse {
+	return dto.TagResponse{
+		ID:   tag.ID,
+		Name: tag.Name,
+		Slug: tag.Slug,
+	}
+}
+
+func toTagResponses(tags []entities.Tags) []dto.TagResponse {
+	response := make([]dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		response[i] = toTagResponse(&tag)
+	}
+	return response
+}
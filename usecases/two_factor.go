@@ -0,0 +1,522 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kreasi-nusantara-api/drivers/redis"
+	"kreasi-nusantara-api/dto"
+	"kreasi-nusantara-api/entities"
+	"kreasi-nusantara-api/repositories"
+	err_util "kreasi-nusantara-api/utils/error"
+	"kreasi-nusantara-api/utils/oidc"
+	"kreasi-nusantara-api/utils/totp"
+	"os"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	// twoFactorIssuer is the name authenticator apps show for the account
+	twoFactorIssuer = "Kreasi Nusantara"
+	// twoFactorChallengeTTL is how long the second login step may take
+	twoFactorChallengeTTL = 5 * time.Minute
+	// recoveryCodeCount is how many recovery codes an account gets at once
+	recoveryCodeCount = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TwoFactorUseCase interface {
+	// Enrolment
+	GetStatus(c echo.Context, accountID uuid.UUID, accountType string) (*dto.TwoFactorStatusResponse, error)
+	Setup(c echo.Context, accountID uuid.UUID, accountType string) (*dto.TwoFactorSetupResponse, error)
+	Enable(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	Disable(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	ResetAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error
+
+	// Login
+	Challenge(c echo.Context, accountID uuid.UUID, accountType string, role string, email string) (*dto.TwoFactorChallenge, error)
+	VerifyLogin(c echo.Context, req *dto.TwoFactorLoginRequest) (*dto.TokenResponse, error)
+	SetupLogin(c echo.Context, req *dto.TwoFactorSetupLoginRequest) (*dto.TwoFactorSetupResponse, error)
+	EnableLogin(c echo.Context, req *dto.TwoFactorLoginRequest) (*dto.TwoFactorEnableLoginResponse, error)
+}
+
+type twoFactorUseCase struct {
+	twoFactorRepository repositories.TwoFactorRepository
+	userRepository      repositories.UserRepository
+	adminRepository     repositories.AdminRepository
+	redisClient         redis.RedisClient
+	authUseCase         AuthUseCase
+}
+
+// twoFactorChallenge is the account that passed its password check and still
+// has to finish the second login step
+type twoFactorChallenge struct {
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountType   string    `json:"account_type"`
+	Role          string    `json:"role"`
+	Email         string    `json:"email"`
+	SetupRequired bool      `json:"setup_required"`
+}
+
+func NewTwoFactorUseCase(
+	twoFactorRepository repositories.TwoFactorRepository,
+	userRepository repositories.UserRepository,
+	adminRepository repositories.AdminRepository,
+	redisClient redis.RedisClient,
+	authUseCase AuthUseCase,
+) *twoFactorUseCase {
+	return &twoFactorUseCase{
+		twoFactorRepository: twoFactorRepository,
+		userRepository:      userRepository,
+		adminRepository:     adminRepository,
+		redisClient:         redisClient,
+		authUseCase:         authUseCase,
+	}
+}
+
+func (tu *twoFactorUseCase) GetStatus(c echo.Context, accountID uuid.UUID, accountType string) (*dto.TwoFactorStatusResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	response := &dto.TwoFactorStatusResponse{Required: twoFactorRequired(accountType)}
+
+	twoFactor, err := tu.getEnabled(ctx, accountID, accountType)
+	if err != nil {
+		if errors.Is(err, err_util.ErrTwoFactorNotEnabled) {
+			return response, nil
+		}
+		return nil, err
+	}
+
+	response.Enabled = true
+	response.RecoveryCodesLeft, err = tu.twoFactorRepository.CountRecoveryCodes(ctx, twoFactor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Setup starts an enrolment with a new secret, it is not used for logging in
+// until Enable confirms a code of it
+func (tu *twoFactorUseCase) Setup(c echo.Context, accountID uuid.UUID, accountType string) (*dto.TwoFactorSetupResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	email, err := tu.getEmail(ctx, accountID, accountType)
+	if err != nil {
+		return nil, err
+	}
+
+	return tu.setup(ctx, accountID, accountType, email)
+}
+
+func (tu *twoFactorUseCase) Enable(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := tu.authUseCase.CheckAttempts(c, AttemptTwoFactor, accountID.String()); err != nil {
+		return nil, err
+	}
+
+	codes, err := tu.enable(ctx, accountID, accountType, req.Code)
+	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidTwoFactorCode) {
+			tu.authUseCase.CountAttempt(c, AttemptTwoFactor, accountID.String())
+		}
+		return nil, err
+	}
+	tu.authUseCase.ClearAttempts(AttemptTwoFactor, accountID.String())
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns two-factor authentication off after checking a current code,
+// admins cannot when it is required for them
+func (tu *twoFactorUseCase) Disable(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if twoFactorRequired(accountType) {
+		return err_util.ErrTwoFactorRequired
+	}
+
+	if _, err := tu.checkCode(c, accountID, accountType, req.Code); err != nil {
+		return err
+	}
+
+	return tu.twoFactorRepository.DeleteTwoFactor(ctx, accountID, accountType)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of an account
+func (tu *twoFactorUseCase) RegenerateRecoveryCodes(c echo.Context, accountID uuid.UUID, accountType string, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	twoFactor, err := tu.checkCode(c, accountID, accountType, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, stored, err := newRecoveryCodes(twoFactor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tu.twoFactorRepository.ReplaceRecoveryCodes(ctx, twoFactor.ID, stored); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// ResetAdmin removes the two-factor authentication of an admin who lost it
+// and logs them out everywhere, so they enrol again on their next login
+func (tu *twoFactorUseCase) ResetAdmin(c echo.Context, adminID uuid.UUID, superAdminID uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	email, err := tu.getEmail(ctx, adminID, entities.AccountTypeAdmin)
+	if err != nil {
+		return err
+	}
+
+	if err := tu.twoFactorRepository.DeleteTwoFactor(ctx, adminID, entities.AccountTypeAdmin); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err_util.ErrTwoFactorNotEnabled
+		}
+		return err
+	}
+
+	tu.authUseCase.RecordSecurityEvent(c, entities.SecurityEventTwoFactorReset, AttemptTwoFactor, email,
+		fmt.Sprintf("two-factor authentication reset by super admin %s", superAdminID))
+
+	return tu.authUseCase.RevokeAllSessions(ctx, adminID)
+}
+
+// Challenge returns the second login step an account that passed its password
+// check still has to take, or nil when it can log in right away
+func (tu *twoFactorUseCase) Challenge(c echo.Context, accountID uuid.UUID, accountType string, role string, email string) (*dto.TwoFactorChallenge, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	enabled := true
+	if _, err := tu.getEnabled(ctx, accountID, accountType); err != nil {
+		if !errors.Is(err, err_util.ErrTwoFactorNotEnabled) {
+			return nil, err
+		}
+		enabled = false
+	}
+
+	if !enabled && !twoFactorRequired(accountType) {
+		return nil, nil
+	}
+
+	challengeToken, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(twoFactorChallenge{
+		AccountID:     accountID,
+		AccountType:   accountType,
+		Role:          role,
+		Email:         email,
+		SetupRequired: !enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tu.redisClient.Set(twoFactorChallengeKey(challengeToken), string(value), twoFactorChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorChallenge{ChallengeToken: challengeToken, SetupRequired: !enabled}, nil
+}
+
+// VerifyLogin finishes a login with a code of the authenticator app or a
+// recovery code
+func (tu *twoFactorUseCase) VerifyLogin(c echo.Context, req *dto.TwoFactorLoginRequest) (*dto.TokenResponse, error) {
+	challenge, err := tu.getChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.SetupRequired {
+		return nil, err_util.ErrInvalidTwoFactorChallenge
+	}
+
+	if _, err := tu.checkCode(c, challenge.AccountID, challenge.AccountType, req.Code); err != nil {
+		return nil, err
+	}
+
+	return tu.finishLogin(c, req.ChallengeToken, challenge)
+}
+
+// SetupLogin starts the enrolment of an admin who must enrol to log in
+func (tu *twoFactorUseCase) SetupLogin(c echo.Context, req *dto.TwoFactorSetupLoginRequest) (*dto.TwoFactorSetupResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	challenge, err := tu.getChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.SetupRequired {
+		return nil, err_util.ErrInvalidTwoFactorChallenge
+	}
+
+	return tu.setup(ctx, challenge.AccountID, challenge.AccountType, challenge.Email)
+}
+
+// EnableLogin confirms the enrolment started by SetupLogin and finishes the login
+func (tu *twoFactorUseCase) EnableLogin(c echo.Context, req *dto.TwoFactorLoginRequest) (*dto.TwoFactorEnableLoginResponse, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	challenge, err := tu.getChallenge(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.SetupRequired {
+		return nil, err_util.ErrInvalidTwoFactorChallenge
+	}
+
+	account := challenge.AccountID.String()
+	if err := tu.authUseCase.CheckAttempts(c, AttemptTwoFactor, account); err != nil {
+		return nil, err
+	}
+
+	codes, err := tu.enable(ctx, challenge.AccountID, challenge.AccountType, req.Code)
+	if err != nil {
+		if errors.Is(err, err_util.ErrInvalidTwoFactorCode) {
+			tu.authUseCase.CountAttempt(c, AttemptTwoFactor, account)
+		}
+		return nil, err
+	}
+	tu.authUseCase.ClearAttempts(AttemptTwoFactor, account)
+
+	tokens, err := tu.finishLogin(c, req.ChallengeToken, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnableLoginResponse{TokenResponse: *tokens, RecoveryCodes: codes}, nil
+}
+
+func (tu *twoFactorUseCase) setup(ctx context.Context, accountID uuid.UUID, accountType string, email string) (*dto.TwoFactorSetupResponse, error) {
+	if _, err := tu.getEnabled(ctx, accountID, accountType); err == nil {
+		return nil, err_util.ErrTwoFactorAlreadyEnabled
+	} else if !errors.Is(err, err_util.ErrTwoFactorNotEnabled) {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tu.twoFactorRepository.SavePendingTwoFactor(ctx, &entities.TwoFactorAuth{
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		AccountType: accountType,
+		Secret:      secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURL: totp.KeyURI(twoFactorIssuer, email, secret),
+	}, nil
+}
+
+// enable confirms the pending secret of an account with a code of it and
+// returns the first recovery codes
+func (tu *twoFactorUseCase) enable(ctx context.Context, accountID uuid.UUID, accountType string, code string) ([]string, error) {
+	twoFactor, err := tu.twoFactorRepository.GetTwoFactor(ctx, accountID, accountType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTwoFactorSetupNotStarted
+		}
+		return nil, err
+	}
+	if twoFactor.EnabledAt != nil {
+		return nil, err_util.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return nil, err_util.ErrInvalidTwoFactorCode
+	}
+
+	codes, stored, err := newRecoveryCodes(twoFactor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tu.twoFactorRepository.EnableTwoFactor(ctx, twoFactor.ID, step, stored); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTwoFactorAlreadyEnabled
+		}
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// checkCode accepts a code of the authenticator app or an unused recovery
+// code of an account, failed guesses count towards a lockout
+func (tu *twoFactorUseCase) checkCode(c echo.Context, accountID uuid.UUID, accountType string, code string) (*entities.TwoFactorAuth, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	account := accountID.String()
+	if err := tu.authUseCase.CheckAttempts(c, AttemptTwoFactor, account); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := tu.getEnabled(ctx, accountID, accountType)
+	if err != nil {
+		return nil, err
+	}
+
+	var accepted bool
+	if step, ok := totp.Validate(twoFactor.Secret, code, time.Now()); ok {
+		// A code seen before is refused, it may have been read over a shoulder
+		accepted, err = tu.twoFactorRepository.UseStep(ctx, twoFactor.ID, step)
+	} else {
+		accepted, err = tu.twoFactorRepository.UseRecoveryCode(ctx, twoFactor.ID, hashRecoveryCode(code))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !accepted {
+		tu.authUseCase.CountAttempt(c, AttemptTwoFactor, account)
+		return nil, err_util.ErrInvalidTwoFactorCode
+	}
+	tu.authUseCase.ClearAttempts(AttemptTwoFactor, account)
+
+	return twoFactor, nil
+}
+
+func (tu *twoFactorUseCase) getEnabled(ctx context.Context, accountID uuid.UUID, accountType string) (*entities.TwoFactorAuth, error) {
+	twoFactor, err := tu.twoFactorRepository.GetTwoFactor(ctx, accountID, accountType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+	if twoFactor.EnabledAt == nil {
+		return nil, err_util.ErrTwoFactorNotEnabled
+	}
+
+	return twoFactor, nil
+}
+
+// getEmail returns the email of a user or an admin, authenticator apps show
+// it next to the issuer
+func (tu *twoFactorUseCase) getEmail(ctx context.Context, accountID uuid.UUID, accountType string) (string, error) {
+	if accountType == entities.AccountTypeAdmin {
+		admin, err := tu.adminRepository.GetAdminByID(ctx, accountID)
+		if err != nil {
+			return "", err
+		}
+		// GetAdminByID returns no admin and no error when there is none
+		if admin == nil {
+			return "", err_util.ErrNotFound
+		}
+		return admin.Email, nil
+	}
+
+	user, err := tu.userRepository.GetUserByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err_util.ErrNotFound
+		}
+		return "", err
+	}
+	return user.Email, nil
+}
+
+func (tu *twoFactorUseCase) getChallenge(challengeToken string) (*twoFactorChallenge, error) {
+	value, err := tu.redisClient.Get(twoFactorChallengeKey(challengeToken))
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, err_util.ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal([]byte(value), &challenge); err != nil {
+		return nil, err_util.ErrInvalidTwoFactorChallenge
+	}
+
+	return &challenge, nil
+}
+
+// finishLogin uses up a challenge and starts the session it was for
+func (tu *twoFactorUseCase) finishLogin(c echo.Context, challengeToken string, challenge *twoFactorChallenge) (*dto.TokenResponse, error) {
+	if _, err := tu.redisClient.GetDel(twoFactorChallengeKey(challengeToken)); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, err_util.ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	return tu.authUseCase.IssueTokens(c, challenge.AccountID, challenge.Role, challenge.Email)
+}
+
+// twoFactorRequired tells whether accounts of the type must use two-factor
+// authentication. Admins must when REQUIRE_ADMIN_2FA is true, users never must.
+func twoFactorRequired(accountType string) bool {
+	return accountType == entities.AccountTypeAdmin && os.Getenv("REQUIRE_ADMIN_2FA") == "true"
+}
+
+// newRecoveryCodes returns new recovery codes such as "abcd-efgh" and their
+// hashes to store
+func newRecoveryCodes(twoFactorID uuid.UUID) ([]string, []entities.TwoFactorRecoveryCodes, error) {
+	codes := make([]string, recoveryCodeCount)
+	stored := make([]entities.TwoFactorRecoveryCodes, recoveryCodeCount)
+
+	for i := range codes {
+		random := make([]byte, 5)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(random))
+		codes[i] = code[:4] + "-" + code[4:]
+		stored[i] = entities.TwoFactorRecoveryCodes{
+			ID:          uuid.New(),
+			TwoFactorID: twoFactorID,
+			CodeHash:    hashRecoveryCode(code),
+		}
+	}
+
+	return codes, stored, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func twoFactorChallengeKey(challengeToken string) string {
+	return "2fa:challenge:" + challengeToken
+}
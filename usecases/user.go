@@ -2,27 +2,56 @@ package usecases
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"fmt"
 	cs "kreasi-nusantara-api/drivers/cloudinary"
 	dto "kreasi-nusantara-api/dto/user"
 	"kreasi-nusantara-api/entities"
 	"kreasi-nusantara-api/repositories"
 	"kreasi-nusantara-api/utils/email"
+	err_util "kreasi-nusantara-api/utils/error"
 	"kreasi-nusantara-api/utils/otp"
 	"kreasi-nusantara-api/utils/password"
+	"kreasi-nusantara-api/utils/sms"
 	"kreasi-nusantara-api/utils/token"
+	"os"
 	"time"
 
 	"kreasi-nusantara-api/drivers/redis"
 
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// otpLength is the number of digits of a one-time password
+	otpLength = 6
+	// maxOTPGuesses is how many wrong guesses invalidate a one-time password
+	maxOTPGuesses = 5
+	// otpTTL is how long a one-time password can be used
+	otpTTL = 10 * time.Minute
+	// defaultUnverifiedAccountTTL is how long accounts may stay unverified
+	// when UNVERIFIED_ACCOUNT_TTL is not set
+	defaultUnverifiedAccountTTL = 72 * time.Hour
+)
+
+// What a one-time password was sent for. Each purpose has its own code so
+// one flow cannot consume the code of another.
+const (
+	OTPPurposeVerifyEmail   = "verify_email"
+	OTPPurposeResetPassword = "reset_password"
+	OTPPurposeChangeEmail   = "change_email"
+	OTPPurposeVerifyPhone   = "verify_phone"
 )
 
 type UserUseCase interface {
 	// Authentication
 	Register(c echo.Context, req *dto.RegisterRequest) error
 	VerifyOTP(c echo.Context, req *dto.VerifyOTPRequest) error
+	ResendOTP(c echo.Context, req *dto.ResendOTPRequest) error
 	Login(c echo.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
 	ForgotPassword(c echo.Context, req *dto.ForgotPasswordRequest) error
 	ResetPassword(c echo.Context, req *dto.ResetPasswordRequest) error
@@ -31,38 +60,57 @@ type UserUseCase interface {
 	GetUserByID(c echo.Context, id uuid.UUID) (*dto.UserProfileResponse, error)
 	UpdateProfile(c echo.Context, id uuid.UUID, req *dto.UpdateProfileRequest) error
 	DeleteProfile(c echo.Context, id uuid.UUID) error
+	ExportData(c echo.Context, id uuid.UUID, format string) ([]byte, error)
 	UploadProfilePhoto(c echo.Context, id uuid.UUID, req *dto.UserProfilePhotoRequest) error
 	DeleteProfilePhoto(c echo.Context, id uuid.UUID) error
 	ChangePassword(c echo.Context, id uuid.UUID, req *dto.ChangePasswordRequest) error
+
+	// Contact verification
+	RequestEmailChange(c echo.Context, id uuid.UUID, req *dto.ChangeEmailRequest) error
+	ConfirmEmailChange(c echo.Context, id uuid.UUID, req *dto.ConfirmOTPRequest) error
+	SendPhoneOTP(c echo.Context, id uuid.UUID) error
+	VerifyPhone(c echo.Context, id uuid.UUID, req *dto.ConfirmOTPRequest) error
 }
 
 type userUseCase struct {
 	userRepo          repositories.UserRepository
+	userDataRepo      repositories.UserDataRepository
 	passwordUtil      password.PasswordUtil
 	redisClient       redis.RedisClient
 	cloudinaryService cs.CloudinaryService
 	otpUtil           otp.OTPUtil
 	emailUtil         email.EmailUtil
+	smsUtil           sms.SMSUtil
 	tokenUtil         token.TokenUtil
+	authUseCase       AuthUseCase
+	twoFactorUseCase  TwoFactorUseCase
 }
 
 func NewUserUseCase(
 	userRepo repositories.UserRepository,
+	userDataRepo repositories.UserDataRepository,
 	passwordUtil password.PasswordUtil,
 	redisClient redis.RedisClient,
 	cloudinaryService cs.CloudinaryService,
 	otpUtil otp.OTPUtil,
 	emailUtil email.EmailUtil,
+	smsUtil sms.SMSUtil,
 	tokenUtil token.TokenUtil,
+	authUseCase AuthUseCase,
+	twoFactorUseCase TwoFactorUseCase,
 ) *userUseCase {
 	return &userUseCase{
 		userRepo:          userRepo,
+		userDataRepo:      userDataRepo,
 		passwordUtil:      passwordUtil,
 		redisClient:       redisClient,
 		cloudinaryService: cloudinaryService,
 		otpUtil:           otpUtil,
 		emailUtil:         emailUtil,
+		smsUtil:           smsUtil,
 		tokenUtil:         tokenUtil,
+		authUseCase:       authUseCase,
+		twoFactorUseCase:  twoFactorUseCase,
 	}
 }
 
@@ -75,6 +123,7 @@ func (uc *userUseCase) Register(c echo.Context, req *dto.RegisterRequest) error
 		return err
 	}
 
+	verifyBy := time.Now().Add(unverifiedAccountTTL())
 	user := &entities.User{
 		ID:        uuid.New(),
 		Username:  req.Username,
@@ -82,69 +131,101 @@ func (uc *userUseCase) Register(c echo.Context, req *dto.RegisterRequest) error
 		LastName:  req.LastName,
 		Email:     req.Email,
 		Password:  hashedPassword,
+		VerifyBy:  &verifyBy,
 	}
 
 	if err := uc.userRepo.CreateUser(ctx, user); err != nil {
 		return err
 	}
 
-	otpCode, err := uc.otpUtil.GenerateOTP(4)
-	if err != nil {
-		return err
-	}
+	return uc.sendOTP(OTPPurposeVerifyEmail, user.Email)
+}
 
-	err = uc.redisClient.Set(user.Email, otpCode, 10*time.Minute)
-	if err != nil {
+func (uc *userUseCase) VerifyOTP(c echo.Context, req *dto.VerifyOTPRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	if err := uc.checkOTP(c, OTPPurposeVerifyEmail, req.Email, req.OTP); err != nil {
 		return err
 	}
-
-	err = uc.emailUtil.SendOTP(user.Email, otpCode)
+	err := uc.userRepo.VerifyUser(ctx, req.Email)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return uc.redisClient.Del(otpKey(OTPPurposeVerifyEmail, req.Email))
 }
 
-func (uc *userUseCase) VerifyOTP(c echo.Context, req *dto.VerifyOTPRequest) error {
+// ResendOTP sends a new one-time password for a verification or password
+// reset that is still pending
+func (uc *userUseCase) ResendOTP(c echo.Context, req *dto.ResendOTPRequest) error {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
-	storedOTP, err := uc.redisClient.Get(req.Email)
-	if err != nil {
+	if err := uc.limitOTPSend(c, req.Email); err != nil {
 		return err
 	}
-	if storedOTP != req.OTP {
-		return errors.New("invalid otp")
-	}
-	err = uc.userRepo.VerifyUser(ctx, req.Email)
+
+	user, err := uc.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		return err
 	}
-	return uc.redisClient.Del(req.Email)
+
+	if req.Purpose == OTPPurposeVerifyEmail && user.IsVerified {
+		return err_util.ErrAlreadyVerified
+	}
+
+	return uc.sendOTP(req.Purpose, user.Email)
 }
 
 func (uc *userUseCase) Login(c echo.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
+	if err := uc.authUseCase.CheckAttempts(c, AttemptLogin, req.Email); err != nil {
+		return nil, err
+	}
+
+	// Unknown emails count too, so accounts cannot be probed without limit
 	user, err := uc.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
+		uc.authUseCase.CountAttempt(c, AttemptLogin, req.Email)
 		return nil, err
 	}
 
 	if err := uc.passwordUtil.VerifyPassword(req.Password, user.Password); err != nil {
+		uc.authUseCase.CountAttempt(c, AttemptLogin, req.Email)
+		return nil, err
+	}
+	uc.authUseCase.ClearAttempts(AttemptLogin, req.Email)
+
+	// Checked after the password so it does not reveal which emails are registered
+	if !user.IsVerified {
+		return nil, err_util.ErrEmailNotVerified
+	}
+
+	challenge, err := uc.twoFactorUseCase.Challenge(c, user.ID, entities.AccountTypeUser, "user", user.Email)
+	if err != nil {
 		return nil, err
 	}
+	if challenge != nil {
+		return &dto.LoginResponse{
+			Username:          user.Username,
+			Email:             user.Email,
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge.ChallengeToken,
+		}, nil
+	}
 
-	token, err := uc.tokenUtil.GenerateToken(user.ID, "user")
+	tokens, err := uc.authUseCase.IssueTokens(c, user.ID, "user", user.Email)
 	if err != nil {
 		return nil, err
 	}
 	return &dto.LoginResponse{
-		Username: user.Username,
-		Email:    user.Email,
-		Token:    token,
+		Username:     user.Username,
+		Email:        user.Email,
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 	}, nil
 }
 
@@ -152,26 +233,16 @@ func (uc *userUseCase) ForgotPassword(c echo.Context, req *dto.ForgotPasswordReq
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
-	user, err := uc.userRepo.GetUserByEmail(ctx, req.Email)
-	if err != nil {
+	if err := uc.limitOTPSend(c, req.Email); err != nil {
 		return err
 	}
 
-	otpCode, err := uc.otpUtil.GenerateOTP(4)
-	if err != nil {
-		return err
-	}
-
-	err = uc.redisClient.Set(user.Email, otpCode, 10*time.Minute)
+	user, err := uc.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		return err
 	}
 
-	err = uc.emailUtil.SendOTP(user.Email, otpCode)
-	if err != nil {
-		return err
-	}
-	return nil
+	return uc.sendOTP(OTPPurposeResetPassword, user.Email)
 }
 
 func (uc *userUseCase) ResetPassword(c echo.Context, req *dto.ResetPasswordRequest) error {
@@ -182,6 +253,10 @@ func (uc *userUseCase) ResetPassword(c echo.Context, req *dto.ResetPasswordReque
 		return errors.New("passwords do not match")
 	}
 
+	if err := uc.checkOTP(c, OTPPurposeResetPassword, req.Email, req.OTP); err != nil {
+		return err
+	}
+
 	hashedPassword, err := uc.passwordUtil.HashPassword(req.NewPassword)
 	if err != nil {
 		return err
@@ -191,7 +266,18 @@ func (uc *userUseCase) ResetPassword(c echo.Context, req *dto.ResetPasswordReque
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if err := uc.redisClient.Del(otpKey(OTPPurposeResetPassword, req.Email)); err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+
+	// Sign out every device that used the old password
+	return uc.authUseCase.RevokeAllSessions(ctx, user.ID)
 }
 
 func (uc *userUseCase) GetUserByID(c echo.Context, id uuid.UUID) (*dto.UserProfileResponse, error) {
@@ -204,13 +290,14 @@ func (uc *userUseCase) GetUserByID(c echo.Context, id uuid.UUID) (*dto.UserProfi
 	}
 
 	return &dto.UserProfileResponse{
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		Photo:     user.Photo,
-		Bio:       user.Bio,
+		Username:        user.Username,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		IsPhoneVerified: user.IsPhoneVerified,
+		Photo:           user.Photo,
+		Bio:             user.Bio,
 	}, nil
 }
 
@@ -218,6 +305,11 @@ func (uc *userUseCase) UpdateProfile(c echo.Context, id uuid.UUID, req *dto.Upda
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
+	current, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	user := &entities.User{
 		ID:        id,
 		FirstName: req.FirstName,
@@ -228,16 +320,40 @@ func (uc *userUseCase) UpdateProfile(c echo.Context, id uuid.UUID, req *dto.Upda
 	if err := uc.userRepo.UpdateProfile(ctx, user); err != nil {
 		return err
 	}
+
+	// A new number has to be verified again
+	if req.Phone != nil && (current.Phone == nil || *current.Phone != *req.Phone) && current.IsPhoneVerified {
+		return uc.userRepo.SetPhoneVerified(ctx, id, false)
+	}
 	return nil
 }
 
+// DeleteProfile anonymizes the account of a user. Orders and tickets are
+// kept for bookkeeping without the personal details of the user.
 func (uc *userUseCase) DeleteProfile(c echo.Context, id uuid.UUID) error {
 	ctx, cancel := context.WithCancel(c.Request().Context())
 	defer cancel()
 
-	if err := uc.userRepo.DeleteProfile(ctx, id); err != nil {
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
 		return err
 	}
+
+	if err := uc.userDataRepo.AnonymizeUser(ctx, id); err != nil {
+		return err
+	}
+
+	if err := uc.authUseCase.RevokeAllSessions(ctx, id); err != nil {
+		logrus.Errorf("failed to revoke sessions of deleted user %s: %v", id, err)
+	}
+	if err := uc.redisClient.Del(chatHistoryKey(id)); err != nil {
+		logrus.Errorf("failed to delete chat history of deleted user %s: %v", id, err)
+	}
+	if user.Photo != nil {
+		if err := uc.cloudinaryService.DeleteImage(ctx, *user.Photo); err != nil {
+			logrus.Errorf("failed to delete photo of deleted user %s: %v", id, err)
+		}
+	}
 	return nil
 }
 
@@ -332,5 +448,131 @@ func (uc *userUseCase) ChangePassword(c echo.Context, id uuid.UUID, req *dto.Cha
 	if err != nil {
 		return err
 	}
+
+	// Sign out every device that used the old password
+	return uc.authUseCase.RevokeAllSessions(ctx, user.ID)
+}
+
+// checkOTP compares a one-time password with the one sent to the email address
+// for the purpose. The code is invalidated after maxOTPGuesses wrong guesses,
+// and both the account and the IP address are locked out after repeated failures.
+func (uc *userUseCase) checkOTP(c echo.Context, purpose string, email string, otp string) error {
+	if err := uc.authUseCase.CheckAttempts(c, AttemptOTP, email); err != nil {
+		return err
+	}
+
+	// A code that expired or was invalidated is simply wrong
+	key := otpKey(purpose, email)
+	storedOTP, err := uc.redisClient.Get(key)
+	if errors.Is(err, goredis.Nil) {
+		return errors.New("invalid otp")
+	}
+	if err != nil {
+		return err
+	}
+
+	guessesKey := otpGuessesKey(purpose, email)
+	if subtle.ConstantTimeCompare([]byte(storedOTP), []byte(otp)) != 1 {
+		uc.authUseCase.CountAttempt(c, AttemptOTP, email)
+
+		guesses, err := uc.redisClient.IncrWithExpiry(guessesKey, 10*time.Minute)
+		if err != nil {
+			return err
+		}
+		if guesses >= maxOTPGuesses {
+			if err := uc.redisClient.Del(key); err != nil {
+				return err
+			}
+			if err := uc.redisClient.Del(guessesKey); err != nil {
+				return err
+			}
+			uc.authUseCase.RecordSecurityEvent(c, entities.SecurityEventOTPInvalidated, AttemptOTP, email,
+				fmt.Sprintf("one-time password invalidated after %d wrong guesses", guesses))
+		}
+		return errors.New("invalid otp")
+	}
+
+	uc.authUseCase.ClearAttempts(AttemptOTP, email)
+	return uc.redisClient.Del(guessesKey)
+}
+
+// sendOTP emails a new one-time password
+func (uc *userUseCase) sendOTP(purpose string, email string) error {
+	otpCode, err := uc.storeOTP(purpose, email)
+	if err != nil {
+		return err
+	}
+
+	return uc.emailUtil.SendOTP(email, otpCode)
+}
+
+// storeOTP generates a one-time password for the purpose, which replaces any
+// earlier code and comes with a fresh set of guesses
+func (uc *userUseCase) storeOTP(purpose string, account string) (string, error) {
+	otpCode, err := uc.otpUtil.GenerateOTP(otpLength)
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.redisClient.Set(otpKey(purpose, account), otpCode, otpTTL); err != nil {
+		return "", err
+	}
+
+	if err := uc.redisClient.Del(otpGuessesKey(purpose, account)); err != nil {
+		return "", err
+	}
+
+	return otpCode, nil
+}
+
+// limitOTPSend counts every request for a code, since each new code brings
+// new guesses
+func (uc *userUseCase) limitOTPSend(c echo.Context, email string) error {
+	if err := uc.authUseCase.CheckAttempts(c, AttemptOTPSend, email); err != nil {
+		return err
+	}
+
+	uc.authUseCase.CountAttempt(c, AttemptOTPSend, email)
 	return nil
 }
+
+// RunUnverifiedCleanup deletes accounts that were not verified within the
+// period set by UNVERIFIED_ACCOUNT_TTL after registering, freeing their email
+// and username
+func (uc *userUseCase) RunUnverifiedCleanup(ctx context.Context, interval time.Duration) {
+	log := logrus.New()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := uc.userRepo.DeleteUnverifiedUsers(ctx, time.Now())
+			if err != nil {
+				log.WithError(err).Error("Failed to delete unverified accounts")
+				continue
+			}
+			if deleted > 0 {
+				log.WithField("count", deleted).Info("Deleted unverified accounts")
+			}
+		}
+	}
+}
+
+func unverifiedAccountTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("UNVERIFIED_ACCOUNT_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultUnverifiedAccountTTL
+	}
+	return ttl
+}
+
+func otpKey(purpose string, email string) string {
+	return fmt.Sprintf("otp:%s:%s", purpose, email)
+}
+
+func otpGuessesKey(purpose string, email string) string {
+	return fmt.Sprintf("otp:guesses:%s:%s", purpose, email)
+}
@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	dto "kreasi-nusantara-api/dto/user"
+	err_util "kreasi-nusantara-api/utils/error"
+	"strings"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RequestEmailChange sends a code to the new email address. The email only
+// changes once the code is confirmed, so nobody can take over an address
+// they do not own.
+func (uc *userUseCase) RequestEmailChange(c echo.Context, id uuid.UUID, req *dto.ChangeEmailRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.Email, req.Email) {
+		return err_util.ErrSameEmail
+	}
+
+	if _, err := uc.userRepo.GetUserByEmail(ctx, req.Email); err == nil {
+		return err_util.ErrEmailTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	account := emailChangeAccount(id, req.Email)
+	if err := uc.limitOTPSend(c, account); err != nil {
+		return err
+	}
+
+	if err := uc.redisClient.Set(emailChangeKey(id), req.Email, otpTTL); err != nil {
+		return err
+	}
+
+	otpCode, err := uc.storeOTP(OTPPurposeChangeEmail, account)
+	if err != nil {
+		return err
+	}
+
+	return uc.emailUtil.SendOTP(req.Email, otpCode)
+}
+
+// ConfirmEmailChange switches to the requested email address and lets the
+// old address know, in case the change was not made by its owner
+func (uc *userUseCase) ConfirmEmailChange(c echo.Context, id uuid.UUID, req *dto.ConfirmOTPRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	newEmail, err := uc.redisClient.Get(emailChangeKey(id))
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return err_util.ErrNoEmailChange
+		}
+		return err
+	}
+
+	if err := uc.checkOTP(c, OTPPurposeChangeEmail, emailChangeAccount(id, newEmail), req.OTP); err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.UpdateEmail(ctx, id, newEmail); err != nil {
+		// Someone registered the address since the code was sent
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return err_util.ErrEmailTaken
+		}
+		return err
+	}
+
+	if err := uc.redisClient.Del(emailChangeKey(id)); err != nil {
+		logrus.WithError(err).Error("Failed to clear email change")
+	}
+	if err := uc.redisClient.Del(otpKey(OTPPurposeChangeEmail, emailChangeAccount(id, newEmail))); err != nil {
+		logrus.WithError(err).Error("Failed to clear email change code")
+	}
+
+	go uc.notifyEmailChanged(user.Email, newEmail)
+	return nil
+}
+
+// SendPhoneOTP texts a code to the phone number of the profile
+func (uc *userUseCase) SendPhoneOTP(c echo.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Phone == nil || *user.Phone == "" {
+		return err_util.ErrPhoneNotSet
+	}
+	if user.IsPhoneVerified {
+		return err_util.ErrPhoneAlreadyVerified
+	}
+
+	account := phoneAccount(id, *user.Phone)
+	if err := uc.limitOTPSend(c, account); err != nil {
+		return err
+	}
+
+	otpCode, err := uc.storeOTP(OTPPurposeVerifyPhone, account)
+	if err != nil {
+		return err
+	}
+
+	return uc.smsUtil.SendSMS(*user.Phone, "Your Kreasi Nusantara verification code is: "+otpCode)
+}
+
+// VerifyPhone marks the phone number as verified. The code only works for the
+// number it was sent to, so changing the number in between needs a new code.
+func (uc *userUseCase) VerifyPhone(c echo.Context, id uuid.UUID, req *dto.ConfirmOTPRequest) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	user, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Phone == nil || *user.Phone == "" {
+		return err_util.ErrPhoneNotSet
+	}
+	if user.IsPhoneVerified {
+		return err_util.ErrPhoneAlreadyVerified
+	}
+
+	account := phoneAccount(id, *user.Phone)
+	if err := uc.checkOTP(c, OTPPurposeVerifyPhone, account, req.OTP); err != nil {
+		return err
+	}
+
+	if err := uc.redisClient.Del(otpKey(OTPPurposeVerifyPhone, account)); err != nil {
+		logrus.WithError(err).Error("Failed to clear phone verification code")
+	}
+
+	return uc.userRepo.SetPhoneVerified(ctx, id, true)
+}
+
+func (uc *userUseCase) notifyEmailChanged(oldEmail string, newEmail string) {
+	body := fmt.Sprintf(
+		"The email address of your Kreasi Nusantara account was changed to %s.\n\nIf you did not make this change, contact us right away.",
+		newEmail,
+	)
+
+	if err := uc.emailUtil.SendEmail(oldEmail, "Kreasi Nusantara Email Changed", body); err != nil {
+		logrus.WithError(err).Error("Failed to notify old email address")
+	}
+}
+
+// The codes of email changes and phone verification belong to a user and an
+// address, so they stop working when either changes
+func emailChangeAccount(id uuid.UUID, email string) string {
+	return id.String() + ":" + strings.ToLower(email)
+}
+
+func phoneAccount(id uuid.UUID, phone string) string {
+	return id.String() + ":" + phone
+}
+
+func emailChangeKey(id uuid.UUID) string {
+	return "email_change:" + id.String()
+}
@@ -0,0 +1,285 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	dto "kreasi-nusantara-api/dto/user"
+	"kreasi-nusantara-api/entities"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Supported data export formats
+const (
+	ExportFormatZip  = "zip"
+	ExportFormatJSON = "json"
+)
+
+// ExportData gathers everything stored about a user. The zip archive holds
+// one JSON file per section, the json format a single document.
+func (uc *userUseCase) ExportData(c echo.Context, id uuid.UUID, format string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	data, err := uc.userDataRepo.GetUserData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.redisClient.Get(chatHistoryKey(id))
+	if err != nil && err.Error() != "redis: nil" {
+		return nil, err
+	}
+
+	export := newUserDataExport(data, history)
+	if format == ExportFormatJSON {
+		return json.MarshalIndent(export, "", "  ")
+	}
+
+	sections := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"addresses.json", export.Addresses},
+		{"product_transactions.json", export.ProductTransactions},
+		{"event_transactions.json", export.EventTransactions},
+		{"tickets.json", export.Tickets},
+		{"comments.json", export.Comments},
+		{"product_reviews.json", export.ProductReviews},
+		{"event_reviews.json", export.EventReviews},
+		{"waitlist.json", export.Waitlist},
+		{"linked_accounts.json", export.LinkedAccounts},
+		{"sessions.json", export.Sessions},
+		{"chat_history.json", export.ChatHistory},
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	for _, section := range sections {
+		content, err := json.MarshalIndent(section.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     section.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func newUserDataExport(data *entities.UserData, history string) *dto.UserDataExport {
+	user := data.User
+	export := &dto.UserDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: dto.ExportProfile{
+			ID:              user.ID,
+			Username:        user.Username,
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			Email:           user.Email,
+			Phone:           user.Phone,
+			IsPhoneVerified: user.IsPhoneVerified,
+			Photo:           user.Photo,
+			Bio:             user.Bio,
+			IsVerified:      user.IsVerified,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		Addresses:           []dto.ExportAddress{},
+		ProductTransactions: []dto.ExportProductTransaction{},
+		EventTransactions:   []dto.ExportEventTransaction{},
+		Tickets:             []dto.ExportTicket{},
+		Comments:            []dto.ExportComment{},
+		ProductReviews:      []dto.ExportReview{},
+		EventReviews:        []dto.ExportReview{},
+		Waitlist:            []dto.ExportWaitlist{},
+		LinkedAccounts:      []dto.IdentityResponse{},
+		Sessions:            []dto.ExportSession{},
+		ChatHistory:         parseChatHistory(history),
+	}
+
+	for _, address := range data.Addresses {
+		export.Addresses = append(export.Addresses, dto.ExportAddress{
+			Label:         address.Label,
+			RecipientName: address.RecipientName,
+			Phone:         address.Phone,
+			Address:       address.Address,
+			City:          address.City,
+			Province:      address.Province,
+			PostalCode:    address.PostalCode,
+			IsPrimary:     address.IsPrimary,
+			CreatedAt:     address.CreatedAt,
+		})
+	}
+
+	cartItems := make(map[uuid.UUID][]dto.ExportProductItem)
+	for _, cart := range data.Carts {
+		items := []dto.ExportProductItem{}
+		for _, item := range cart.Items {
+			exportItem := dto.ExportProductItem{
+				ProductID: item.ProductVariant.ProductID,
+				Size:      item.ProductVariant.Size,
+				Quantity:  item.Quantity,
+			}
+			if item.ProductVariant.Products != nil {
+				exportItem.ProductName = item.ProductVariant.Products.Name
+			}
+			items = append(items, exportItem)
+		}
+		cartItems[cart.ID] = items
+	}
+	for _, transaction := range data.ProductTransactions {
+		items, ok := cartItems[transaction.CartId]
+		if !ok {
+			items = []dto.ExportProductItem{}
+		}
+		export.ProductTransactions = append(export.ProductTransactions, dto.ExportProductTransaction{
+			ID:                transaction.ID,
+			TransactionDate:   transaction.TracsactionDate,
+			TotalAmount:       transaction.TotalAmount,
+			TransactionStatus: transaction.TransactionStatus,
+			TransactionMethod: transaction.TransactionMethod,
+			Items:             items,
+		})
+	}
+
+	for _, transaction := range data.EventTransactions {
+		export.EventTransactions = append(export.EventTransactions, dto.ExportEventTransaction{
+			ID:                transaction.ID,
+			EventPriceID:      transaction.EventPriceID,
+			TransactionDate:   transaction.TransactionDate,
+			Quantity:          transaction.Quantity,
+			TotalAmount:       transaction.TotalAmount,
+			TransactionStatus: transaction.TransactionStatus,
+			TransactionMethod: transaction.TransactionMethod,
+			BuyerName:         transaction.Buyer.FullName,
+			BuyerEmail:        transaction.Buyer.Email,
+			BuyerPhone:        transaction.Buyer.Phone,
+			BuyerIdentity:     transaction.Buyer.IdentityNumber,
+		})
+	}
+
+	for _, ticket := range data.Tickets {
+		export.Tickets = append(export.Tickets, dto.ExportTicket{
+			Code:           ticket.Code,
+			EventID:        ticket.EventID,
+			EventName:      ticket.Event.Name,
+			EventDate:      ticket.Event.Date,
+			FullName:       ticket.FullName,
+			Email:          ticket.Email,
+			Phone:          ticket.Phone,
+			IdentityNumber: ticket.IdentityNumber,
+			CreatedAt:      ticket.CreatedAt,
+		})
+	}
+
+	for _, comment := range data.Comments {
+		export.Comments = append(export.Comments, dto.ExportComment{
+			ID:        comment.ID,
+			ArticleID: comment.ArticleID,
+			Content:   comment.Content,
+			Hidden:    comment.Hidden,
+			CreatedAt: comment.CreatedAt,
+			EditedAt:  comment.EditedAt,
+		})
+	}
+	for _, reply := range data.Replies {
+		replyTo := reply.CommentID
+		if reply.ParentReplyID != nil {
+			replyTo = *reply.ParentReplyID
+		}
+		export.Comments = append(export.Comments, dto.ExportComment{
+			ID:        reply.ID,
+			ArticleID: reply.ArticleID,
+			ReplyTo:   &replyTo,
+			Content:   reply.Content,
+			Hidden:    reply.Hidden,
+			CreatedAt: reply.CreatedAt,
+			EditedAt:  reply.EditedAt,
+		})
+	}
+
+	for _, review := range data.ProductReviews {
+		export.ProductReviews = append(export.ProductReviews, dto.ExportReview{
+			SubjectID: review.ProductID,
+			Rating:    review.Rating,
+			Review:    review.Review,
+			CreatedAt: review.CreatedAt,
+		})
+	}
+	for _, review := range data.EventReviews {
+		export.EventReviews = append(export.EventReviews, dto.ExportReview{
+			SubjectID: review.EventID,
+			Rating:    review.Rating,
+			Review:    review.Review,
+			CreatedAt: review.CreatedAt,
+		})
+	}
+
+	for _, entry := range data.Waitlist {
+		export.Waitlist = append(export.Waitlist, dto.ExportWaitlist{
+			EventPriceID: entry.EventPriceID,
+			Quantity:     entry.Quantity,
+			Status:       entry.Status,
+			CreatedAt:    entry.CreatedAt,
+		})
+	}
+
+	for _, identity := range data.Identities {
+		export.LinkedAccounts = append(export.LinkedAccounts, dto.IdentityResponse{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	for _, session := range data.Sessions {
+		export.Sessions = append(export.Sessions, dto.ExportSession{
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastSeenAt: session.LastSeenAt,
+			RevokedAt:  session.RevokedAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+
+	return export
+}
+
+// parseChatHistory splits the stored chatbot conversation into messages.
+// Answers may span several lines, so lines without a speaker belong to the
+// message before them.
+func parseChatHistory(history string) []dto.ExportChatMessage {
+	messages := []dto.ExportChatMessage{}
+	for _, line := range strings.Split(history, "\n") {
+		switch {
+		case strings.HasPrefix(line, "User: "):
+			messages = append(messages, dto.ExportChatMessage{Role: "user", Message: strings.TrimPrefix(line, "User: ")})
+		case strings.HasPrefix(line, "Assistant: "):
+			messages = append(messages, dto.ExportChatMessage{Role: "assistant", Message: strings.TrimPrefix(line, "Assistant: ")})
+		case len(messages) > 0:
+			messages[len(messages)-1].Message += "\n" + line
+		}
+	}
+	return messages
+}
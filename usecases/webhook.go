@@ -15,12 +15,16 @@ type WebhookUsecase interface {
 type webhookUsecase struct {
 	webhookRepository repositories.WebhookRepository
 	redisClient redis.RedisClient
+	waitlistUseCase EventWaitlistUseCase
+	ticketUseCase EventTicketUseCase
 }
 
-func NewWebhookUsecase(webhookRepository repositories.WebhookRepository, redisClient redis.RedisClient) WebhookUsecase {
+func NewWebhookUsecase(webhookRepository repositories.WebhookRepository, redisClient redis.RedisClient, waitlistUseCase EventWaitlistUseCase, ticketUseCase EventTicketUseCase) WebhookUsecase {
 	return &webhookUsecase{
 		webhookRepository: webhookRepository,
 		redisClient:       redisClient,
+		waitlistUseCase:   waitlistUseCase,
+		ticketUseCase:     ticketUseCase,
 	}
 }
 
@@ -57,10 +61,38 @@ func (u *webhookUsecase) HandleNotification(c echo.Context, webhook entities.Pay
 		transactionUpdate.TransactionStatus = "canceled"
 	} else if transactionStatus == "pending" {
 		transactionUpdate.TransactionStatus = "pending"
+	} else if transactionStatus == "refund" {
+		transactionUpdate.TransactionStatus = "refunded"
 	}
 
-	if res == "event" {
-		return u.webhookRepository.HandleNotification(c.Request().Context(), webhook, transactionUpdate, "event_transactions")
+	// The key only lives for an hour, late notifications such as an expired
+	// payment are looked up in the database
+	isEvent := res == "event"
+	if res == "" {
+		isEvent, err = u.webhookRepository.IsEventTransaction(c.Request().Context(), webhook.OrderID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isEvent {
+		err := u.webhookRepository.HandleNotification(c.Request().Context(), webhook, transactionUpdate, "event_transactions")
+		if err != nil {
+			return err
+		}
+
+		// Paid bookings get their tickets, failed or refunded bookings lose their
+		// tickets and their seats go to the ticket waitlist
+		switch transactionUpdate.TransactionStatus {
+		case "paid":
+			return u.ticketUseCase.IssueTickets(c.Request().Context(), webhook.OrderID)
+		case "canceled", "rejected", "refunded":
+			if err := u.ticketUseCase.InvalidateTickets(c.Request().Context(), webhook.OrderID); err != nil {
+				return err
+			}
+			return u.waitlistUseCase.ReleaseTicketsForTransaction(c.Request().Context(), webhook.OrderID)
+		}
+		return nil
 	} 
 	return u.webhookRepository.HandleNotification(c.Request().Context(), webhook, transactionUpdate, "product_transactions")
 }
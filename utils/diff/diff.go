@@ -0,0 +1,91 @@
+package diff
+
+import "strings"
+
+const (
+	Unchanged = "unchanged"
+	Added     = "added"
+	Removed   = "removed"
+)
+
+type Line struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Lines returns a line based diff that turns from into to, computed from the
+// longest common subsequence of both texts.
+func Lines(from, to string) []Line {
+	a := splitLines(from)
+	b := splitLines(to)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := []Line{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Type: Unchanged, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Type: Removed, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Type: Added, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Type: Removed, Text: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Type: Added, Text: b[j]})
+	}
+
+	return lines
+}
+
+// Unified renders the diff as text, prefixing lines with "+", "-" or a space.
+func Unified(lines []Line) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		switch line.Type {
+		case Added:
+			sb.WriteString("+ ")
+		case Removed:
+			sb.WriteString("- ")
+		default:
+			sb.WriteString("  ")
+		}
+		sb.WriteString(line.Text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -8,6 +8,7 @@ import (
 
 type EmailUtil interface {
 	SendOTP(email string, otp string) error
+	SendEmail(email string, subject string, body string) error
 }
 
 type emailUtil struct{}
@@ -17,6 +18,10 @@ func NewEmailUtil() *emailUtil {
 }
 
 func (e *emailUtil) SendOTP(email string, otp string) error {
+	return e.SendEmail(email, "Kreasi Nusantara OTP Verification", "Your OTP code is: "+otp)
+}
+
+func (e *emailUtil) SendEmail(email string, subject string, body string) error {
 	server := mail.NewSMTPClient()
 	server.Host = os.Getenv("SMTP_HOST")
 	server.Port = 587
@@ -30,8 +35,8 @@ func (e *emailUtil) SendOTP(email string, otp string) error {
 	}
 
 	emailObj := mail.NewMSG()
-	emailObj.SetFrom(os.Getenv("EMAIL_FROM")).AddTo(email).SetSubject("Kreasi Nusantara OTP Verification")
-	emailObj.SetBody(mail.TextPlain, "Your OTP code is: "+otp)
+	emailObj.SetFrom(os.Getenv("EMAIL_FROM")).AddTo(email).SetSubject(subject)
+	emailObj.SetBody(mail.TextPlain, body)
 
 	return emailObj.Send(smtpClient)
 }
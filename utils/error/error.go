@@ -18,6 +18,50 @@ var (
 
 	// Token
 	ErrFailedGenerateToken = errors.New(message.FAILED_GENERATE_TOKEN)
+	ErrInvalidRefreshToken = errors.New(message.INVALID_REFRESH_TOKEN)
+	ErrRefreshTokenReused  = errors.New(message.REFRESH_TOKEN_REUSED)
+
+	// Brute-force Protection
+	ErrTooManyAttempts = errors.New(message.TOO_MANY_ATTEMPTS)
+
+	// Email Verification
+	ErrEmailNotVerified = errors.New(message.EMAIL_NOT_VERIFIED)
+	ErrAlreadyVerified  = errors.New(message.EMAIL_ALREADY_VERIFIED)
+
+	// Contact Verification
+	ErrSameEmail            = errors.New(message.SAME_EMAIL)
+	ErrEmailTaken           = errors.New(message.USER_EXIST)
+	ErrNoEmailChange        = errors.New(message.NO_EMAIL_CHANGE)
+	ErrPhoneNotSet          = errors.New(message.PHONE_NOT_SET)
+	ErrPhoneAlreadyVerified = errors.New(message.PHONE_ALREADY_VERIFIED)
+
+	// Social Login
+	ErrUnknownOIDCProvider   = errors.New(message.UNKNOWN_OIDC_PROVIDER)
+	ErrInvalidOIDCState      = errors.New(message.INVALID_OIDC_STATE)
+	ErrOIDCLoginFailed       = errors.New(message.OIDC_LOGIN_FAILED)
+	ErrOIDCEmailNotVerified  = errors.New(message.OIDC_EMAIL_NOT_VERIFIED)
+	ErrIdentityAlreadyLinked = errors.New(message.IDENTITY_ALREADY_LINKED)
+	ErrLastLoginMethod       = errors.New(message.LAST_LOGIN_METHOD)
+
+	// Two-Factor Authentication
+	ErrInvalidTwoFactorCode      = errors.New(message.INVALID_TWO_FACTOR_CODE)
+	ErrInvalidTwoFactorChallenge = errors.New(message.INVALID_TWO_FACTOR_CHALLENGE)
+	ErrTwoFactorAlreadyEnabled   = errors.New(message.TWO_FACTOR_ALREADY_ENABLED)
+	ErrTwoFactorNotEnabled       = errors.New(message.TWO_FACTOR_NOT_ENABLED)
+	ErrTwoFactorSetupNotStarted  = errors.New(message.TWO_FACTOR_SETUP_NOT_STARTED)
+	ErrTwoFactorRequired         = errors.New(message.TWO_FACTOR_REQUIRED)
+
+	// Admin Invitations
+	ErrInvalidInvitation    = errors.New(message.INVALID_INVITATION)
+	ErrAdminEmailExists     = errors.New(message.ADMIN_EMAIL_EXIST)
+	ErrAdminUsernameExists  = errors.New(message.ADMIN_USERNAME_EXIST)
+	ErrAdminDeactivated     = errors.New(message.ADMIN_DEACTIVATED)
+	ErrCannotDeactivateSelf = errors.New(message.CANNOT_DEACTIVATE_SELF)
+
+	// Admin Roles
+	ErrRoleNotFound      = errors.New(message.ROLE_NOT_FOUND)
+	ErrRoleNameExists    = errors.New(message.ROLE_NAME_EXIST)
+	ErrUnknownPermission = errors.New(message.UNKNOWN_PERMISSION)
 
 	// DuplicateKey 
 	ErrDuplicateKey = errors.New(message.DUPLICATE_KEY)
@@ -26,4 +70,54 @@ var (
 	ErrPageNotFound = errors.New(message.PAGE_NOT_FOUND)
 
 	ErrNotFound = errors.New(message.NOT_FOUND)
+
+	// Venues
+	ErrVenueInUse = errors.New(message.VENUE_IN_USE)
+
+	// Tickets & Waitlist
+	ErrTicketSoldOut         = errors.New(message.TICKET_SOLD_OUT)
+	ErrTicketsStillAvailable = errors.New(message.TICKETS_STILL_AVAILABLE)
+	ErrAlreadyOnWaitlist     = errors.New(message.ALREADY_ON_WAITLIST)
+	ErrInvalidReservation    = errors.New(message.INVALID_RESERVATION)
+	ErrInvalidTicketQuantity = errors.New(message.INVALID_TICKET_QUANTITY)
+
+	// Event Tickets
+	ErrTicketTransferDisabled   = errors.New(message.TICKET_TRANSFER_DISABLED)
+	ErrInvalidTransferRecipient = errors.New(message.INVALID_TRANSFER_RECIPIENT)
+	ErrTicketTransferred        = errors.New(message.TICKET_ALREADY_TRANSFERRED)
+	ErrTicketChanged            = errors.New(message.TICKET_CHANGED)
+
+	// Free Events & RSVP
+	ErrBookingNotCancellable = errors.New(message.BOOKING_NOT_CANCELLABLE)
+	ErrAlreadyRSVPed         = errors.New(message.ALREADY_RSVPED)
+	ErrPaidTicketOnRSVPEvent = errors.New(message.PAID_TICKET_ON_RSVP_EVENT)
+
+	// Article Workflow
+	ErrInvalidArticleStatus = errors.New(message.INVALID_ARTICLE_STATUS)
+	ErrInvalidPublishAt     = errors.New(message.INVALID_PUBLISH_AT)
+
+	// Article Revisions
+	ErrArticleRevisionNotFound = errors.New(message.ARTICLE_REVISION_NOT_FOUND)
+
+	// Featured Products
+	ErrInvalidArticleProducts = errors.New(message.INVALID_ARTICLE_PRODUCTS)
+
+	// Tags
+	ErrTagAlreadyExists = errors.New(message.TAG_ALREADY_EXISTS)
+	ErrInvalidTagName   = errors.New(message.INVALID_TAG_NAME)
+	ErrTooManyTags      = errors.New(message.TOO_MANY_TAGS)
+	ErrInvalidTagMerge  = errors.New(message.INVALID_TAG_MERGE)
+
+	// Comment Moderation
+	ErrCommentBlocked    = errors.New(message.COMMENT_CONTAINS_BANNED_WORDS)
+	ErrAlreadyReported   = errors.New(message.ALREADY_REPORTED_COMMENT)
+	ErrBannedWordExists  = errors.New(message.BANNED_WORD_ALREADY_EXISTS)
+	ErrInvalidBannedWord = errors.New(message.INVALID_BANNED_WORD)
+	ErrInvalidComment    = errors.New(message.INVALID_COMMENT)
+	ErrInvalidCursor     = errors.New(message.INVALID_CURSOR)
+
+	// Event Reviews
+	ErrEventNotEnded        = errors.New(message.EVENT_NOT_ENDED)
+	ErrNotEventAttendee     = errors.New(message.NOT_EVENT_ATTENDEE)
+	ErrAlreadyReviewedEvent = errors.New(message.ALREADY_REVIEWED_EVENT)
 )
@@ -55,4 +55,15 @@ func HandlePaginationResponse(c echo.Context, message string, data any, paginati
 		Pagination: pagination,
 		Link:       link,
 	})
-}
\ No newline at end of file
+}
+
+func HandleCursorResponse(c echo.Context, message string, data any, metadata *dto.CursorMetadata) error {
+	return c.JSON(http.StatusOK, &dto.CursorResponse{
+		BaseResponse: dto.BaseResponse{
+			Status:  status.STATUS_SUCCESS,
+			Message: message,
+			Data:    data,
+		},
+		Metadata: metadata,
+	})
+}
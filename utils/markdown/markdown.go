@@ -0,0 +1,367 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"kreasi-nusantara-api/utils/slug"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordsPerMinute is the reading speed used to estimate reading time
+const WordsPerMinute = 200
+
+// Heading is an entry of the table of contents
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
+
+// Document is Markdown rendered to HTML. The HTML only contains tags produced
+// by the renderer, raw HTML in the source is escaped and unsafe links are dropped.
+type Document struct {
+	HTML      string
+	TOC       []Heading
+	Summary   string
+	WordCount int
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleLinePattern    = regexp.MustCompile(`^ {0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	unorderedPattern   = regexp.MustCompile(`^ {0,3}[-*+]\s+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^ {0,3}\d{1,9}[.)]\s+(.*)$`)
+	fencePattern       = regexp.MustCompile("^ {0,3}(```|~~~)\\s*([A-Za-z0-9_+#-]*)")
+	quotePattern       = regexp.MustCompile(`^ {0,3}>\s?(.*)$`)
+	tagPattern         = regexp.MustCompile(`<[^>]*>`)
+	allowedURLSchemes  = map[string]bool{"": true, "http": true, "https": true, "mailto": true}
+	escapablePunctuals = "\\`*_{}[]()#+-.!>~|"
+)
+
+type renderer struct {
+	sb       strings.Builder
+	toc      []Heading
+	ids      map[string]int
+	summary  []string
+	wordText []string
+}
+
+// Render converts Markdown to sanitized HTML and collects its headings, the
+// text of its paragraphs and its word count.
+func Render(source string) Document {
+	r := &renderer{ids: map[string]int{}}
+
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	r.blocks(strings.Split(source, "\n"))
+
+	words := 0
+	for _, text := range r.wordText {
+		words += len(strings.Fields(text))
+	}
+
+	return Document{
+		HTML:      r.sb.String(),
+		TOC:       r.toc,
+		Summary:   strings.Join(r.summary, " "),
+		WordCount: words,
+	}
+}
+
+// ReadingTime returns the estimated minutes needed to read the given number of words
+func ReadingTime(words int) int {
+	minutes := int(math.Ceil(float64(words) / WordsPerMinute))
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// Excerpt shortens text to at most max characters without cutting a word in half
+func Excerpt(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	runes := []rune(text)[:max-1]
+	cut := strings.TrimRightFunc(string(runes), func(r rune) bool { return !unicode.IsSpace(r) })
+	if strings.TrimSpace(cut) == "" {
+		cut = string(runes)
+	}
+
+	return strings.TrimRightFunc(strings.TrimSpace(cut), unicode.IsPunct) + "…"
+}
+
+func (r *renderer) blocks(lines []string) {
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		text := strings.Join(paragraph, "\n")
+		content := r.inline(text)
+		r.sb.WriteString("<p>" + content + "</p>\n")
+		r.summary = append(r.summary, plainText(content))
+		r.wordText = append(r.wordText, plainText(content))
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			r.code(match[2], strings.Join(code, "\n"))
+			continue
+		}
+
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			r.heading(len(match[1]), match[2])
+			continue
+		}
+
+		if ruleLinePattern.MatchString(line) {
+			flush()
+			r.sb.WriteString("<hr>\n")
+			continue
+		}
+
+		if quotePattern.MatchString(line) {
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				match := quotePattern.FindStringSubmatch(lines[i])
+				if match == nil {
+					break
+				}
+				quoted = append(quoted, match[1])
+			}
+			i--
+			r.sb.WriteString("<blockquote>\n")
+			r.blocks(quoted)
+			r.sb.WriteString("</blockquote>\n")
+			continue
+		}
+
+		if unorderedPattern.MatchString(line) || orderedPattern.MatchString(line) {
+			flush()
+			i = r.list(lines, i) - 1
+			continue
+		}
+
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+
+	flush()
+}
+
+// list renders the list starting at lines[start] and returns the index of the
+// first line after it
+func (r *renderer) list(lines []string, start int) int {
+	pattern, tag := unorderedPattern, "ul"
+	if orderedPattern.MatchString(lines[start]) {
+		pattern, tag = orderedPattern, "ol"
+	}
+
+	var items []string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			items = append(items, match[1])
+			continue
+		}
+
+		// Indented lines continue the previous item
+		if strings.TrimSpace(line) != "" && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			items[len(items)-1] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+
+		break
+	}
+
+	r.sb.WriteString("<" + tag + ">\n")
+	for _, item := range items {
+		content := r.inline(item)
+		r.sb.WriteString("<li>" + content + "</li>\n")
+		r.wordText = append(r.wordText, plainText(content))
+	}
+	r.sb.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+func (r *renderer) heading(level int, text string) {
+	content := r.inline(text)
+	plain := plainText(content)
+
+	id := slug.Make(plain)
+	if id == "" {
+		id = "section"
+	}
+	r.ids[id]++
+	if n := r.ids[id]; n > 1 {
+		id = fmt.Sprintf("%s-%d", id, n)
+	}
+
+	r.toc = append(r.toc, Heading{Level: level, Text: plain, ID: id})
+	r.wordText = append(r.wordText, plain)
+	fmt.Fprintf(&r.sb, "<h%d id=\"%s\">%s</h%d>\n", level, id, content, level)
+}
+
+func (r *renderer) code(language string, code string) {
+	r.wordText = append(r.wordText, code)
+
+	if language != "" {
+		fmt.Fprintf(&r.sb, "<pre><code class=\"language-%s\">%s</code></pre>\n", html.EscapeString(language), html.EscapeString(code))
+		return
+	}
+	fmt.Fprintf(&r.sb, "<pre><code>%s</code></pre>\n", html.EscapeString(code))
+}
+
+// inline renders emphasis, code spans, links and images. Every other
+// character is escaped.
+func (r *renderer) inline(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(escapablePunctuals, text[i+1]) >= 0:
+			sb.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '\n':
+			sb.WriteString("\n")
+			i++
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				sb.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "!["):
+			if label, target, n, ok := parseLink(text[i+1:]); ok {
+				if safe, ok := safeURL(target); ok {
+					fmt.Fprintf(&sb, "<img src=\"%s\" alt=\"%s\" loading=\"lazy\">", safe, html.EscapeString(label))
+				} else {
+					sb.WriteString(html.EscapeString(label))
+				}
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if label, target, n, ok := parseLink(rest); ok {
+				if safe, ok := safeURL(target); ok {
+					fmt.Fprintf(&sb, "<a href=\"%s\" rel=\"nofollow noopener noreferrer\">%s</a>", safe, r.inline(label))
+				} else {
+					sb.WriteString(r.inline(label))
+				}
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delimiter := rest[:2]
+			if end := strings.Index(text[i+2:], delimiter); end > 0 {
+				sb.WriteString("<strong>" + r.inline(text[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordByte(text[i-1]))):
+			if end := strings.IndexByte(text[i+1:], c); end > 0 && text[i+1] != ' ' {
+				sb.WriteString("<em>" + r.inline(text[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		sb.WriteString(html.EscapeString(rest[:size]))
+		i += size
+	}
+
+	return sb.String()
+}
+
+// parseLink parses "[label](target)" at the start of text and returns the
+// number of bytes it spans
+func parseLink(text string) (label string, target string, n int, ok bool) {
+	if !strings.HasPrefix(text, "[") {
+		return "", "", 0, false
+	}
+
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 0 {
+		return "", "", 0, false
+	}
+
+	closeTarget := strings.IndexByte(text[closeLabel+2:], ')')
+	if closeTarget < 0 {
+		return "", "", 0, false
+	}
+
+	label = text[1:closeLabel]
+	target = strings.TrimSpace(text[closeLabel+2 : closeLabel+2+closeTarget])
+
+	// Drop an optional title, e.g. [label](url "title")
+	if fields := strings.Fields(target); len(fields) > 0 {
+		target = fields[0]
+	}
+
+	return label, target, closeLabel + 3 + closeTarget, true
+}
+
+// safeURL escapes a link target for an HTML attribute, rejecting schemes such
+// as javascript: that can run scripts
+func safeURL(target string) (string, bool) {
+	if target == "" {
+		return "", false
+	}
+
+	for _, r := range target {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return "", false
+		}
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil || !allowedURLSchemes[strings.ToLower(parsed.Scheme)] {
+		return "", false
+	}
+
+	return html.EscapeString(target), true
+}
+
+func plainText(content string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(content, ""))
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,185 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"kreasi-nusantara-api/utils/oidc"
+	"kreasi-nusantara-api/utils/oidc/oidctest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const clientID = "kreasi-nusantara"
+
+var account = oidctest.Account{
+	Subject:       "subject-1",
+	Email:         "budi@example.com",
+	EmailVerified: true,
+	GivenName:     "Budi",
+	FamilyName:    "Santoso",
+}
+
+func newProvider(issuer *oidctest.Issuer) oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		Name:         "test",
+		Issuer:       issuer.URL(),
+		ClientID:     clientID,
+		ClientSecret: "secret",
+		RedirectURL:  "https://kreasinusantara.example/auth/test/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+}
+
+// login runs the authorization code flow and returns the result of the exchange
+func login(t *testing.T, issuer *oidctest.Issuer, provider oidc.Provider, nonce string, codeVerifier string, exchangeVerifier string) (*oidc.Claims, error) {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", nonce, codeVerifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+
+	code, state := issuer.Authorize(t, authURL, account)
+	if state != "state-1" {
+		t.Fatalf("state = %q, want %q", state, "state-1")
+	}
+
+	return provider.Exchange(context.Background(), code, exchangeVerifier, nonce)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, clientID)
+	provider := newProvider(issuer)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+	query := parsed.Query()
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             clientID,
+		"redirect_uri":          "https://kreasinusantara.example/auth/test/callback",
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        oidc.CodeChallenge("verifier-1"),
+		"code_challenge_method": "S256",
+	}
+	for param, value := range want {
+		if got := query.Get(param); got != value {
+			t.Errorf("%s = %q, want %q", param, got, value)
+		}
+	}
+	if query.Get("code_challenge") == "verifier-1" {
+		t.Error("code_challenge leaks the code verifier")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, clientID)
+	provider := newProvider(issuer)
+
+	claims, err := login(t, issuer, provider, "nonce-1", "verifier-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	want := oidc.Claims{
+		Subject:       account.Subject,
+		Email:         account.Email,
+		EmailVerified: true,
+		GivenName:     account.GivenName,
+		FamilyName:    account.FamilyName,
+	}
+	if *claims != want {
+		t.Errorf("Exchange() = %+v, want %+v", *claims, want)
+	}
+}
+
+func TestExchangeEmailVerifiedAsString(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, clientID)
+	issuer.Tamper = func(claims jwt.MapClaims) {
+		claims["email_verified"] = "true"
+	}
+
+	claims, err := login(t, issuer, newProvider(issuer), "nonce-1", "verifier-1", "verifier-1")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if !claims.EmailVerified {
+		t.Error("EmailVerified = false, want true")
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		tamper     func(claims jwt.MapClaims)
+		foreignKey bool
+	}{
+		{
+			name:   "wrong issuer",
+			tamper: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example" },
+		},
+		{
+			name:   "wrong audience",
+			tamper: func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+		},
+		{
+			name:   "expired",
+			tamper: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name:   "missing expiry",
+			tamper: func(claims jwt.MapClaims) { delete(claims, "exp") },
+		},
+		{
+			name:   "issued in the future",
+			tamper: func(claims jwt.MapClaims) { claims["iat"] = time.Now().Add(time.Hour).Unix() },
+		},
+		{
+			name:   "wrong nonce",
+			tamper: func(claims jwt.MapClaims) { claims["nonce"] = "replayed-nonce" },
+		},
+		{
+			name:   "missing subject",
+			tamper: func(claims jwt.MapClaims) { delete(claims, "sub") },
+		},
+		{
+			name:       "unknown signing key",
+			foreignKey: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := oidctest.NewIssuer(t, clientID)
+			issuer.Tamper = tt.tamper
+			issuer.ForeignKey = tt.foreignKey
+
+			claims, err := login(t, issuer, newProvider(issuer), "nonce-1", "verifier-1", "verifier-1")
+			if !errors.Is(err, oidc.ErrInvalidIDToken) {
+				t.Fatalf("Exchange() = %+v, %v, want %v", claims, err, oidc.ErrInvalidIDToken)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsPKCEMismatch(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, clientID)
+
+	claims, err := login(t, issuer, newProvider(issuer), "nonce-1", "verifier-1", "another-verifier")
+	if err == nil {
+		t.Fatalf("Exchange() = %+v, want an error", claims)
+	}
+}
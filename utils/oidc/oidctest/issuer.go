@@ -0,0 +1,230 @@
+// Package oidctest runs a local OpenID Connect issuer for tests. It serves
+// discovery, JWKS and a token endpoint that checks the PKCE code verifier.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Account is the provider account a user logs in with
+type Account struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+// Issuer is a fake OpenID Connect provider
+type Issuer struct {
+	Server   *httptest.Server
+	ClientID string
+
+	// Tamper changes the claims of the next ID tokens before they are signed
+	Tamper func(claims jwt.MapClaims)
+	// ForeignKey signs the ID tokens with a key the JWKS does not publish
+	ForeignKey bool
+
+	key        *rsa.PrivateKey
+	foreignKey *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+type grant struct {
+	account       Account
+	nonce         string
+	codeChallenge string
+}
+
+const keyID = "test-key"
+
+// RSA keys are slow to generate, so all issuers of a test run share them
+var (
+	keysOnce          sync.Once
+	signingKey        *rsa.PrivateKey
+	foreignSigningKey *rsa.PrivateKey
+	keysErr           error
+)
+
+// NewIssuer starts an issuer that is shut down when the test ends
+func NewIssuer(t testing.TB, clientID string) *Issuer {
+	t.Helper()
+
+	keysOnce.Do(func() {
+		if signingKey, keysErr = rsa.GenerateKey(rand.Reader, 2048); keysErr != nil {
+			return
+		}
+		foreignSigningKey, keysErr = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	if keysErr != nil {
+		t.Fatalf("generate rsa keys: %v", keysErr)
+	}
+
+	issuer := &Issuer{
+		ClientID:   clientID,
+		key:        signingKey,
+		foreignKey: foreignSigningKey,
+		grants:     make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.handleDiscovery)
+	mux.HandleFunc("/jwks", issuer.handleJWKS)
+	mux.HandleFunc("/token", issuer.handleToken)
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Server.Close)
+
+	return issuer
+}
+
+func (i *Issuer) URL() string {
+	return i.Server.URL
+}
+
+// Authorize plays the user logging in at the provider's login page for the
+// given authorization URL. It returns the code and state the provider sends
+// back to the redirect URL.
+func (i *Issuer) Authorize(t testing.TB, authURL string, account Account) (code string, state string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	query := parsed.Query()
+
+	if !strings.HasPrefix(authURL, i.URL()+"/authorize?") {
+		t.Fatalf("authorization url %q does not point at the issuer", authURL)
+	}
+	if query.Get("client_id") != i.ClientID {
+		t.Fatalf("client_id = %q, want %q", query.Get("client_id"), i.ClientID)
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("authorization url %q has no S256 code challenge", authURL)
+	}
+
+	code = randomString(t)
+	i.mu.Lock()
+	i.grants[code] = grant{
+		account:       account,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	i.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+func (i *Issuer) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL(),
+		"authorization_endpoint": i.URL() + "/authorize",
+		"token_endpoint":         i.URL() + "/token",
+		"jwks_uri":               i.URL() + "/jwks",
+	})
+}
+
+func (i *Issuer) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	publicKey := i.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("client_id") != i.ClientID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// Codes can be redeemed once, and only with the verifier of their challenge
+	i.mu.Lock()
+	grant, ok := i.grants[r.PostForm.Get("code")]
+	delete(i.grants, r.PostForm.Get("code"))
+	tamper, foreignKey := i.Tamper, i.ForeignKey
+	i.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != grant.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            i.URL(),
+		"sub":            grant.account.Subject,
+		"aud":            i.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          grant.nonce,
+		"email":          grant.account.Email,
+		"email_verified": grant.account.EmailVerified,
+		"given_name":     grant.account.GivenName,
+		"family_name":    grant.account.FamilyName,
+	}
+	if tamper != nil {
+		tamper(claims)
+	}
+
+	key := i.key
+	if foreignKey {
+		key = i.foreignKey
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func randomString(t testing.TB) string {
+	t.Helper()
+
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,76 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the longest slug Make returns
+const MaxLength = 100
+
+// letters that do not decompose into an ASCII base letter
+var transliterations = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'đ': "d",
+	'ð': "d",
+	'ł': "l",
+	'þ': "th",
+	'ı': "i",
+	'&': " and ",
+}
+
+// Make turns text into a lowercase, URL friendly slug. Accented letters are
+// transliterated to ASCII and every other run of characters that are not ASCII
+// letters or digits becomes a single hyphen.
+func Make(text string) string {
+	text = transliterate(strings.ToLower(text))
+
+	var sb strings.Builder
+	hyphen := false
+
+	for _, r := range text {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			hyphen = false
+			continue
+		}
+
+		if !hyphen && sb.Len() > 0 {
+			sb.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	result := sb.String()
+	if len(result) > MaxLength {
+		result = result[:MaxLength]
+	}
+
+	return strings.TrimRight(result, "-")
+}
+
+func transliterate(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		if replacement, ok := transliterations[r]; ok {
+			sb.WriteString(replacement)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	// Decompose accented letters and drop the combining marks, e.g. "é" becomes "e"
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), sb.String())
+	if err != nil {
+		return sb.String()
+	}
+
+	return stripped
+}
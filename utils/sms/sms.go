@@ -0,0 +1,23 @@
+package sms
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// SMSUtil sends text messages. Providers implement it, so sending can be
+// switched from logging to a real gateway without touching the use cases.
+type SMSUtil interface {
+	SendSMS(phone string, message string) error
+}
+
+// logSMSUtil only writes messages to the log, for local development
+type logSMSUtil struct{}
+
+func NewLogSMSUtil() *logSMSUtil {
+	return &logSMSUtil{}
+}
+
+func (s *logSMSUtil) SendSMS(phone string, message string) error {
+	logrus.WithField("phone", phone).Info("SMS: " + message)
+	return nil
+}
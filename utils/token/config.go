@@ -2,37 +2,100 @@ package token
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 
 	msg "kreasi-nusantara-api/constants/message"
+	"kreasi-nusantara-api/drivers/redis"
 	http_util "kreasi-nusantara-api/utils/http"
 )
 
+// ErrSessionRevoked is returned for access tokens of a session that was logged out
+var ErrSessionRevoked = errors.New(msg.SESSION_REVOKED)
+
+var (
+	sessionStore     *redis.RedisClient
+	sessionStoreOnce sync.Once
+)
+
 type JWTClaim struct {
-	ID   uuid.UUID `json:"id"`
-	Role string    `json:"role"`
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	SessionID uuid.UUID `json:"sid"`
 	jwt.RegisteredClaims
 }
 
 func GetJWTConfig() echojwt.Config {
 	return echojwt.Config{
-		NewClaimsFunc: func(c echo.Context) jwt.Claims {
-			return new(JWTClaim)
-		},
-		ErrorHandler: jwtErrorHandler,
-		SigningKey:   []byte(os.Getenv("JWT_KEY")),
+		ParseTokenFunc: parseToken,
+		ErrorHandler:   jwtErrorHandler,
+	}
+}
+
+// RevokedSessionKey is the Redis key marking a revoked session. It only has to
+// live as long as the access tokens of the session.
+func RevokedSessionKey(sessionID uuid.UUID) string {
+	return "sessions:revoked:" + sessionID.String()
+}
+
+// parseToken verifies an access token and rejects it when it has no session or
+// its session was revoked
+func parseToken(c echo.Context, auth string) (interface{}, error) {
+	token, err := jwt.ParseWithClaims(auth, new(JWTClaim), func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected jwt signing method=%v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("JWT_KEY")), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued before sessions existed cannot be revoked, so they are
+	// treated as logged out
+	claims := token.Claims.(*JWTClaim)
+	if claims.SessionID == uuid.Nil || isSessionRevoked(claims.SessionID) {
+		return nil, ErrSessionRevoked
+	}
+
+	return token, nil
+}
+
+// isSessionRevoked lets requests through when Redis is unavailable, access
+// tokens are short-lived and revoked sessions can no longer be refreshed
+func isSessionRevoked(sessionID uuid.UUID) bool {
+	sessionStoreOnce.Do(func() {
+		sessionStore = redis.NewRedisClient()
+	})
+
+	revoked, err := sessionStore.Exists(RevokedSessionKey(sessionID))
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to check session revocation")
+		return false
 	}
+
+	return revoked
 }
 
 func jwtErrorHandler(c echo.Context, err error) error {
 	code := http.StatusUnauthorized
 
+	if errors.Is(err, ErrSessionRevoked) {
+		return http_util.HandleErrorResponse(
+			c,
+			code,
+			msg.SESSION_REVOKED,
+		)
+	}
+
 	if errors.Is(err, echojwt.ErrJWTInvalid) {
 		return http_util.HandleErrorResponse(
 			c,
@@ -1,6 +1,10 @@
 package token
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"os"
 	"time"
 
@@ -9,9 +13,21 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// AccessTokenTTL is how long an access token is accepted, clients renew it
+	// with their refresh token
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is how long a session stays signed in without being used
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type TokenUtil interface {
-	GenerateToken(id uuid.UUID, role string) (string, error)
+	GenerateToken(id uuid.UUID, role string, sessionId uuid.UUID) (string, error)
+	GenerateRefreshToken() (string, error)
+	HashRefreshToken(refreshToken string) string
 	GetClaims(c echo.Context) *JWTClaim
+	GenerateInviteToken(invitationID uuid.UUID, expiresAt time.Time) (string, error)
+	ParseInviteToken(inviteToken string) (uuid.UUID, error)
 }
 
 type tokenUtil struct{}
@@ -20,12 +36,13 @@ func NewTokenUtil() *tokenUtil {
 	return &tokenUtil{}
 }
 
-func (*tokenUtil) GenerateToken(id uuid.UUID, role string) (string, error) {
+func (*tokenUtil) GenerateToken(id uuid.UUID, role string, sessionId uuid.UUID) (string, error) {
 	claims := JWTClaim{
-		ID:   id,
-		Role: role,
+		ID:        id,
+		Role:      role,
+		SessionID: sessionId,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(30 * 24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 		},
 	}
 	unsignedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -36,6 +53,21 @@ func (*tokenUtil) GenerateToken(id uuid.UUID, role string) (string, error) {
 	return signedToken, nil
 }
 
+// GenerateRefreshToken returns a random opaque refresh token
+func (*tokenUtil) GenerateRefreshToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashRefreshToken returns the digest refresh tokens are stored and looked up by
+func (*tokenUtil) HashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
 func (*tokenUtil) GetClaims(c echo.Context) *JWTClaim {
 	user := c.Get("user").(*jwt.Token)
 	claims := user.Claims.(*JWTClaim)